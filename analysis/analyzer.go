@@ -0,0 +1,188 @@
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/threagile/threagile/model"
+)
+
+// Analyzer holds the settings for analyzing models. The same analyzer can be used for any number of models, as each
+// analysis keeps its state in its own AnalysisResult. As the analysis steps activate that state via the package-level
+// model variables (see model.WithModelState), analyses running concurrently wait for each other step by step though.
+type Analyzer struct {
+	Verbose                    bool
+	RAAPlugin                  string // RAA calculation plugin (.so shared object) file name
+	CustomRiskRules            map[string]model.CustomRiskRule
+	SkipRiskRules              string // comma-separated list of risk rules (by their ID) to skip
+	EnableRiskRules            string // comma-separated list of risk rules (by their ID) disabled by default to execute
+	IgnoreOrphanedRiskTracking bool
+	DiagramDPI                 int
+	TemplateFilename           string // background pdf file of the report
+	BuildTimestamp             string
+	KeepDiagramSourceFiles     bool
+	Baseline                   string // risks.json file of a previous run to classify the risks as new, unchanged, or resolved
+	SASTReports                string // comma-separated SARIF files (or folders of them) to confirm risks by SAST findings
+	SASTRaiseLikelihood        bool   // raise the exploitation likelihood of risks confirmed by SAST findings
+	FlowLog                    string // flow log (like VPC flow logs) to verify the communication links against
+}
+
+// AnalysisResult owns everything belonging to the analysis of one model: the model input, the parsed model and the
+// generated risks (both within State) as well as the diagnostics found during validation.
+type AnalysisResult struct {
+	ModelInput     model.ModelInput
+	ModelFilenames []string // the model file followed by all files included by it (in order of inclusion)
+	Diagnostics    []model.ModelDiagnostic
+	IntroTextRAA   string
+	State          *model.ModelState
+
+	analyzer                                  *Analyzer
+	modelElementPositions                     map[string]map[string]yamlPosition // keyed by model file and then by element path
+	deferredRiskTrackingDueToWildcardMatching map[string]model.RiskTracking
+	renderedDiagrams                          map[string]bool // the PNG files rendered (to be embedded by the HTML report)
+}
+
+// Analyze executes all analysis steps (parsing, RAA calculation, risk generation and risk tracking) for the model file.
+// Model validation problems are returned as model.ModelValidationError containing all diagnostics found.
+func (analyzer *Analyzer) Analyze(modelFilename string) (*AnalysisResult, error) {
+	result, err := analyzer.Parse(modelFilename)
+	if err != nil {
+		return result, err
+	}
+	if err = result.ApplyRAA(); err != nil {
+		return result, err
+	}
+	if len(analyzer.FlowLog) > 0 { // before the risk generation, as undeclared observed flows are risks
+		if err = result.ApplyFlowLog(analyzer.FlowLog); err != nil {
+			return result, err
+		}
+	}
+	if err = result.GenerateRisks(); err != nil {
+		return result, err
+	}
+	if len(analyzer.SASTReports) > 0 {
+		if err = result.ApplySASTFindings(analyzer.SASTReports); err != nil {
+			return result, err
+		}
+	}
+	if err = result.ApplyRiskTracking(); err != nil {
+		return result, err
+	}
+	if len(analyzer.Baseline) > 0 {
+		return result, result.ApplyBaseline(analyzer.Baseline)
+	}
+	return result, nil
+}
+
+// Parse reads and validates the model file (including all files referenced via "includes")
+func (analyzer *Analyzer) Parse(modelFilename string) (result *AnalysisResult, err error) {
+	result = &AnalysisResult{
+		ModelFilenames:        []string{modelFilename},
+		Diagnostics:           make([]model.ModelDiagnostic, 0),
+		State:                 model.NewModelState(),
+		analyzer:              analyzer,
+		modelElementPositions: make(map[string]map[string]yamlPosition),
+		deferredRiskTrackingDueToWildcardMatching: make(map[string]model.RiskTracking),
+		renderedDiagrams: make(map[string]bool),
+	}
+	defer recoverError(&err)
+	result.WithModelState(func() {
+		result.parseModel(modelFilename)
+	})
+	return result, nil
+}
+
+func (result *AnalysisResult) ApplyRAA() (err error) {
+	defer recoverError(&err)
+	result.WithModelState(func() {
+		result.IntroTextRAA = result.applyRAA()
+	})
+	return nil
+}
+
+func (result *AnalysisResult) GenerateRisks() (err error) {
+	defer recoverError(&err)
+	result.WithModelState(result.applyRiskGeneration)
+	return nil
+}
+
+// ApplyRiskTracking matches the risk tracking of the model to the generated risks
+func (result *AnalysisResult) ApplyRiskTracking() (err error) {
+	defer recoverError(&err)
+	result.WithModelState(func() {
+		result.applyWildcardRiskTrackingEvaluation()
+		result.checkRiskTracking()
+		result.sortModelDiagnostics()
+	})
+	return nil
+}
+
+// ApplyBaseline reads the risks of a previous run (as written by WriteRisksJSON) to compare the current risks against
+func (result *AnalysisResult) ApplyBaseline(baselineFilename string) (err error) {
+	defer recoverError(&err)
+	/* #nosec baselineFilename is not tainted (read from command-line params) */
+	jsonBytes, err := ioutil.ReadFile(baselineFilename)
+	checkErr(err)
+	baselineRisks := make([]model.BaselineRisk, 0)
+	if err = json.Unmarshal(jsonBytes, &baselineRisks); err != nil {
+		panic(errors.New("unable to read baseline risks from " + baselineFilename + ": " + err.Error()))
+	}
+	result.WithModelState(func() {
+		model.ParsedModelRoot.BaselineRisks = baselineRisks
+		model.ParsedModelRoot.BaselineRisksBySyntheticId = model.BaselineRisksBySyntheticId(baselineRisks)
+	})
+	return nil
+}
+
+// WithModelState runs the action with the package-level model variables pointing to this result, which is required
+// for all code still relying on them (like the risk rules, model macros, and the report generation)
+func (result *AnalysisResult) WithModelState(action func()) {
+	model.WithModelState(result.State, action)
+}
+
+func (result *AnalysisResult) ParsedModel() model.ParsedModel {
+	return result.State.ParsedModel
+}
+
+func (result *AnalysisResult) HasBaseline() bool {
+	return result.State.ParsedModel.BaselineRisks != nil
+}
+
+func (result *AnalysisResult) HasSASTFindings() bool {
+	return result.State.ParsedModel.SASTFindings != nil
+}
+
+func (result *AnalysisResult) HasObservedFlows() bool {
+	return result.State.ParsedModel.ObservedFlows != nil
+}
+
+// VerifyFlows compares the observed flows (see Analyzer.FlowLog) with the declared communication links
+func (result *AnalysisResult) VerifyFlows() (verification model.FlowVerification) {
+	result.WithModelState(func() {
+		verification = model.VerifyFlows()
+	})
+	return verification
+}
+
+func (result *AnalysisResult) GeneratedRisksByCategory() map[model.RiskCategory][]model.Risk {
+	return result.State.GeneratedRisksByCategory
+}
+
+// recoverError turns a panic (the way errors are raised throughout the model processing) into the returned error
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		if recoveredError, ok := r.(error); ok {
+			*err = recoveredError
+		} else {
+			*err = errors.New(fmt.Sprintf("%v", r))
+		}
+	}
+}
+
+func checkErr(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlPosition struct {
+	line, column int
+}
+
+var yamlErrorLine = regexp.MustCompile(`line ([0-9]+): `)
+
+// remembers the position of each element of a model file keyed by its slash-separated path
+// (like "technical_assets/Some Title/communication_links/Some Link/protocol"), so that diagnostics can point to it
+func (result *AnalysisResult) registerModelElementPositions(filename string, content []byte) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return
+	}
+	positions := make(map[string]yamlPosition)
+	collectYamlPositions(&document, nil, positions)
+	result.modelElementPositions[filename] = positions
+}
+
+func collectYamlPositions(node *yaml.Node, path []string, positions map[string]yamlPosition) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectYamlPositions(child, path, positions)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := appendPath(path, key.Value)
+			positions[strings.Join(childPath, "/")] = yamlPosition{line: key.Line, column: key.Column}
+			collectYamlPositions(value, childPath, positions)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			childPath := appendPath(path, strconv.Itoa(i))
+			positions[strings.Join(childPath, "/")] = yamlPosition{line: child.Line, column: child.Column}
+			collectYamlPositions(child, childPath, positions)
+		}
+	}
+}
+
+func appendPath(path []string, elements ...string) []string {
+	result := make([]string, 0, len(path)+len(elements))
+	result = append(result, path...)
+	return append(result, elements...)
+}
+
+// finds the model file and position defining the element of the given path (or else its closest parent element),
+// searching the given files or all model files (in order of inclusion) when none are given
+func (result *AnalysisResult) locateModelElement(path []string, filenames ...string) (string, yamlPosition) {
+	if len(filenames) == 0 {
+		filenames = result.ModelFilenames
+	}
+	for length := len(path); length > 0; length-- {
+		key := strings.Join(path[:length], "/")
+		for _, filename := range filenames {
+			if position, found := result.modelElementPositions[filename][key]; found {
+				return filename, position
+			}
+		}
+	}
+	if len(filenames) > 0 {
+		return filenames[0], yamlPosition{}
+	}
+	return "", yamlPosition{}
+}
+
+func (result *AnalysisResult) addModelDiagnostic(severity model.DiagnosticSeverity, code, message string, filenames []string, path []string) {
+	filename, position := result.locateModelElement(path, filenames...)
+	result.Diagnostics = append(result.Diagnostics, model.ModelDiagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  message,
+		Path:     strings.Join(path, "/"),
+		File:     filename,
+		Line:     position.line,
+		Column:   position.column,
+	})
+}
+
+func (result *AnalysisResult) addModelError(code, message string, path ...string) {
+	result.addModelDiagnostic(model.ErrorDiagnostic, code, message, nil, path)
+}
+
+func (result *AnalysisResult) addModelWarning(code, message string, path ...string) {
+	result.addModelDiagnostic(model.WarningDiagnostic, code, message, nil, path)
+}
+
+func (result *AnalysisResult) addModelErrorInFile(code, message, filename string, path ...string) {
+	result.addModelDiagnostic(model.ErrorDiagnostic, code, message, []string{filename}, path)
+}
+
+func (result *AnalysisResult) addModelDiagnosticInFile(severity model.DiagnosticSeverity, code, message, filename string, line int) {
+	result.Diagnostics = append(result.Diagnostics, model.ModelDiagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  message,
+		File:     filename,
+		Line:     line,
+	})
+}
+
+func (result *AnalysisResult) addYamlSyntaxDiagnostic(filename string, err error) {
+	line := 0
+	if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+	message := strings.Join(strings.Fields(yamlErrorLine.ReplaceAllString(strings.TrimPrefix(err.Error(), "yaml: "), "")), " ") // on a single line
+	result.addModelDiagnosticInFile(model.ErrorDiagnostic, model.DiagnosticYamlSyntax, message, filename, line)
+}
+
+func (result *AnalysisResult) addOrphanedRiskTrackingDiagnostic(message, syntheticRiskId string) {
+	if result.analyzer.IgnoreOrphanedRiskTracking {
+		result.addModelWarning(model.DiagnosticOrphanedRiskTracking, message, "risk_tracking", syntheticRiskId)
+	} else {
+		result.addModelError(model.DiagnosticOrphanedRiskTracking, message, "risk_tracking", syntheticRiskId)
+	}
+}
+
+// abortOnModelErrors stops the processing with all diagnostics collected so far when any of them is an error
+func (result *AnalysisResult) abortOnModelErrors() {
+	if model.ContainsErrorDiagnostics(result.Diagnostics) {
+		result.sortModelDiagnostics()
+		panic(model.ModelValidationError{Diagnostics: result.Diagnostics})
+	}
+}
+
+// sorts the diagnostics by their position (files in order of inclusion) as the model maps are iterated in random order
+func (result *AnalysisResult) sortModelDiagnostics() {
+	fileIndex := func(filename string) int {
+		for i, modelInputFilename := range result.ModelFilenames {
+			if modelInputFilename == filename {
+				return i
+			}
+		}
+		return len(result.ModelFilenames)
+	}
+	sort.SliceStable(result.Diagnostics, func(i, j int) bool {
+		first, second := result.Diagnostics[i], result.Diagnostics[j]
+		if first.File != second.File {
+			return fileIndex(first.File) < fileIndex(second.File)
+		}
+		if first.Line != second.Line {
+			return first.Line < second.Line
+		}
+		if first.Column != second.Column {
+			return first.Column < second.Column
+		}
+		return first.Message < second.Message
+	})
+}
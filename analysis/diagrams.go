@@ -0,0 +1,636 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/colors"
+	"github.com/threagile/threagile/model"
+)
+
+const graphvizDataFlowDiagramConversionCall, graphvizDataAssetDiagramConversionCall = "render-data-flow-diagram.sh", "render-data-asset-diagram.sh"
+
+func hash(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("%v", h.Sum32())
+}
+
+func (result *AnalysisResult) writeDataAssetDiagramGraphvizDOT(diagramFilenameDOT string, dpi int) *os.File {
+	if result.analyzer.Verbose {
+		fmt.Println("Writing data asset diagram input")
+	}
+	var dotContent strings.Builder
+	dotContent.WriteString("digraph generatedModel { concentrate=true \n")
+
+	// Metadata init ===============================================================================
+	dotContent.WriteString(`	graph [
+		dpi=` + strconv.Itoa(dpi) + `
+		fontname="Verdana"
+		labelloc="c"
+		fontsize="20"
+		splines=false
+		rankdir="LR"
+		nodesep=1.0
+		ranksep=3.0
+        outputorder="nodesfirst"
+	];
+	node [
+		fontcolor="white"
+		fontname="Verdana"
+		fontsize="20"
+	];
+	edge [
+		shape="none"
+		fontname="Verdana"
+		fontsize="18"
+	];
+`)
+
+	// Technical Assets ===============================================================================
+	techAssets := make([]model.TechnicalAsset, 0)
+	for _, techAsset := range model.ParsedModelRoot.TechnicalAssets {
+		techAssets = append(techAssets, techAsset)
+	}
+	sort.Sort(model.ByOrderAndIdSort(techAssets))
+	for _, technicalAsset := range techAssets {
+		if len(technicalAsset.DataAssetsStored) > 0 || len(technicalAsset.DataAssetsProcessed) > 0 {
+			dotContent.WriteString(makeTechAssetNode(technicalAsset, true))
+			dotContent.WriteString("\n")
+		}
+	}
+
+	// Data Assets ===============================================================================
+	dataAssets := make([]model.DataAsset, 0)
+	for _, dataAsset := range model.ParsedModelRoot.DataAssets {
+		dataAssets = append(dataAssets, dataAsset)
+	}
+	sort.Sort(model.ByDataAssetDataBreachProbabilityAndTitleSort(dataAssets))
+	for _, dataAsset := range dataAssets {
+		dotContent.WriteString(makeDataAssetNode(dataAsset))
+		dotContent.WriteString("\n")
+	}
+
+	// Data Asset to Tech Asset links ===============================================================================
+	for _, technicalAsset := range techAssets {
+		for _, sourceId := range technicalAsset.DataAssetsStored {
+			targetId := technicalAsset.Id
+			dotContent.WriteString("\n")
+			dotContent.WriteString(hash(sourceId) + " -> " + hash(targetId) +
+				` [ color="blue" style="solid" ];`)
+			dotContent.WriteString("\n")
+		}
+		for _, sourceId := range technicalAsset.DataAssetsProcessed {
+			if !model.Contains(technicalAsset.DataAssetsStored, sourceId) { // here only if not already drawn above
+				targetId := technicalAsset.Id
+				dotContent.WriteString("\n")
+				dotContent.WriteString(hash(sourceId) + " -> " + hash(targetId) +
+					` [ color="#666666" style="dashed" ];`)
+				dotContent.WriteString("\n")
+			}
+		}
+	}
+
+	dotContent.WriteString("}")
+
+	// Write the DOT file
+	file, err := os.Create(diagramFilenameDOT)
+	checkErr(err)
+	defer file.Close()
+	_, err = fmt.Fprintln(file, dotContent.String())
+	checkErr(err)
+	return file
+}
+
+// dataFlowDiagramHighlights selects the technical assets and communication links to emphasize in the data-flow diagram
+// (the zero value draws the plain diagram)
+type dataFlowDiagramHighlights struct {
+	onlyTechnicalAssetIds map[string]bool // when set, all other technical assets (and their links) are left out
+	entryIds, targetIds   map[string]bool
+	linkIds               map[string]bool
+}
+
+func (highlights dataFlowDiagramHighlights) includes(technicalAssetId string) bool {
+	return highlights.onlyTechnicalAssetIds == nil || highlights.onlyTechnicalAssetIds[technicalAssetId]
+}
+
+func attackPathHighlights(attackPaths []model.AttackPath) dataFlowDiagramHighlights {
+	highlights := dataFlowDiagramHighlights{entryIds: make(map[string]bool), targetIds: make(map[string]bool), linkIds: make(map[string]bool)}
+	for _, attackPath := range attackPaths {
+		highlights.entryIds[attackPath.EntryTechnicalAssetId] = true
+		highlights.targetIds[attackPath.TargetTechnicalAssetId] = true
+		for _, linkId := range attackPath.CommunicationLinkIds {
+			highlights.linkIds[linkId] = true
+		}
+	}
+	return highlights
+}
+
+func blastRadiusHighlights(blastRadius model.BlastRadius) dataFlowDiagramHighlights {
+	highlights := dataFlowDiagramHighlights{onlyTechnicalAssetIds: make(map[string]bool), entryIds: make(map[string]bool), targetIds: make(map[string]bool), linkIds: make(map[string]bool)}
+	for _, technicalAsset := range blastRadius.TechnicalAssets {
+		highlights.onlyTechnicalAssetIds[technicalAsset.Id] = true
+	}
+	highlights.targetIds[blastRadius.CompromisedTechnicalAssetId] = true
+	for _, linkId := range blastRadius.CommunicationLinkIds {
+		highlights.linkIds[linkId] = true
+	}
+	return highlights
+}
+
+func (result *AnalysisResult) writeDataFlowDiagramGraphvizDOT(diagramFilenameDOT string, dpi int, highlights dataFlowDiagramHighlights) *os.File {
+	if result.analyzer.Verbose {
+		fmt.Println("Writing data flow diagram input")
+	}
+	var dotContent strings.Builder
+	dotContent.WriteString("digraph generatedModel { concentrate=false \n")
+
+	// Metadata init ===============================================================================
+	drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks := true
+	tweaks := ""
+	if model.ParsedModelRoot.DiagramTweakNodesep > 0 {
+		tweaks += "\n		nodesep=\"" + strconv.Itoa(model.ParsedModelRoot.DiagramTweakNodesep) + "\""
+	}
+	if model.ParsedModelRoot.DiagramTweakRanksep > 0 {
+		tweaks += "\n		ranksep=\"" + strconv.Itoa(model.ParsedModelRoot.DiagramTweakRanksep) + "\""
+	}
+	suppressBidirectionalArrows := true
+	splines := "ortho"
+	if len(model.ParsedModelRoot.DiagramTweakEdgeLayout) > 0 {
+		switch model.ParsedModelRoot.DiagramTweakEdgeLayout {
+		case "spline":
+			splines = "spline"
+			drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks = false
+		case "polyline":
+			splines = "polyline"
+			drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks = false
+		case "ortho":
+			splines = "ortho"
+			suppressBidirectionalArrows = true
+		case "curved":
+			splines = "curved"
+			drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks = false
+		case "false":
+			splines = "false"
+			drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks = false
+		default:
+			panic(errors.New("unknown value for diagram_tweak_suppress_edge_labels (spline, polyline, ortho, curved, false): " +
+				model.ParsedModelRoot.DiagramTweakEdgeLayout))
+		}
+	}
+	rankdir := "TB"
+	if model.ParsedModelRoot.DiagramTweakLayoutLeftToRight {
+		rankdir = "LR"
+	}
+	modelTitle := ""
+	addModelTitle := false
+	if addModelTitle {
+		modelTitle = `label="` + model.ParsedModelRoot.Title + `"`
+	}
+	dotContent.WriteString(`	graph [ ` + modelTitle + `
+		labelloc=t
+		fontname="Verdana"
+		fontsize=40
+        outputorder="nodesfirst"
+		dpi=` + strconv.Itoa(dpi) + `
+		splines=` + splines + `
+		rankdir="` + rankdir + `"
+` + tweaks + `
+	];
+	node [
+		fontname="Verdana"
+		fontsize="20"
+	];
+	edge [
+		shape="none"
+		fontname="Verdana"
+		fontsize="18"
+	];
+`)
+
+	// Trust Boundaries ===============================================================================
+	var subgraphSnippetsById = make(map[string]string)
+	// first create them in memory (see the link replacement below for nested trust boundaries) - otherwise in Go ranging over map is random order
+	// range over them in sorted (hence re-producible) way:
+	keys := make([]string, 0)
+	for k, _ := range model.ParsedModelRoot.TrustBoundaries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		trustBoundary := model.ParsedModelRoot.TrustBoundaries[key]
+		var snippet strings.Builder
+		includedAssetsInside := highlights.onlyTechnicalAssetIds == nil
+		for _, technicalAssetInside := range trustBoundary.RecursivelyAllTechnicalAssetIDsInside() {
+			includedAssetsInside = includedAssetsInside || highlights.includes(technicalAssetInside)
+		}
+		if (len(trustBoundary.TechnicalAssetsInside) > 0 || len(trustBoundary.TrustBoundariesNested) > 0) && includedAssetsInside {
+			if drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks {
+				// see https://stackoverflow.com/questions/17247455/how-do-i-add-extra-space-between-clusters?noredirect=1&lq=1
+				snippet.WriteString("\n subgraph cluster_space_boundary_for_layout_only_1" + hash(trustBoundary.Id) + " {\n")
+				snippet.WriteString(`	graph [
+                                              dpi=` + strconv.Itoa(dpi) + `
+											  label=<<table border="0" cellborder="0" cellpadding="0" bgcolor="#FFFFFF55"><tr><td><b> </b></td></tr></table>>
+											  fontsize="21"
+											  style="invis"
+											  color="green"
+											  fontcolor="green"
+											  margin="50.0"
+											  penwidth="6.5"
+                                              outputorder="nodesfirst"
+											];`)
+			}
+			snippet.WriteString("\n subgraph cluster_" + hash(trustBoundary.Id) + " {\n")
+			color, fontColor, bgColor, style, fontname := colors.RgbHexColorTwilight(), colors.RgbHexColorTwilight() /*"#550E0C"*/, "#FAFAFA", "dashed", "Verdana"
+			penwidth := 4.5
+			if len(trustBoundary.TrustBoundariesNested) > 0 {
+				//color, fontColor, style, fontname = colors.Blue, colors.Blue, "dashed", "Verdana"
+				penwidth = 5.5
+			}
+			if len(trustBoundary.ParentTrustBoundaryID()) > 0 {
+				bgColor = "#F1F1F1"
+			}
+			if trustBoundary.Type == model.NetworkPolicyNamespaceIsolation {
+				fontColor, bgColor = "#222222", "#DFF4FF"
+			}
+			if trustBoundary.Type == model.ExecutionEnvironment {
+				fontColor, bgColor, style = "#555555", "#FFFFF0", "dotted"
+			}
+			snippet.WriteString(`	graph [
+      dpi=` + strconv.Itoa(dpi) + `
+      label=<<table border="0" cellborder="0" cellpadding="0"><tr><td><b>` + trustBoundary.Title + `</b> (` + trustBoundary.Type.String() + `)</td></tr></table>>
+      fontsize="21"
+      style="` + style + `"
+      color="` + color + `"
+      bgcolor="` + bgColor + `"
+      fontcolor="` + fontColor + `"
+      fontname="` + fontname + `"
+      penwidth="` + fmt.Sprintf("%f", penwidth) + `"
+      forcelabels=true
+      outputorder="nodesfirst"
+	  margin="50.0"
+    ];`)
+			snippet.WriteString("\n")
+			keys := trustBoundary.TechnicalAssetsInside
+			sort.Strings(keys)
+			for _, technicalAssetInside := range keys {
+				if !highlights.includes(technicalAssetInside) {
+					continue
+				}
+				//log.Println("About to add technical asset link to trust boundary: ", technicalAssetInside)
+				technicalAsset := model.ParsedModelRoot.TechnicalAssets[technicalAssetInside]
+				snippet.WriteString(hash(technicalAsset.Id))
+				snippet.WriteString(";\n")
+			}
+			keys = trustBoundary.TrustBoundariesNested
+			sort.Strings(keys)
+			for _, trustBoundaryNested := range keys {
+				//log.Println("About to add nested trust boundary to trust boundary: ", trustBoundaryNested)
+				trustBoundaryNested := model.ParsedModelRoot.TrustBoundaries[trustBoundaryNested]
+				snippet.WriteString("LINK-NEEDS-REPLACED-BY-cluster_" + hash(trustBoundaryNested.Id))
+				snippet.WriteString(";\n")
+			}
+			snippet.WriteString(" }\n\n")
+			if drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks {
+				snippet.WriteString(" }\n\n")
+			}
+		}
+		subgraphSnippetsById[hash(trustBoundary.Id)] = snippet.String()
+	}
+	// here replace links and remove from map after replacement (i.e. move snippet into nested)
+	for i, _ := range subgraphSnippetsById {
+		re := regexp.MustCompile(`LINK-NEEDS-REPLACED-BY-cluster_([0-9]*);`)
+		for {
+			matches := re.FindStringSubmatch(subgraphSnippetsById[i])
+			if len(matches) > 0 {
+				embeddedSnippet := " //nested:" + subgraphSnippetsById[matches[1]]
+				subgraphSnippetsById[i] = strings.ReplaceAll(subgraphSnippetsById[i], matches[0], embeddedSnippet)
+				subgraphSnippetsById[matches[1]] = "" // to something like remove it
+			} else {
+				break
+			}
+		}
+	}
+	// now write them all
+	keys = make([]string, 0)
+	for k, _ := range subgraphSnippetsById {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		snippet := subgraphSnippetsById[key]
+		dotContent.WriteString(snippet)
+	}
+
+	// Technical Assets ===============================================================================
+	// first create them in memory (see the link replacement below for nested trust boundaries) - otherwise in Go ranging over map is random order
+	// range over them in sorted (hence re-producible) way:
+	// Convert map to slice of values:
+	techAssets := []model.TechnicalAsset{}
+	for _, techAsset := range model.ParsedModelRoot.TechnicalAssets {
+		if highlights.includes(techAsset.Id) {
+			techAssets = append(techAssets, techAsset)
+		}
+	}
+	sort.Sort(model.ByOrderAndIdSort(techAssets))
+	for _, technicalAsset := range techAssets {
+		dotContent.WriteString(makeTechAssetNode(technicalAsset, false))
+		dotContent.WriteString("\n")
+	}
+	for _, technicalAsset := range techAssets {
+		if highlights.targetIds[technicalAsset.Id] {
+			dotContent.WriteString("  " + hash(technicalAsset.Id) + ` [ color="` + colors.Red + `" penwidth="6.5" ];` + "\n")
+		} else if highlights.entryIds[technicalAsset.Id] {
+			dotContent.WriteString("  " + hash(technicalAsset.Id) + ` [ color="` + colors.Amber + `" penwidth="6.5" ];` + "\n")
+		}
+	}
+
+	// Data Flows (Technical Communication Links) ===============================================================================
+	for _, technicalAsset := range techAssets {
+		for _, dataFlow := range technicalAsset.CommunicationLinks {
+			if !highlights.includes(dataFlow.TargetId) {
+				continue
+			}
+			sourceId := technicalAsset.Id
+			targetId := dataFlow.TargetId
+			//log.Println("About to add link from", sourceId, "to", targetId, "with id", dataFlow.Id)
+			var arrowStyle, arrowColor, readOrWriteHead, readOrWriteTail string
+			if dataFlow.Readonly {
+				readOrWriteHead = "empty"
+				readOrWriteTail = "odot"
+			} else {
+				readOrWriteHead = "normal"
+				readOrWriteTail = "dot"
+			}
+			dir := "forward"
+			if dataFlow.IsBidirectional() {
+				if !suppressBidirectionalArrows { // as it does not work as bug in grahviz with ortho: https://gitlab.com/graphviz/graphviz/issues/144
+					dir = "both"
+				}
+			}
+			arrowStyle = ` style="` + dataFlow.DetermineArrowLineStyle() + `" penwidth="` + dataFlow.DetermineArrowPenWidth() + `" arrowtail="` + readOrWriteTail + `" arrowhead="` + readOrWriteHead + `" dir="` + dir + `" arrowsize="2.0" `
+			arrowColor = ` color="` + dataFlow.DetermineArrowColor() + `"`
+			if highlights.linkIds[dataFlow.Id] {
+				arrowStyle = ` style="solid" penwidth="6.5" arrowtail="` + readOrWriteTail + `" arrowhead="` + readOrWriteHead + `" dir="` + dir + `" arrowsize="2.0" `
+				arrowColor = ` color="` + colors.Red + `"`
+			}
+			tweaks := ""
+			if dataFlow.DiagramTweakWeight > 0 {
+				tweaks += " weight=\"" + strconv.Itoa(dataFlow.DiagramTweakWeight) + "\" "
+			}
+
+			dotContent.WriteString("\n")
+			dotContent.WriteString("  " + hash(sourceId) + " -> " + hash(targetId) +
+				` [` + arrowColor + ` ` + arrowStyle + tweaks + ` constraint=` + strconv.FormatBool(dataFlow.DiagramTweakConstraint) + ` `)
+			if !model.ParsedModelRoot.DiagramTweakSuppressEdgeLabels {
+				dotContent.WriteString(` xlabel="` + encode(dataFlow.Protocol.String()) + `" fontcolor="` + dataFlow.DetermineLabelColor() + `" `)
+			}
+			dotContent.WriteString(" ];\n")
+		}
+	}
+
+	if highlights.onlyTechnicalAssetIds == nil { // as the tweaks would otherwise add the technical assets left out again
+		dotContent.WriteString(makeDiagramInvisibleConnectionsTweaks())
+		dotContent.WriteString(makeDiagramSameRankNodeTweaks())
+	}
+
+	dotContent.WriteString("}")
+
+	//fmt.Println(dotContent.String())
+
+	// Write the DOT file
+	file, err := os.Create(diagramFilenameDOT)
+	checkErr(err)
+	defer file.Close()
+	_, err = fmt.Fprintln(file, dotContent.String())
+	checkErr(err)
+	return file
+}
+
+func makeDiagramInvisibleConnectionsTweaks() string {
+	// see https://stackoverflow.com/questions/2476575/how-to-control-node-placement-in-graphviz-i-e-avoid-edge-crossings
+	tweak := ""
+	if len(model.ParsedModelRoot.DiagramTweakInvisibleConnectionsBetweenAssets) > 0 {
+		for _, invisibleConnections := range model.ParsedModelRoot.DiagramTweakInvisibleConnectionsBetweenAssets {
+			assetIDs := strings.Split(invisibleConnections, ":")
+			if len(assetIDs) == 2 {
+				tweak += "\n" + hash(assetIDs[0]) + " -> " + hash(assetIDs[1]) + " [style=invis]; \n"
+			}
+		}
+	}
+	return tweak
+}
+
+func makeDiagramSameRankNodeTweaks() string {
+	// see https://stackoverflow.com/questions/25734244/how-do-i-place-nodes-on-the-same-level-in-dot
+	tweak := ""
+	if len(model.ParsedModelRoot.DiagramTweakSameRankAssets) > 0 {
+		for _, sameRank := range model.ParsedModelRoot.DiagramTweakSameRankAssets {
+			assetIDs := strings.Split(sameRank, ":")
+			if len(assetIDs) > 0 {
+				tweak += "{ rank=same; "
+				for _, id := range assetIDs {
+					tweak += " " + hash(id) + "; "
+				}
+				tweak += " }"
+			}
+		}
+	}
+	return tweak
+}
+
+func makeTechAssetNode(technicalAsset model.TechnicalAsset, simplified bool) string {
+	if simplified {
+		color := colors.RgbHexColorOutOfScope()
+		if !technicalAsset.OutOfScope {
+			risks := technicalAsset.GeneratedRisks()
+			switch model.HighestSeverityStillAtRisk(risks) {
+			case model.CriticalSeverity:
+				color = colors.RgbHexColorCriticalRisk()
+			case model.HighSeverity:
+				color = colors.RgbHexColorHighRisk()
+			case model.ElevatedSeverity:
+				color = colors.RgbHexColorElevatedRisk()
+			case model.MediumSeverity:
+				color = colors.RgbHexColorMediumRisk()
+			case model.LowSeverity:
+				color = colors.RgbHexColorLowRisk()
+			default:
+				color = "#444444" // since black is too dark here as fill color
+			}
+			if len(model.ReduceToOnlyStillAtRisk(risks)) == 0 {
+				color = "#444444" // since black is too dark here as fill color
+			}
+		}
+		return "  " + hash(technicalAsset.Id) + ` [ shape="box" style="filled" fillcolor="` + color + `" 
+				label=<<b>` + encode(technicalAsset.Title) + `</b>> penwidth="3.0" color="` + color + `" ];
+				`
+	} else {
+		var shape, title string
+		var lineBreak = ""
+		switch technicalAsset.Type {
+		case model.ExternalEntity:
+			shape = "box"
+			title = technicalAsset.Title
+		case model.Process:
+			shape = "ellipse"
+			title = technicalAsset.Title
+		case model.Datastore:
+			shape = "cylinder"
+			title = technicalAsset.Title
+			if technicalAsset.Redundant {
+				lineBreak = "<br/>"
+			}
+		}
+
+		if technicalAsset.UsedAsClientByHuman {
+			shape = "octagon"
+		}
+
+		// RAA = Relative Attacker Attractiveness
+		raa := technicalAsset.RAA
+		var attackerAttractivenessLabel string
+		if technicalAsset.OutOfScope {
+			attackerAttractivenessLabel = "<font point-size=\"15\" color=\"#603112\">RAA: out of scope</font>"
+		} else {
+			attackerAttractivenessLabel = "<font point-size=\"15\" color=\"#603112\">RAA: " + fmt.Sprintf("%.0f", raa) + " %</font>"
+		}
+
+		compartmentBorder := "0"
+		if technicalAsset.MultiTenant {
+			compartmentBorder = "1"
+		}
+
+		return "  " + hash(technicalAsset.Id) + ` [
+	label=<<table border="0" cellborder="` + compartmentBorder + `" cellpadding="2" cellspacing="0"><tr><td><font point-size="15" color="` + colors.DarkBlue + `">` + lineBreak + technicalAsset.Technology.String() + `</font><br/><font point-size="15" color="` + colors.LightGray + `">` + technicalAsset.Size.String() + `</font></td></tr><tr><td><b><font color="` + technicalAsset.DetermineLabelColor() + `">` + encode(title) + `</font></b><br/></td></tr><tr><td>` + attackerAttractivenessLabel + `</td></tr></table>>
+	shape=` + shape + ` style="` + technicalAsset.DetermineShapeBorderLineStyle() + `,` + technicalAsset.DetermineShapeStyle() + `" penwidth="` + technicalAsset.DetermineShapeBorderPenWidth() + `" fillcolor="` + technicalAsset.DetermineShapeFillColor() + `" 
+	peripheries=` + strconv.Itoa(technicalAsset.DetermineShapePeripheries()) + `
+	color="` + technicalAsset.DetermineShapeBorderColor() + "\"\n  ]; "
+	}
+}
+
+func makeDataAssetNode(dataAsset model.DataAsset) string {
+	var color string
+	switch dataAsset.IdentifiedDataBreachProbabilityStillAtRisk() {
+	case model.Probable:
+		color = colors.RgbHexColorHighRisk()
+	case model.Possible:
+		color = colors.RgbHexColorMediumRisk()
+	case model.Improbable:
+		color = colors.RgbHexColorLowRisk()
+	default:
+		color = "#444444" // since black is too dark here as fill color
+	}
+	if !dataAsset.IsDataBreachPotentialStillAtRisk() {
+		color = "#444444" // since black is too dark here as fill color
+	}
+	return "  " + hash(dataAsset.Id) + ` [ label=<<b>` + encode(dataAsset.Title) + `</b>> penwidth="3.0" style="filled" fillcolor="` + color + `" color="` + color + "\"\n  ]; "
+}
+
+func encode(value string) string {
+	return strings.ReplaceAll(value, "&", "&amp;")
+}
+
+func (result *AnalysisResult) renderDataFlowDiagramGraphvizImage(dotFile *os.File, targetDir string, diagramFilenamePNG string) {
+	if result.analyzer.Verbose {
+		fmt.Println("Rendering data flow diagram input")
+	}
+	// tmp files
+	tmpFileDOT, err := ioutil.TempFile(model.TempFolder, "diagram-*-.gv")
+	checkErr(err)
+	defer os.Remove(tmpFileDOT.Name())
+
+	tmpFilePNG, err := ioutil.TempFile(model.TempFolder, "diagram-*-.png")
+	checkErr(err)
+	defer os.Remove(tmpFilePNG.Name())
+
+	// copy into tmp file as input
+	input, err := ioutil.ReadFile(dotFile.Name())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	err = ioutil.WriteFile(tmpFileDOT.Name(), input, 0644)
+	if err != nil {
+		fmt.Println("Error creating", tmpFileDOT.Name())
+		fmt.Println(err)
+		return
+	}
+
+	// exec
+	cmd := exec.Command(graphvizDataFlowDiagramConversionCall, tmpFileDOT.Name(), tmpFilePNG.Name())
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		panic(errors.New("graph rendering call failed with error:" + err.Error()))
+	}
+	// copy into resulting file
+	input, err = ioutil.ReadFile(tmpFilePNG.Name())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	err = ioutil.WriteFile(targetDir+"/"+diagramFilenamePNG, input, 0644)
+	if err != nil {
+		fmt.Println("Error creating", diagramFilenamePNG)
+		fmt.Println(err)
+		return
+	}
+}
+
+func (result *AnalysisResult) renderDataAssetDiagramGraphvizImage(dotFile *os.File, targetDir string) { // TODO dedupe with other render...() method here
+	if result.analyzer.Verbose {
+		fmt.Println("Rendering data asset diagram input")
+	}
+	// tmp files
+	tmpFileDOT, err := ioutil.TempFile(model.TempFolder, "diagram-*-.gv")
+	checkErr(err)
+	defer os.Remove(tmpFileDOT.Name())
+
+	tmpFilePNG, err := ioutil.TempFile(model.TempFolder, "diagram-*-.png")
+	checkErr(err)
+	defer os.Remove(tmpFilePNG.Name())
+
+	// copy into tmp file as input
+	input, err := ioutil.ReadFile(dotFile.Name())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	err = ioutil.WriteFile(tmpFileDOT.Name(), input, 0644)
+	if err != nil {
+		fmt.Println("Error creating", tmpFileDOT.Name())
+		fmt.Println(err)
+		return
+	}
+
+	// exec
+	cmd := exec.Command(graphvizDataAssetDiagramConversionCall, tmpFileDOT.Name(), tmpFilePNG.Name())
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		panic(errors.New("graph rendering call failed with error: " + err.Error()))
+	}
+	// copy into resulting file
+	input, err = ioutil.ReadFile(tmpFilePNG.Name())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	err = ioutil.WriteFile(targetDir+"/"+DataAssetDiagramFilenamePNG, input, 0644)
+	if err != nil {
+		fmt.Println("Error creating", DataAssetDiagramFilenamePNG)
+		fmt.Println(err)
+		return
+	}
+}
@@ -0,0 +1,26 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/report"
+)
+
+const MarkdownModelDiffFilename, JsonModelDiffFilename = "model-diff.md", "model-diff.json"
+
+// DiffModels compares two analyzed models (usually two versions of the same model) semantically
+func DiffModels(oldResult *AnalysisResult, newResult *AnalysisResult) model.ModelDiff {
+	return model.DiffModels(oldResult.State, newResult.State)
+}
+
+// WriteModelDiff writes the diff as Markdown and JSON into the output directory
+func WriteModelDiff(diff model.ModelDiff, outputDirectory string, verbose bool) (err error) {
+	defer recoverError(&err)
+	if verbose {
+		fmt.Println("Writing model diff")
+	}
+	report.WriteModelDiffMarkdown(outputDirectory+"/"+MarkdownModelDiffFilename, diff)
+	report.WriteModelDiffJSON(outputDirectory+"/"+JsonModelDiffFilename, diff)
+	return nil
+}
@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// ApplyFlowLog reads the flow log (like an export of VPC flow logs) and maps the hosts of the observed flows to the
+// technical assets via "hosts", so that the undeclared communication can be turned into risks and the verification
+// against the declared communication links can be reported
+func (result *AnalysisResult) ApplyFlowLog(flowLogFilename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Reading flow log:", flowLogFilename)
+	}
+	/* #nosec flowLogFilename is not tainted (read from command-line params) */
+	content, err := ioutil.ReadFile(flowLogFilename)
+	checkErr(err)
+	records, err := model.ParseFlowLog(content)
+	if err != nil {
+		panic(errors.New("unable to read flow log " + flowLogFilename + ": " + err.Error()))
+	}
+	result.WithModelState(func() {
+		model.ParsedModelRoot.ObservedFlows = aggregateFlows(records)
+		if result.analyzer.Verbose {
+			fmt.Println("Observed flows:", len(model.ParsedModelRoot.ObservedFlows), "(of", len(records), "flow log records)")
+		}
+	})
+	return nil
+}
+
+// aggregates the flow log records by source host, target host, target port and protocol (with logged responses turned
+// into the direction of their requests)
+func aggregateFlows(records []model.FlowLogRecord) []model.ObservedFlow {
+	flowsByKey := make(map[string]*model.ObservedFlow)
+	assetIdsByHost := make(map[string]string)
+	assetIdOfHost := func(host string) string {
+		if id, found := assetIdsByHost[host]; found {
+			return id
+		}
+		id := model.TechnicalAssetIdOfHost(host)
+		assetIdsByHost[host] = id
+		return id
+	}
+	for _, record := range model.NormalizedFlowLogRecords(records) {
+		key := record.Source + "|" + record.Target + "|" + strconv.Itoa(record.TargetPort) + "|" + record.Protocol
+		flow, exists := flowsByKey[key]
+		if !exists {
+			flow = &model.ObservedFlow{
+				SourceHost:             record.Source,
+				TargetHost:             record.Target,
+				Port:                   record.TargetPort,
+				Protocol:               record.Protocol,
+				SourceTechnicalAssetId: assetIdOfHost(record.Source),
+				TargetTechnicalAssetId: assetIdOfHost(record.Target),
+			}
+			flowsByKey[key] = flow
+		}
+		flow.Records++
+	}
+	keys := make([]string, 0, len(flowsByKey))
+	for key := range flowsByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	flows := make([]model.ObservedFlow, 0, len(keys))
+	for _, key := range keys {
+		flows = append(flows, *flowsByKey[key])
+	}
+	return flows
+}
+
+// checks the host names, IP addresses and CIDR ranges of a technical asset (entries containing a slash must be valid
+// CIDR ranges)
+func (result *AnalysisResult) checkHosts(title string, hosts []string) []string {
+	checkedHosts := make([]string, 0, len(hosts))
+	for i, host := range hosts {
+		host = strings.TrimSpace(host)
+		if len(host) == 0 {
+			continue
+		}
+		if strings.Contains(host, "/") {
+			if _, _, err := net.ParseCIDR(host); err != nil {
+				result.addModelError(model.DiagnosticInvalidHost, "invalid CIDR range of technical asset '"+title+"': "+host,
+					"technical_assets", title, "hosts", strconv.Itoa(i))
+				continue
+			}
+		}
+		checkedHosts = append(checkedHosts, host)
+	}
+	return checkedHosts
+}
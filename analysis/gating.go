@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/threagile/threagile/model"
+)
+
+// the conditions of a fail-on policy as given on the commandline (like "severity=elevated,questions")
+const FailOnSeverity, FailOnUncheckedOlderThan, FailOnQuestions, FailOnModelFailures = "severity", "unchecked-older-than", "questions", "model-failures"
+
+// FailOnPolicy defines when an analysis fails (as in CI pipelines), i.e. which findings break the build
+type FailOnPolicy struct {
+	CheckSeverity          bool
+	MinimumSeverity        model.RiskSeverity // of the risks still at risk
+	CheckUncheckedAge      bool
+	UncheckedOlderThanDays int // age of unchecked risks by their risk tracking date (or else by the model date)
+	UnansweredQuestions    bool
+	ModelFailures          bool // risks still at risk of categories possibly caused by model failures
+}
+
+// FailOnCondition is the outcome of evaluating one condition of a fail-on policy
+type FailOnCondition struct {
+	Condition  string   `json:"condition"`
+	Threshold  string   `json:"threshold,omitempty"`
+	Violations []string `json:"violations"` // synthetic risk ids or unanswered questions
+}
+
+func (what FailOnCondition) Failed() bool {
+	return len(what.Violations) > 0
+}
+
+// ParseFailOnPolicy parses a comma-separated list of conditions: "severity=<min severity>", "unchecked-older-than=<days>",
+// "questions", and "model-failures"
+func ParseFailOnPolicy(policy string) (FailOnPolicy, error) {
+	var result FailOnPolicy
+	for _, condition := range strings.Split(policy, ",") {
+		condition = strings.TrimSpace(condition)
+		if len(condition) == 0 {
+			continue
+		}
+		name, value := condition, ""
+		if index := strings.Index(condition, "="); index >= 0 {
+			name, value = strings.TrimSpace(condition[:index]), strings.TrimSpace(condition[index+1:])
+		}
+		switch name {
+		case FailOnSeverity:
+			severity, err := model.ParseRiskSeverity(value)
+			if err != nil {
+				return result, errors.New("unknown severity of fail-on condition '" + condition + "' (values: low, medium, elevated, high, critical)")
+			}
+			result.CheckSeverity, result.MinimumSeverity = true, severity
+		case FailOnUncheckedOlderThan:
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 0 {
+				return result, errors.New("invalid number of days of fail-on condition '" + condition + "'")
+			}
+			result.CheckUncheckedAge, result.UncheckedOlderThanDays = true, days
+		case FailOnQuestions:
+			result.UnansweredQuestions = true
+		case FailOnModelFailures:
+			result.ModelFailures = true
+		default:
+			return result, errors.New("unknown fail-on condition '" + condition + "' (conditions: " +
+				FailOnSeverity + "=<severity>, " + FailOnUncheckedOlderThan + "=<days>, " + FailOnQuestions + ", " + FailOnModelFailures + ")")
+		}
+	}
+	return result, nil
+}
+
+// EvaluateFailOnPolicy checks the conditions of the policy (in the order of their definition), the analysis failed when
+// any of the conditions returned has violations. With a baseline, only new risks count.
+func (result *AnalysisResult) EvaluateFailOnPolicy(policy FailOnPolicy) (conditions []FailOnCondition, err error) {
+	defer recoverError(&err)
+	conditions = make([]FailOnCondition, 0)
+	now := time.Now()
+	result.WithModelState(func() {
+		risks := make([]model.Risk, 0)
+		for _, risk := range model.AllRisks() {
+			if risk.IsNewSinceBaseline() {
+				risks = append(risks, risk)
+			}
+		}
+		if policy.CheckSeverity {
+			condition := FailOnCondition{Condition: FailOnSeverity, Threshold: policy.MinimumSeverity.String(), Violations: make([]string, 0)}
+			for _, risk := range model.ReduceToOnlyStillAtRisk(risks) {
+				if risk.Severity >= policy.MinimumSeverity {
+					condition.Violations = append(condition.Violations, risk.SyntheticId)
+				}
+			}
+			conditions = append(conditions, condition)
+		}
+		if policy.CheckUncheckedAge {
+			condition := FailOnCondition{Condition: FailOnUncheckedOlderThan, Threshold: strconv.Itoa(policy.UncheckedOlderThanDays) + " days", Violations: make([]string, 0)}
+			for _, risk := range risks {
+				if risk.GetRiskTrackingStatusDefaultingUnchecked() != model.Unchecked {
+					continue
+				}
+				since := risk.GetRiskTracking().Date
+				if since.IsZero() {
+					since = model.ParsedModelRoot.Date
+				}
+				if !since.IsZero() && now.Sub(since) > time.Duration(policy.UncheckedOlderThanDays)*24*time.Hour {
+					condition.Violations = append(condition.Violations, risk.SyntheticId)
+				}
+			}
+			conditions = append(conditions, condition)
+		}
+		if policy.UnansweredQuestions {
+			condition := FailOnCondition{Condition: FailOnQuestions, Violations: make([]string, 0)}
+			for _, question := range model.SortedKeysOfQuestions() {
+				if len(strings.TrimSpace(model.ParsedModelRoot.Questions[question])) == 0 {
+					condition.Violations = append(condition.Violations, question)
+				}
+			}
+			conditions = append(conditions, condition)
+		}
+		if policy.ModelFailures {
+			condition := FailOnCondition{Condition: FailOnModelFailures, Violations: make([]string, 0)}
+			for _, risk := range model.ReduceToOnlyStillAtRisk(model.FlattenRiskSlice(model.FilterByModelFailures(model.GeneratedRisksByCategory))) {
+				if risk.IsNewSinceBaseline() {
+					condition.Violations = append(condition.Violations, risk.SyntheticId)
+				}
+			}
+			conditions = append(conditions, condition)
+		}
+	})
+	for _, condition := range conditions {
+		sort.Strings(condition.Violations)
+	}
+	return conditions, nil
+}
@@ -0,0 +1,459 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/report"
+)
+
+const JsonAttackPathsFilename, AttackPathsDiagramFilenameDOT, AttackPathsDiagramFilenamePNG = "attack-paths.json", "data-flow-diagram-attack-paths.gv", "data-flow-diagram-attack-paths.png"
+const JsonBlastRadiusFilename, BlastRadiusDiagramFilenameDOT, BlastRadiusDiagramFilenamePNG = "blast-radius.json", "data-flow-diagram-blast-radius.gv", "data-flow-diagram-blast-radius.png"
+const ExcelComplianceFilename = "compliance.xlsx"
+const SarifRisksFilename = "risks.sarif"
+const ThreatDragonFilename = "threat-dragon.json"
+const CycloneDXFilename = "threat-model.cdx.json"
+const JsonRiskDeltaFilename = "risks-delta.json"
+const JsonFlowVerificationFilename = "flow-verification.json"
+const HtmlReportFilename = "report.html"
+const MarkdownReportFilename = "report.md"
+
+const ReportFilename, ExcelRisksFilename, ExcelTagsFilename, JsonRisksFilename, JsonTechnicalAssetsFilename, JsonStatsFilename, DataFlowDiagramFilenameDOT, DataFlowDiagramFilenamePNG, DataAssetDiagramFilenameDOT, DataAssetDiagramFilenamePNG = "report.pdf", "risks.xlsx", "tags.xlsx", "risks.json", "technical-assets.json", "stats.json", "data-flow-diagram.gv", "data-flow-diagram.png", "data-asset-diagram.gv", "data-asset-diagram.png"
+
+// Outputs selects the files to be written by WriteOutputs
+type Outputs struct {
+	DataFlowDiagram, DataAssetDiagram, AttackPathsDiagram, RisksJSON, RisksSARIF, ThreatDragonJSON, CycloneDX, TechnicalAssetsJSON, StatsJSON, AttackPathsJSON, RisksExcel, TagsExcel, ComplianceExcel, ReportPDF, ReportHTML, ReportMarkdown bool
+
+	// the markdown report is written compact (only the risks still at risk, fitting into a pull request comment)
+	ReportMarkdownCompact bool
+
+	// the blast radius outputs are written for the compromised technical asset given
+	BlastRadiusJSON, BlastRadiusDiagram bool
+	BlastRadiusTechnicalAssetId         string
+}
+
+// WriteOutputs writes the selected outputs (using their default file names) into the output directory
+func (result *AnalysisResult) WriteOutputs(outputDirectory string, outputs Outputs) (err error) {
+	if result.analyzer.Verbose {
+		fmt.Println("Writing into output directory:", outputDirectory)
+	}
+	if outputs.ReportPDF { // as the report includes all diagrams (while the HTML report just embeds the ones rendered)
+		outputs.DataFlowDiagram, outputs.DataAssetDiagram, outputs.AttackPathsDiagram = true, true, true
+	}
+	if outputs.DataFlowDiagram {
+		if err = result.WriteDataFlowDiagram(outputDirectory); err != nil {
+			return err
+		}
+	}
+	if outputs.DataAssetDiagram {
+		if err = result.WriteDataAssetDiagram(outputDirectory); err != nil {
+			return err
+		}
+	}
+	if outputs.AttackPathsDiagram {
+		if err = result.WriteAttackPathsDiagram(outputDirectory); err != nil {
+			return err
+		}
+	}
+	if outputs.RisksJSON {
+		if err = result.WriteRisksJSON(outputDirectory + "/" + JsonRisksFilename); err != nil {
+			return err
+		}
+		if result.HasBaseline() {
+			if err = result.WriteRiskDeltaJSON(outputDirectory + "/" + JsonRiskDeltaFilename); err != nil {
+				return err
+			}
+		}
+		if result.HasObservedFlows() {
+			if err = result.WriteFlowVerificationJSON(outputDirectory + "/" + JsonFlowVerificationFilename); err != nil {
+				return err
+			}
+		}
+	}
+	if outputs.RisksSARIF {
+		if err = result.WriteRisksSARIF(outputDirectory + "/" + SarifRisksFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.ThreatDragonJSON {
+		if err = result.WriteThreatDragonJSON(outputDirectory + "/" + ThreatDragonFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.CycloneDX {
+		if err = result.WriteCycloneDX(outputDirectory + "/" + CycloneDXFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.TechnicalAssetsJSON {
+		if err = result.WriteTechnicalAssetsJSON(outputDirectory + "/" + JsonTechnicalAssetsFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.StatsJSON {
+		if err = result.WriteStatsJSON(outputDirectory + "/" + JsonStatsFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.AttackPathsJSON {
+		if err = result.WriteAttackPathsJSON(outputDirectory + "/" + JsonAttackPathsFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.RisksExcel {
+		if err = result.WriteRisksExcel(outputDirectory + "/" + ExcelRisksFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.TagsExcel {
+		if err = result.WriteTagsExcel(outputDirectory + "/" + ExcelTagsFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.ComplianceExcel {
+		if err = result.WriteComplianceExcel(outputDirectory + "/" + ExcelComplianceFilename); err != nil {
+			return err
+		}
+	}
+	if outputs.BlastRadiusDiagram {
+		if err = result.WriteBlastRadiusDiagram(outputDirectory, outputs.BlastRadiusTechnicalAssetId); err != nil {
+			return err
+		}
+	}
+	if outputs.BlastRadiusJSON {
+		if err = result.WriteBlastRadiusJSON(outputDirectory+"/"+JsonBlastRadiusFilename, outputs.BlastRadiusTechnicalAssetId); err != nil {
+			return err
+		}
+	}
+	if outputs.ReportPDF {
+		if err = result.WriteReportPDF(outputDirectory); err != nil {
+			return err
+		}
+	}
+	if outputs.ReportHTML {
+		if err = result.WriteReportHTML(outputDirectory); err != nil {
+			return err
+		}
+	}
+	if outputs.ReportMarkdown {
+		if err = result.WriteReportMarkdown(outputDirectory, outputs.ReportMarkdownCompact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDataFlowDiagram renders the data-flow diagram (as PNG) into the output directory
+func (result *AnalysisResult) WriteDataFlowDiagram(outputDirectory string) (err error) {
+	defer recoverError(&err)
+	gvFile := outputDirectory + "/" + DataFlowDiagramFilenameDOT
+	if !result.analyzer.KeepDiagramSourceFiles {
+		tmpFileGV, err := ioutil.TempFile(model.TempFolder, DataFlowDiagramFilenameDOT)
+		checkErr(err)
+		gvFile = tmpFileGV.Name()
+		defer os.Remove(gvFile)
+	}
+	result.WithModelState(func() {
+		dotFile := result.writeDataFlowDiagramGraphvizDOT(gvFile, result.analyzer.DiagramDPI, dataFlowDiagramHighlights{})
+		result.renderDataFlowDiagramGraphvizImage(dotFile, outputDirectory, DataFlowDiagramFilenamePNG)
+		result.renderedDiagrams[outputDirectory+"/"+DataFlowDiagramFilenamePNG] = true
+	})
+	return nil
+}
+
+// WriteAttackPathsDiagram renders the data-flow diagram with the attack paths highlighted (as PNG) into the output directory
+func (result *AnalysisResult) WriteAttackPathsDiagram(outputDirectory string) (err error) {
+	defer recoverError(&err)
+	gvFile := outputDirectory + "/" + AttackPathsDiagramFilenameDOT
+	if !result.analyzer.KeepDiagramSourceFiles {
+		tmpFileGV, err := ioutil.TempFile(model.TempFolder, AttackPathsDiagramFilenameDOT)
+		checkErr(err)
+		gvFile = tmpFileGV.Name()
+		defer os.Remove(gvFile)
+	}
+	result.WithModelState(func() {
+		dotFile := result.writeDataFlowDiagramGraphvizDOT(gvFile, result.analyzer.DiagramDPI, attackPathHighlights(model.AttackPaths()))
+		result.renderDataFlowDiagramGraphvizImage(dotFile, outputDirectory, AttackPathsDiagramFilenamePNG)
+		result.renderedDiagrams[outputDirectory+"/"+AttackPathsDiagramFilenamePNG] = true
+	})
+	return nil
+}
+
+// WriteDataAssetDiagram renders the data asset diagram (as PNG) into the output directory
+func (result *AnalysisResult) WriteDataAssetDiagram(outputDirectory string) (err error) {
+	defer recoverError(&err)
+	gvFile := outputDirectory + "/" + DataAssetDiagramFilenameDOT
+	if !result.analyzer.KeepDiagramSourceFiles {
+		tmpFile, err := ioutil.TempFile(model.TempFolder, DataAssetDiagramFilenameDOT)
+		checkErr(err)
+		gvFile = tmpFile.Name()
+		defer os.Remove(gvFile)
+	}
+	result.WithModelState(func() {
+		dotFile := result.writeDataAssetDiagramGraphvizDOT(gvFile, result.analyzer.DiagramDPI)
+		result.renderDataAssetDiagramGraphvizImage(dotFile, outputDirectory)
+		result.renderedDiagrams[outputDirectory+"/"+DataAssetDiagramFilenamePNG] = true
+	})
+	return nil
+}
+
+// WriteBlastRadiusDiagram renders the data-flow diagram reduced to the blast radius of the compromised technical asset
+// (as PNG) into the output directory
+func (result *AnalysisResult) WriteBlastRadiusDiagram(outputDirectory string, compromisedTechnicalAssetId string) (err error) {
+	defer recoverError(&err)
+	gvFile := outputDirectory + "/" + BlastRadiusDiagramFilenameDOT
+	if !result.analyzer.KeepDiagramSourceFiles {
+		tmpFileGV, err := ioutil.TempFile(model.TempFolder, BlastRadiusDiagramFilenameDOT)
+		checkErr(err)
+		gvFile = tmpFileGV.Name()
+		defer os.Remove(gvFile)
+	}
+	result.WithModelState(func() {
+		dotFile := result.writeDataFlowDiagramGraphvizDOT(gvFile, result.analyzer.DiagramDPI, blastRadiusHighlights(model.BlastRadiusOf(compromisedTechnicalAssetId)))
+		result.renderDataFlowDiagramGraphvizImage(dotFile, outputDirectory, BlastRadiusDiagramFilenamePNG)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteRisksJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing risks json")
+	}
+	result.WithModelState(func() {
+		report.WriteRisksJSON(filename)
+	})
+	return nil
+}
+
+// WriteRiskDeltaJSON writes the classification of the risks compared to the baseline (see Analyzer.Baseline)
+func (result *AnalysisResult) WriteRiskDeltaJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing risk delta json")
+	}
+	result.WithModelState(func() {
+		report.WriteRiskDeltaJSON(filename)
+	})
+	return nil
+}
+
+// WriteFlowVerificationJSON writes the comparison of the observed flows with the declared communication links (see
+// Analyzer.FlowLog)
+func (result *AnalysisResult) WriteFlowVerificationJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing flow verification json")
+	}
+	result.WithModelState(func() {
+		report.WriteFlowVerificationJSON(filename)
+	})
+	return nil
+}
+
+// WriteRisksSARIF writes the risks as SARIF log pointing to the model file locations of their most relevant elements
+func (result *AnalysisResult) WriteRisksSARIF(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing risks sarif")
+	}
+	result.WithModelState(func() {
+		report.WriteRisksSARIF(filename, filepath.Dir(result.ModelFilenames[0]), func(path ...string) (string, int, int) {
+			modelFilename, position := result.locateModelElement(path)
+			return modelFilename, position.line, position.column
+		})
+	})
+	return nil
+}
+
+// WriteThreatDragonJSON writes the model as OWASP Threat Dragon model with the risks as threats on its elements
+func (result *AnalysisResult) WriteThreatDragonJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing threat dragon json")
+	}
+	result.WithModelState(func() {
+		report.WriteThreatDragonJSON(filename)
+	})
+	return nil
+}
+
+// WriteCycloneDX writes the model as CycloneDX BOM with the technical assets as services and components, their
+// communication links as data flows, and the risks as vulnerabilities
+func (result *AnalysisResult) WriteCycloneDX(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing cyclonedx")
+	}
+	result.WithModelState(func() {
+		report.WriteCycloneDX(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteTechnicalAssetsJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing technical assets json")
+	}
+	result.WithModelState(func() {
+		report.WriteTechnicalAssetsJSON(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteStatsJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing stats json")
+	}
+	result.WithModelState(func() {
+		report.WriteStatsJSON(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteAttackPathsJSON(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing attack paths json")
+	}
+	result.WithModelState(func() {
+		report.WriteAttackPathsJSON(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteBlastRadiusJSON(filename string, compromisedTechnicalAssetId string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing blast radius json")
+	}
+	result.WithModelState(func() {
+		report.WriteBlastRadiusJSON(filename, compromisedTechnicalAssetId)
+	})
+	return nil
+}
+
+// BlastRadiusOf returns the blast radius of the compromised technical asset
+func (result *AnalysisResult) BlastRadiusOf(compromisedTechnicalAssetId string) (blastRadius model.BlastRadius, err error) {
+	defer recoverError(&err)
+	result.WithModelState(func() {
+		blastRadius = model.BlastRadiusOf(compromisedTechnicalAssetId)
+	})
+	return blastRadius, nil
+}
+
+func (result *AnalysisResult) WriteRisksExcel(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing risks excel")
+	}
+	result.WithModelState(func() {
+		report.WriteRisksExcelToFile(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteTagsExcel(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing tags excel")
+	}
+	result.WithModelState(func() {
+		report.WriteTagsExcelToFile(filename)
+	})
+	return nil
+}
+
+func (result *AnalysisResult) WriteComplianceExcel(filename string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing compliance excel")
+	}
+	result.WithModelState(func() {
+		report.WriteComplianceExcelToFile(filename)
+	})
+	return nil
+}
+
+// WriteReportPDF writes the report into the output directory, which must already contain all diagrams
+func (result *AnalysisResult) WriteReportPDF(outputDirectory string) (err error) {
+	defer recoverError(&err)
+	// hash the YAML input file (followed by all included YAML files in order of inclusion)
+	hasher := sha256.New()
+	for _, filename := range result.ModelFilenames {
+		f, err := os.Open(filename)
+		checkErr(err)
+		_, err = io.Copy(hasher, f)
+		f.Close()
+		checkErr(err)
+	}
+	modelHash := hex.EncodeToString(hasher.Sum(nil))
+	if result.analyzer.Verbose {
+		fmt.Println("Writing report pdf")
+	}
+	result.WithModelState(func() {
+		report.WriteReportPDF(outputDirectory+"/"+ReportFilename,
+			result.analyzer.TemplateFilename,
+			outputDirectory+"/"+DataFlowDiagramFilenamePNG,
+			outputDirectory+"/"+DataAssetDiagramFilenamePNG,
+			outputDirectory+"/"+AttackPathsDiagramFilenamePNG,
+			result.ModelFilenames[0],
+			result.analyzer.SkipRiskRules,
+			result.analyzer.EnableRiskRules,
+			result.analyzer.BuildTimestamp,
+			modelHash,
+			result.IntroTextRAA,
+			result.analyzer.CustomRiskRules)
+	})
+	return nil
+}
+
+// WriteReportHTML writes the self-contained HTML report into the output directory, embedding the diagrams rendered
+// into it before (the others are left out)
+func (result *AnalysisResult) WriteReportHTML(outputDirectory string) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing report html")
+	}
+	result.WithModelState(func() {
+		report.WriteReportHTML(outputDirectory+"/"+HtmlReportFilename,
+			result.renderedDiagram(outputDirectory+"/"+DataFlowDiagramFilenamePNG),
+			result.renderedDiagram(outputDirectory+"/"+DataAssetDiagramFilenamePNG),
+			result.renderedDiagram(outputDirectory+"/"+AttackPathsDiagramFilenamePNG),
+			result.IntroTextRAA)
+	})
+	return nil
+}
+
+// renderedDiagram returns the diagram file when rendered by this analysis, or else an empty file name (so that no
+// outdated diagram of a previous run gets embedded)
+func (result *AnalysisResult) renderedDiagram(diagramFilenamePNG string) string {
+	if result.renderedDiagrams[diagramFilenamePNG] {
+		return diagramFilenamePNG
+	}
+	return ""
+}
+
+// WriteReportMarkdown writes the markdown report into the output directory, linking to the diagrams already written
+func (result *AnalysisResult) WriteReportMarkdown(outputDirectory string, compact bool) (err error) {
+	defer recoverError(&err)
+	if result.analyzer.Verbose {
+		fmt.Println("Writing report markdown")
+	}
+	result.WithModelState(func() {
+		report.WriteReportMarkdown(outputDirectory+"/"+MarkdownReportFilename,
+			outputDirectory+"/"+DataFlowDiagramFilenamePNG,
+			outputDirectory+"/"+DataAssetDiagramFilenamePNG,
+			outputDirectory+"/"+AttackPathsDiagramFilenamePNG,
+			compact)
+	})
+	return nil
+}
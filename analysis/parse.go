@@ -0,0 +1,1380 @@
+package analysis
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+var validIdSyntax = regexp.MustCompile(`^[a-zA-Z0-9\-]+$`)
+
+func (result *AnalysisResult) checkIdSyntax(id string, path ...string) {
+	if !validIdSyntax.MatchString(id) {
+		result.addModelError(model.DiagnosticInvalidIdSyntax, "invalid id syntax used (only letters, numbers, and hyphen allowed): "+id, path...)
+	}
+}
+
+func (result *AnalysisResult) parseModel(inputFilename string) {
+	if result.analyzer.Verbose {
+		fmt.Println("Parsing model:", inputFilename)
+	}
+	result.Diagnostics = make([]model.ModelDiagnostic, 0)
+	result.modelElementPositions = make(map[string]map[string]yamlPosition)
+	modelYaml, err := ioutil.ReadFile(inputFilename)
+	if err == nil {
+		result.ModelInput = model.ModelInput{}
+		err = yaml.Unmarshal(modelYaml, &result.ModelInput)
+		if err != nil {
+			result.addYamlSyntaxDiagnostic(inputFilename, err)
+			result.abortOnModelErrors()
+		}
+		result.registerModelElementPositions(inputFilename, modelYaml)
+		//fmt.Println(result.ModelInput)
+		result.mergeIncludedModelFiles(inputFilename)
+		result.abortOnModelErrors() // a broken include makes the remaining checks meaningless
+
+		var businessCriticality model.Criticality
+		switch result.ModelInput.Business_criticality {
+		case model.Archive.String():
+			businessCriticality = model.Archive
+		case model.Operational.String():
+			businessCriticality = model.Operational
+		case model.Important.String():
+			businessCriticality = model.Important
+		case model.Critical.String():
+			businessCriticality = model.Critical
+		case model.MissionCritical.String():
+			businessCriticality = model.MissionCritical
+		default:
+			result.addModelError(model.DiagnosticUnknownValue, "unknown 'business_criticality' value of application: "+result.ModelInput.Business_criticality, "business_criticality")
+		}
+
+		reportDate := time.Now()
+		if len(result.ModelInput.Date) > 0 {
+			reportDate, err = time.Parse("2006-01-02", result.ModelInput.Date)
+			if err != nil {
+				result.addModelError(model.DiagnosticInvalidDate, "unable to parse 'date' value of model file: "+result.ModelInput.Date, "date")
+			}
+		}
+
+		model.ParsedModelRoot = model.ParsedModel{
+			Author:                         result.ModelInput.Author,
+			Title:                          result.ModelInput.Title,
+			Date:                           reportDate,
+			ManagementSummaryComment:       result.ModelInput.Management_summary_comment,
+			BusinessCriticality:            businessCriticality,
+			BusinessOverview:               removePathElementsFromImageFiles(result.ModelInput.Business_overview),
+			TechnicalOverview:              removePathElementsFromImageFiles(result.ModelInput.Technical_overview),
+			Questions:                      result.ModelInput.Questions,
+			AbuseCases:                     result.ModelInput.Abuse_cases,
+			SecurityRequirements:           result.ModelInput.Security_requirements,
+			TagsAvailable:                  lowerCaseAndTrim(result.ModelInput.Tags_available),
+			DiagramTweakNodesep:            result.ModelInput.Diagram_tweak_nodesep,
+			DiagramTweakRanksep:            result.ModelInput.Diagram_tweak_ranksep,
+			DiagramTweakEdgeLayout:         result.ModelInput.Diagram_tweak_edge_layout,
+			DiagramTweakSuppressEdgeLabels: result.ModelInput.Diagram_tweak_suppress_edge_labels,
+			DiagramTweakLayoutLeftToRight:  result.ModelInput.Diagram_tweak_layout_left_to_right,
+			DiagramTweakInvisibleConnectionsBetweenAssets: result.ModelInput.Diagram_tweak_invisible_connections_between_assets,
+			DiagramTweakSameRankAssets:                    result.ModelInput.Diagram_tweak_same_rank_assets,
+		}
+		if model.ParsedModelRoot.DiagramTweakNodesep == 0 {
+			model.ParsedModelRoot.DiagramTweakNodesep = 2
+		}
+		if model.ParsedModelRoot.DiagramTweakRanksep == 0 {
+			model.ParsedModelRoot.DiagramTweakRanksep = 2
+		}
+
+		// Data Assets ===============================================================================
+		model.ParsedModelRoot.DataAssets = make(map[string]model.DataAsset)
+		for title, asset := range result.ModelInput.Data_assets {
+			id := fmt.Sprintf("%v", asset.ID)
+
+			var usage model.Usage
+			switch asset.Usage {
+			case model.Business.String():
+				usage = model.Business
+			case model.DevOps.String():
+				usage = model.DevOps
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'usage' value of data asset '"+title+"': "+asset.Usage, "data_assets", title, "usage")
+			}
+
+			var quantity model.Quantity
+			switch asset.Quantity {
+			case model.VeryFew.String():
+				quantity = model.VeryFew
+			case model.Few.String():
+				quantity = model.Few
+			case model.Many.String():
+				quantity = model.Many
+			case model.VeryMany.String():
+				quantity = model.VeryMany
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'quantity' value of data asset '"+title+"': "+asset.Quantity, "data_assets", title, "quantity")
+			}
+
+			var confidentiality model.Confidentiality
+			switch asset.Confidentiality {
+			case model.Public.String():
+				confidentiality = model.Public
+			case model.Internal.String():
+				confidentiality = model.Internal
+			case model.Restricted.String():
+				confidentiality = model.Restricted
+			case model.Confidential.String():
+				confidentiality = model.Confidential
+			case model.StrictlyConfidential.String():
+				confidentiality = model.StrictlyConfidential
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'confidentiality' value of data asset '"+title+"': "+asset.Confidentiality, "data_assets", title, "confidentiality")
+			}
+
+			var integrity model.Criticality
+			switch asset.Integrity {
+			case model.Archive.String():
+				integrity = model.Archive
+			case model.Operational.String():
+				integrity = model.Operational
+			case model.Important.String():
+				integrity = model.Important
+			case model.Critical.String():
+				integrity = model.Critical
+			case model.MissionCritical.String():
+				integrity = model.MissionCritical
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'integrity' value of data asset '"+title+"': "+asset.Integrity, "data_assets", title, "integrity")
+			}
+
+			var availability model.Criticality
+			switch asset.Availability {
+			case model.Archive.String():
+				availability = model.Archive
+			case model.Operational.String():
+				availability = model.Operational
+			case model.Important.String():
+				availability = model.Important
+			case model.Critical.String():
+				availability = model.Critical
+			case model.MissionCritical.String():
+				availability = model.MissionCritical
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'availability' value of data asset '"+title+"': "+asset.Availability, "data_assets", title, "availability")
+			}
+
+			result.checkIdSyntax(id, "data_assets", title, "id")
+			if _, exists := model.ParsedModelRoot.DataAssets[id]; exists {
+				result.addModelError(model.DiagnosticDuplicateId, "duplicate id used: "+id, "data_assets", title, "id")
+			}
+			model.ParsedModelRoot.DataAssets[id] = model.DataAsset{
+				Id:                     id,
+				Title:                  title,
+				Usage:                  usage,
+				Description:            withDefault(fmt.Sprintf("%v", asset.Description), title),
+				Quantity:               quantity,
+				Tags:                   result.checkTags(lowerCaseAndTrim(asset.Tags), "data asset '"+title+"'", "data_assets", title),
+				Origin:                 fmt.Sprintf("%v", asset.Origin),
+				Owner:                  fmt.Sprintf("%v", asset.Owner),
+				Confidentiality:        confidentiality,
+				Integrity:              integrity,
+				Availability:           availability,
+				JustificationCiaRating: fmt.Sprintf("%v", asset.Justification_cia_rating),
+			}
+		}
+
+		// Technical Assets ===============================================================================
+		model.ParsedModelRoot.TechnicalAssets = make(map[string]model.TechnicalAsset)
+		for title, asset := range result.ModelInput.Technical_assets {
+			id := fmt.Sprintf("%v", asset.ID)
+
+			var usage model.Usage
+			switch asset.Usage {
+			case model.Business.String():
+				usage = model.Business
+			case model.DevOps.String():
+				usage = model.DevOps
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'usage' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Usage), "technical_assets", title, "usage")
+			}
+
+			var dataAssetsProcessed = make([]string, 0)
+			if asset.Data_assets_processed != nil {
+				dataAssetsProcessed = make([]string, len(asset.Data_assets_processed))
+				for i, parsedProcessedAsset := range asset.Data_assets_processed {
+					referencedAsset := fmt.Sprintf("%v", parsedProcessedAsset)
+					result.checkDataAssetTargetExists(referencedAsset, "technical asset '"+title+"'", "technical_assets", title, "data_assets_processed", strconv.Itoa(i))
+					dataAssetsProcessed[i] = referencedAsset
+				}
+			}
+
+			var dataAssetsStored = make([]string, 0)
+			if asset.Data_assets_stored != nil {
+				dataAssetsStored = make([]string, len(asset.Data_assets_stored))
+				for i, parsedStoredAssets := range asset.Data_assets_stored {
+					referencedAsset := fmt.Sprintf("%v", parsedStoredAssets)
+					result.checkDataAssetTargetExists(referencedAsset, "technical asset '"+title+"'", "technical_assets", title, "data_assets_stored", strconv.Itoa(i))
+					dataAssetsStored[i] = referencedAsset
+				}
+			}
+
+			var technicalAssetType model.TechnicalAssetType
+			switch asset.Type {
+			case model.ExternalEntity.String():
+				technicalAssetType = model.ExternalEntity
+			case model.Process.String():
+				technicalAssetType = model.Process
+			case model.Datastore.String():
+				technicalAssetType = model.Datastore
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'type' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Type), "technical_assets", title, "type")
+			}
+
+			var technicalAssetSize model.TechnicalAssetSize
+			switch asset.Size {
+			case model.Service.String():
+				technicalAssetSize = model.Service
+			case model.System.String():
+				technicalAssetSize = model.System
+			case model.Application.String():
+				technicalAssetSize = model.Application
+			case model.Component.String():
+				technicalAssetSize = model.Component
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'size' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Size), "technical_assets", title, "size")
+			}
+
+			var technicalAssetTechnology model.TechnicalAssetTechnology
+			switch asset.Technology {
+			case model.UnknownTechnology.String():
+				technicalAssetTechnology = model.UnknownTechnology
+			case model.ClientSystem.String():
+				technicalAssetTechnology = model.ClientSystem
+			case model.Browser.String():
+				technicalAssetTechnology = model.Browser
+			case model.Desktop.String():
+				technicalAssetTechnology = model.Desktop
+			case model.MobileApp.String():
+				technicalAssetTechnology = model.MobileApp
+			case model.DevOpsClient.String():
+				technicalAssetTechnology = model.DevOpsClient
+			case model.WebServer.String():
+				technicalAssetTechnology = model.WebServer
+			case model.WebApplication.String():
+				technicalAssetTechnology = model.WebApplication
+			case model.ApplicationServer.String():
+				technicalAssetTechnology = model.ApplicationServer
+			case model.Database.String():
+				technicalAssetTechnology = model.Database
+			case model.FileServer.String():
+				technicalAssetTechnology = model.FileServer
+			case model.LocalFileSystem.String():
+				technicalAssetTechnology = model.LocalFileSystem
+			case model.ERP.String():
+				technicalAssetTechnology = model.ERP
+			case model.CMS.String():
+				technicalAssetTechnology = model.CMS
+			case model.WebServiceREST.String():
+				technicalAssetTechnology = model.WebServiceREST
+			case model.WebServiceSOAP.String():
+				technicalAssetTechnology = model.WebServiceSOAP
+			case model.EJB.String():
+				technicalAssetTechnology = model.EJB
+			case model.SearchIndex.String():
+				technicalAssetTechnology = model.SearchIndex
+			case model.SearchEngine.String():
+				technicalAssetTechnology = model.SearchEngine
+			case model.ServiceRegistry.String():
+				technicalAssetTechnology = model.ServiceRegistry
+			case model.ReverseProxy.String():
+				technicalAssetTechnology = model.ReverseProxy
+			case model.LoadBalancer.String():
+				technicalAssetTechnology = model.LoadBalancer
+			case model.BuildPipeline.String():
+				technicalAssetTechnology = model.BuildPipeline
+			case model.SourcecodeRepository.String():
+				technicalAssetTechnology = model.SourcecodeRepository
+			case model.ArtifactRegistry.String():
+				technicalAssetTechnology = model.ArtifactRegistry
+			case model.CodeInspectionPlatform.String():
+				technicalAssetTechnology = model.CodeInspectionPlatform
+			case model.Monitoring.String():
+				technicalAssetTechnology = model.Monitoring
+			case model.LDAPServer.String():
+				technicalAssetTechnology = model.LDAPServer
+			case model.ContainerPlatform.String():
+				technicalAssetTechnology = model.ContainerPlatform
+			case model.BatchProcessing.String():
+				technicalAssetTechnology = model.BatchProcessing
+			case model.EventListener.String():
+				technicalAssetTechnology = model.EventListener
+			case model.IdentityProvider.String():
+				technicalAssetTechnology = model.IdentityProvider
+			case model.IdentityStoreLDAP.String():
+				technicalAssetTechnology = model.IdentityStoreLDAP
+			case model.IdentityStoreDatabase.String():
+				technicalAssetTechnology = model.IdentityStoreDatabase
+			case model.Tool.String():
+				technicalAssetTechnology = model.Tool
+			case model.CLI.String():
+				technicalAssetTechnology = model.CLI
+			case model.Task.String():
+				technicalAssetTechnology = model.Task
+			case model.Function.String():
+				technicalAssetTechnology = model.Function
+			case model.Gateway.String():
+				technicalAssetTechnology = model.Gateway
+			case model.IoTDevice.String():
+				technicalAssetTechnology = model.IoTDevice
+			case model.MessageQueue.String():
+				technicalAssetTechnology = model.MessageQueue
+			case model.StreamProcessing.String():
+				technicalAssetTechnology = model.StreamProcessing
+			case model.ServiceMesh.String():
+				technicalAssetTechnology = model.ServiceMesh
+			case model.DataLake.String():
+				technicalAssetTechnology = model.DataLake
+			case model.BigDataPlatform.String():
+				technicalAssetTechnology = model.BigDataPlatform
+			case model.ReportEngine.String():
+				technicalAssetTechnology = model.ReportEngine
+			case model.AI.String():
+				technicalAssetTechnology = model.AI
+			case model.MailServer.String():
+				technicalAssetTechnology = model.MailServer
+			case model.Vault.String():
+				technicalAssetTechnology = model.Vault
+			case model.HSM.String():
+				technicalAssetTechnology = model.HSM
+			case model.WAF.String():
+				technicalAssetTechnology = model.WAF
+			case model.IDS.String():
+				technicalAssetTechnology = model.IDS
+			case model.IPS.String():
+				technicalAssetTechnology = model.IPS
+			case model.Scheduler.String():
+				technicalAssetTechnology = model.Scheduler
+			case model.Mainframe.String():
+				technicalAssetTechnology = model.Mainframe
+			case model.BlockStorage.String():
+				technicalAssetTechnology = model.BlockStorage
+			case model.Library.String():
+				technicalAssetTechnology = model.Library
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'technology' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Technology), "technical_assets", title, "technology")
+			}
+
+			var encryption model.EncryptionStyle
+			switch asset.Encryption {
+			case model.NoneEncryption.String():
+				encryption = model.NoneEncryption
+			case model.Transparent.String():
+				encryption = model.Transparent
+			case model.DataWithSymmetricSharedKey.String():
+				encryption = model.DataWithSymmetricSharedKey
+			case model.DataWithAsymmetricSharedKey.String():
+				encryption = model.DataWithAsymmetricSharedKey
+			case model.DataWithEnduserIndividualKey.String():
+				encryption = model.DataWithEnduserIndividualKey
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'encryption' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Encryption), "technical_assets", title, "encryption")
+			}
+
+			var technicalAssetMachine model.TechnicalAssetMachine
+			switch asset.Machine {
+			case model.Physical.String():
+				technicalAssetMachine = model.Physical
+			case model.Virtual.String():
+				technicalAssetMachine = model.Virtual
+			case model.Container.String():
+				technicalAssetMachine = model.Container
+			case model.Serverless.String():
+				technicalAssetMachine = model.Serverless
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'machine' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Machine), "technical_assets", title, "machine")
+			}
+
+			var confidentiality model.Confidentiality
+			switch asset.Confidentiality {
+			case model.Public.String():
+				confidentiality = model.Public
+			case model.Internal.String():
+				confidentiality = model.Internal
+			case model.Restricted.String():
+				confidentiality = model.Restricted
+			case model.Confidential.String():
+				confidentiality = model.Confidential
+			case model.StrictlyConfidential.String():
+				confidentiality = model.StrictlyConfidential
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'confidentiality' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Confidentiality), "technical_assets", title, "confidentiality")
+			}
+
+			var integrity model.Criticality
+			switch asset.Integrity {
+			case model.Archive.String():
+				integrity = model.Archive
+			case model.Operational.String():
+				integrity = model.Operational
+			case model.Important.String():
+				integrity = model.Important
+			case model.Critical.String():
+				integrity = model.Critical
+			case model.MissionCritical.String():
+				integrity = model.MissionCritical
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'integrity' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Integrity), "technical_assets", title, "integrity")
+			}
+
+			var availability model.Criticality
+			switch asset.Availability {
+			case model.Archive.String():
+				availability = model.Archive
+			case model.Operational.String():
+				availability = model.Operational
+			case model.Important.String():
+				availability = model.Important
+			case model.Critical.String():
+				availability = model.Critical
+			case model.MissionCritical.String():
+				availability = model.MissionCritical
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'availability' value of technical asset '"+title+"': "+fmt.Sprintf("%v", asset.Availability), "technical_assets", title, "availability")
+			}
+
+			dataFormatsAccepted := make([]model.DataFormat, 0)
+			if asset.Data_formats_accepted != nil {
+				for _, dataFormatName := range asset.Data_formats_accepted {
+					switch dataFormatName {
+					case model.JSON.String():
+						dataFormatsAccepted = append(dataFormatsAccepted, model.JSON)
+					case model.XML.String():
+						dataFormatsAccepted = append(dataFormatsAccepted, model.XML)
+					case model.Serialization.String():
+						dataFormatsAccepted = append(dataFormatsAccepted, model.Serialization)
+					case model.File.String():
+						dataFormatsAccepted = append(dataFormatsAccepted, model.File)
+					case model.CSV.String():
+						dataFormatsAccepted = append(dataFormatsAccepted, model.CSV)
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'data_formats_accepted' value of technical asset '"+title+"': "+fmt.Sprintf("%v", dataFormatName), "technical_assets", title, "data_formats_accepted")
+					}
+				}
+			}
+
+			communicationLinks := make([]model.CommunicationLink, 0)
+			if asset.Communication_links != nil {
+				for commLinkTitle, commLink := range asset.Communication_links {
+					constraint := true
+					weight := 1
+					var protocol model.Protocol
+					var authentication model.Authentication
+					var authorization model.Authorization
+					var usage model.Usage
+					var dataAssetsSent []string
+					var dataAssetsReceived []string
+
+					switch commLink.Authentication {
+					case model.NoneAuthentication.String():
+						authentication = model.NoneAuthentication
+					case model.Credentials.String():
+						authentication = model.Credentials
+					case model.SessionId.String():
+						authentication = model.SessionId
+					case model.Token.String():
+						authentication = model.Token
+					case model.ClientCertificate.String():
+						authentication = model.ClientCertificate
+					case model.TwoFactor.String():
+						authentication = model.TwoFactor
+					case model.Externalized.String():
+						authentication = model.Externalized
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'authentication' value of technical asset '"+title+"' communication link '"+commLinkTitle+"': "+fmt.Sprintf("%v", commLink.Authentication), "technical_assets", title, "communication_links", commLinkTitle, "authentication")
+					}
+
+					switch commLink.Authorization {
+					case model.NoneAuthorization.String():
+						authorization = model.NoneAuthorization
+					case model.TechnicalUser.String():
+						authorization = model.TechnicalUser
+					case model.EnduserIdentityPropagation.String():
+						authorization = model.EnduserIdentityPropagation
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'authorization' value of technical asset '"+title+"' communication link '"+commLinkTitle+"': "+fmt.Sprintf("%v", commLink.Authorization), "technical_assets", title, "communication_links", commLinkTitle, "authorization")
+					}
+
+					switch commLink.Usage {
+					case model.Business.String():
+						usage = model.Business
+					case model.DevOps.String():
+						usage = model.DevOps
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'usage' value of technical asset '"+title+"' communication link '"+commLinkTitle+"': "+fmt.Sprintf("%v", commLink.Usage), "technical_assets", title, "communication_links", commLinkTitle, "usage")
+					}
+
+					switch commLink.Protocol {
+					case model.UnknownProtocol.String():
+						protocol = model.UnknownProtocol
+					case model.HTTP.String():
+						protocol = model.HTTP
+					case model.HTTPS.String():
+						protocol = model.HTTPS
+					case model.WS.String():
+						protocol = model.WS
+					case model.WSS.String():
+						protocol = model.WSS
+					case model.MQTT.String():
+						protocol = model.MQTT
+					case model.JDBC.String():
+						protocol = model.JDBC
+					case model.JDBC_encrypted.String():
+						protocol = model.JDBC_encrypted
+					case model.ODBC.String():
+						protocol = model.ODBC
+					case model.ODBC_encrypted.String():
+						protocol = model.ODBC_encrypted
+					case model.SQL_access_protocol.String():
+						protocol = model.SQL_access_protocol
+					case model.SQL_access_protocol_encrypted.String():
+						protocol = model.SQL_access_protocol_encrypted
+					case model.NoSQL_access_protocol.String():
+						protocol = model.NoSQL_access_protocol
+					case model.NoSQL_access_protocol_encrypted.String():
+						protocol = model.NoSQL_access_protocol_encrypted
+					case model.TEXT.String():
+						protocol = model.TEXT
+					case model.TEXT_encrypted.String():
+						protocol = model.TEXT_encrypted
+					case model.BINARY.String():
+						protocol = model.BINARY
+					case model.BINARY_encrypted.String():
+						protocol = model.BINARY_encrypted
+					case model.SSH.String():
+						protocol = model.SSH
+					case model.SSH_tunnel.String():
+						protocol = model.SSH_tunnel
+					case model.SMTP.String():
+						protocol = model.SMTP
+					case model.SMTP_encrypted.String():
+						protocol = model.SMTP_encrypted
+					case model.POP3.String():
+						protocol = model.POP3
+					case model.POP3_encrypted.String():
+						protocol = model.POP3_encrypted
+					case model.IMAP.String():
+						protocol = model.IMAP
+					case model.IMAP_encrypted.String():
+						protocol = model.IMAP_encrypted
+					case model.FTP.String():
+						protocol = model.FTP
+					case model.FTPS.String():
+						protocol = model.FTPS
+					case model.SFTP.String():
+						protocol = model.SFTP
+					case model.SCP.String():
+						protocol = model.SCP
+					case model.LDAP.String():
+						protocol = model.LDAP
+					case model.LDAPS.String():
+						protocol = model.LDAPS
+					case model.JMS.String():
+						protocol = model.JMS
+					case model.NFS.String():
+						protocol = model.NFS
+					case model.SMB.String():
+						protocol = model.SMB
+					case model.SMB_encrypted.String():
+						protocol = model.SMB_encrypted
+					case model.LocalFileAccess.String():
+						protocol = model.LocalFileAccess
+					case model.NRPE.String():
+						protocol = model.NRPE
+					case model.XMPP.String():
+						protocol = model.XMPP
+					case model.IIOP.String():
+						protocol = model.IIOP
+					case model.IIOP_encrypted.String():
+						protocol = model.IIOP_encrypted
+					case model.JRMP.String():
+						protocol = model.JRMP
+					case model.JRMP_encrypted.String():
+						protocol = model.JRMP_encrypted
+					case model.InProcessLibraryCall.String():
+						protocol = model.InProcessLibraryCall
+					case model.ContainerSpawning.String():
+						protocol = model.ContainerSpawning
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'protocol' of technical asset '"+title+"' communication link '"+commLinkTitle+"': "+fmt.Sprintf("%v", commLink.Protocol), "technical_assets", title, "communication_links", commLinkTitle, "protocol")
+					}
+
+					if commLink.Data_assets_sent != nil {
+						for i, dataAssetSent := range commLink.Data_assets_sent {
+							referencedAsset := fmt.Sprintf("%v", dataAssetSent)
+							result.checkDataAssetTargetExists(referencedAsset, "communication link '"+commLinkTitle+"' of technical asset '"+title+"'",
+								"technical_assets", title, "communication_links", commLinkTitle, "data_assets_sent", strconv.Itoa(i))
+							dataAssetsSent = append(dataAssetsSent, referencedAsset)
+						}
+					}
+
+					if commLink.Data_assets_received != nil {
+						for i, dataAssetReceived := range commLink.Data_assets_received {
+							referencedAsset := fmt.Sprintf("%v", dataAssetReceived)
+							result.checkDataAssetTargetExists(referencedAsset, "communication link '"+commLinkTitle+"' of technical asset '"+title+"'",
+								"technical_assets", title, "communication_links", commLinkTitle, "data_assets_received", strconv.Itoa(i))
+							dataAssetsReceived = append(dataAssetsReceived, referencedAsset)
+						}
+					}
+
+					if commLink.Diagram_tweak_weight > 0 {
+						weight = commLink.Diagram_tweak_weight
+					}
+
+					constraint = !commLink.Diagram_tweak_constraint
+
+					dataFlowTitle := fmt.Sprintf("%v", commLinkTitle)
+					commLink := model.CommunicationLink{
+						Id:                     createDataFlowId(id, dataFlowTitle),
+						SourceId:               id,
+						TargetId:               commLink.Target,
+						Title:                  dataFlowTitle,
+						Description:            withDefault(commLink.Description, dataFlowTitle),
+						Protocol:               protocol,
+						Authentication:         authentication,
+						Authorization:          authorization,
+						Usage:                  usage,
+						Tags:                   result.checkTags(lowerCaseAndTrim(commLink.Tags), "communication link '"+commLinkTitle+"' of technical asset '"+title+"'", "technical_assets", title, "communication_links", commLinkTitle),
+						VPN:                    commLink.VPN,
+						IpFiltered:             commLink.IP_filtered,
+						Readonly:               commLink.Readonly,
+						DataAssetsSent:         dataAssetsSent,
+						DataAssetsReceived:     dataAssetsReceived,
+						DiagramTweakWeight:     weight,
+						DiagramTweakConstraint: constraint,
+					}
+					communicationLinks = append(communicationLinks, commLink)
+					// track all comm links
+					model.CommunicationLinks[commLink.Id] = commLink
+					// keep track of map of *all* comm links mapped by target-id (to be able to lookup "who is calling me" kind of things)
+					model.IncomingTechnicalCommunicationLinksMappedByTargetId[commLink.TargetId] = append(
+						model.IncomingTechnicalCommunicationLinksMappedByTargetId[commLink.TargetId], commLink)
+				}
+			}
+
+			result.checkIdSyntax(id, "technical_assets", title, "id")
+			if _, exists := model.ParsedModelRoot.TechnicalAssets[id]; exists {
+				result.addModelError(model.DiagnosticDuplicateId, "duplicate id used: "+id, "technical_assets", title, "id")
+			}
+			model.ParsedModelRoot.TechnicalAssets[id] = model.TechnicalAsset{
+				Id:                      id,
+				Usage:                   usage,
+				Title:                   title, //fmt.Sprintf("%v", asset["title"]),
+				Description:             withDefault(fmt.Sprintf("%v", asset.Description), title),
+				Type:                    technicalAssetType,
+				Size:                    technicalAssetSize,
+				Technology:              technicalAssetTechnology,
+				Tags:                    result.checkTags(lowerCaseAndTrim(asset.Tags), "technical asset '"+title+"'", "technical_assets", title),
+				Machine:                 technicalAssetMachine,
+				Internet:                asset.Internet,
+				Encryption:              encryption,
+				MultiTenant:             asset.Multi_tenant,
+				Redundant:               asset.Redundant,
+				CustomDevelopedParts:    asset.Custom_developed_parts,
+				UsedAsClientByHuman:     asset.Used_as_client_by_human,
+				OutOfScope:              asset.Out_of_scope,
+				JustificationOutOfScope: fmt.Sprintf("%v", asset.Justification_out_of_scope),
+				Owner:                   fmt.Sprintf("%v", asset.Owner),
+				Confidentiality:         confidentiality,
+				Integrity:               integrity,
+				Availability:            availability,
+				JustificationCiaRating:  fmt.Sprintf("%v", asset.Justification_cia_rating),
+				DataAssetsProcessed:     dataAssetsProcessed,
+				DataAssetsStored:        dataAssetsStored,
+				DataFormatsAccepted:     dataFormatsAccepted,
+				CommunicationLinks:      communicationLinks,
+				DiagramTweakOrder:       asset.Diagram_tweak_order,
+				KnownVulnerabilities:    result.readVulnerabilityScans(title, asset.Vulnerability_scans),
+				SourcecodeRepositories:  trimmed(asset.Sourcecode_repositories),
+				Hosts:                   result.checkHosts(title, asset.Hosts),
+			}
+		}
+
+		// Trust Boundaries ===============================================================================
+		checklistToAvoidAssetBeingModeledInMultipleTrustBoundaries := make(map[string]bool)
+		model.ParsedModelRoot.TrustBoundaries = make(map[string]model.TrustBoundary)
+		for title, boundary := range result.ModelInput.Trust_boundaries {
+			id := fmt.Sprintf("%v", boundary.ID)
+
+			var technicalAssetsInside = make([]string, 0)
+			if boundary.Technical_assets_inside != nil {
+				parsedInsideAssets := boundary.Technical_assets_inside
+				technicalAssetsInside = make([]string, len(parsedInsideAssets))
+				for i, parsedInsideAsset := range parsedInsideAssets {
+					technicalAssetsInside[i] = fmt.Sprintf("%v", parsedInsideAsset)
+					_, found := model.ParsedModelRoot.TechnicalAssets[technicalAssetsInside[i]]
+					if !found {
+						result.addModelError(model.DiagnosticMissingReference, "missing referenced technical asset "+technicalAssetsInside[i]+" at trust boundary '"+title+"'", "trust_boundaries", title, "technical_assets_inside", strconv.Itoa(i))
+					}
+					if checklistToAvoidAssetBeingModeledInMultipleTrustBoundaries[technicalAssetsInside[i]] == true {
+						result.addModelError(model.DiagnosticMultipleTrustBoundaries, "referenced technical asset "+technicalAssetsInside[i]+" at trust boundary '"+title+"' is modeled in multiple trust boundaries", "trust_boundaries", title, "technical_assets_inside", strconv.Itoa(i))
+					}
+					checklistToAvoidAssetBeingModeledInMultipleTrustBoundaries[technicalAssetsInside[i]] = true
+					//fmt.Println("asset "+technicalAssetsInside[i]+" at i="+strconv.Itoa(i))
+				}
+			}
+
+			var trustBoundariesNested = make([]string, 0)
+			if boundary.Trust_boundaries_nested != nil {
+				parsedNestedBoundaries := boundary.Trust_boundaries_nested
+				trustBoundariesNested = make([]string, len(parsedNestedBoundaries))
+				for i, parsedNestedBoundary := range parsedNestedBoundaries {
+					trustBoundariesNested[i] = fmt.Sprintf("%v", parsedNestedBoundary)
+				}
+			}
+
+			var trustBoundaryType model.TrustBoundaryType
+			switch boundary.Type {
+			case model.NetworkOnPrem.String():
+				trustBoundaryType = model.NetworkOnPrem
+			case model.NetworkDedicatedHoster.String():
+				trustBoundaryType = model.NetworkDedicatedHoster
+			case model.NetworkVirtualLAN.String():
+				trustBoundaryType = model.NetworkVirtualLAN
+			case model.NetworkCloudProvider.String():
+				trustBoundaryType = model.NetworkCloudProvider
+			case model.NetworkCloudSecurityGroup.String():
+				trustBoundaryType = model.NetworkCloudSecurityGroup
+			case model.NetworkPolicyNamespaceIsolation.String():
+				trustBoundaryType = model.NetworkPolicyNamespaceIsolation
+			case model.ExecutionEnvironment.String():
+				trustBoundaryType = model.ExecutionEnvironment
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'type' of trust boundary '"+title+"': "+fmt.Sprintf("%v", boundary.Type), "trust_boundaries", title, "type")
+			}
+
+			trustBoundary := model.TrustBoundary{
+				Id:                    id,
+				Title:                 title, //fmt.Sprintf("%v", boundary["title"]),
+				Description:           withDefault(fmt.Sprintf("%v", boundary.Description), title),
+				Type:                  trustBoundaryType,
+				Tags:                  result.checkTags(lowerCaseAndTrim(boundary.Tags), "trust boundary '"+title+"'", "trust_boundaries", title),
+				TechnicalAssetsInside: technicalAssetsInside,
+				TrustBoundariesNested: trustBoundariesNested,
+			}
+			result.checkIdSyntax(id, "trust_boundaries", title, "id")
+			if _, exists := model.ParsedModelRoot.TrustBoundaries[id]; exists {
+				result.addModelError(model.DiagnosticDuplicateId, "duplicate id used: "+id, "trust_boundaries", title, "id")
+			}
+			model.ParsedModelRoot.TrustBoundaries[id] = trustBoundary
+			for _, technicalAsset := range trustBoundary.TechnicalAssetsInside {
+				model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[technicalAsset] = trustBoundary
+				//fmt.Println("Asset "+technicalAsset+" is directly in trust boundary "+trustBoundary.Id)
+			}
+		}
+		result.checkNestedTrustBoundariesExisting()
+
+		// Shared Runtime ===============================================================================
+		model.ParsedModelRoot.SharedRuntimes = make(map[string]model.SharedRuntime)
+		for title, runtime := range result.ModelInput.Shared_runtimes {
+			id := fmt.Sprintf("%v", runtime.ID)
+
+			var technicalAssetsRunning = make([]string, 0)
+			if runtime.Technical_assets_running != nil {
+				parsedRunningAssets := runtime.Technical_assets_running
+				technicalAssetsRunning = make([]string, len(parsedRunningAssets))
+				for i, parsedRunningAsset := range parsedRunningAssets {
+					assetId := fmt.Sprintf("%v", parsedRunningAsset)
+					result.checkTechnicalAssetExists(assetId, "shared runtime '"+title+"'", false, "shared_runtimes", title, "technical_assets_running", strconv.Itoa(i))
+					technicalAssetsRunning[i] = assetId
+				}
+			}
+
+			sharedRuntime := model.SharedRuntime{
+				Id:                     id,
+				Title:                  title, //fmt.Sprintf("%v", boundary["title"]),
+				Description:            withDefault(fmt.Sprintf("%v", runtime.Description), title),
+				Tags:                   result.checkTags((runtime.Tags), "shared runtime '"+title+"'", "shared_runtimes", title),
+				TechnicalAssetsRunning: technicalAssetsRunning,
+			}
+			result.checkIdSyntax(id, "shared_runtimes", title, "id")
+			if _, exists := model.ParsedModelRoot.SharedRuntimes[id]; exists {
+				result.addModelError(model.DiagnosticDuplicateId, "duplicate id used: "+id, "shared_runtimes", title, "id")
+			}
+			model.ParsedModelRoot.SharedRuntimes[id] = sharedRuntime
+			for _, technicalAssetId := range sharedRuntime.TechnicalAssetsRunning {
+				model.DirectContainingSharedRuntimeMappedByTechnicalAssetId[technicalAssetId] = sharedRuntime
+			}
+		}
+
+		// Compliance Mappings (extending the ones of the risk rules) ===============================================================================
+		model.ParsedModelRoot.ComplianceMappings = make(map[string]map[string][]string)
+		model.ParsedModelRoot.ComplianceControls = make(map[string]map[string][]string)
+		for categoryId, mapping := range result.ModelInput.Compliance_mappings {
+			model.ParsedModelRoot.ComplianceMappings[categoryId] = model.MergeComplianceControls(mapping)
+		}
+
+		// Individual Risk Categories (just used as regular risk categories) ===============================================================================
+		model.ParsedModelRoot.IndividualRiskCategories = make(map[string]model.RiskCategory)
+		for title, indivCat := range result.ModelInput.Individual_risk_categories {
+			id := fmt.Sprintf("%v", indivCat.ID)
+
+			var function model.RiskFunction
+			switch indivCat.Function {
+			case model.BusinessSide.String():
+				function = model.BusinessSide
+			case model.Architecture.String():
+				function = model.Architecture
+			case model.Development.String():
+				function = model.Development
+			case model.Operations.String():
+				function = model.Operations
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'function' value of individual risk category '"+title+"': "+fmt.Sprintf("%v", indivCat.Function), "individual_risk_categories", title, "function")
+			}
+
+			var stride model.STRIDE
+			switch indivCat.STRIDE {
+			case model.Spoofing.String():
+				stride = model.Spoofing
+			case model.Tampering.String():
+				stride = model.Tampering
+			case model.Repudiation.String():
+				stride = model.Repudiation
+			case model.InformationDisclosure.String():
+				stride = model.InformationDisclosure
+			case model.DenialOfService.String():
+				stride = model.DenialOfService
+			case model.ElevationOfPrivilege.String():
+				stride = model.ElevationOfPrivilege
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'stride' value of individual risk category '"+title+"': "+fmt.Sprintf("%v", indivCat.STRIDE), "individual_risk_categories", title, "stride")
+			}
+
+			cat := model.RiskCategory{
+				Id:                         id,
+				Title:                      title,
+				Description:                withDefault(fmt.Sprintf("%v", indivCat.Description), title),
+				Impact:                     fmt.Sprintf("%v", indivCat.Impact),
+				ASVS:                       fmt.Sprintf("%v", indivCat.ASVS),
+				CheatSheet:                 fmt.Sprintf("%v", indivCat.Cheat_sheet),
+				Action:                     fmt.Sprintf("%v", indivCat.Action),
+				Mitigation:                 fmt.Sprintf("%v", indivCat.Mitigation),
+				Check:                      fmt.Sprintf("%v", indivCat.Check),
+				DetectionLogic:             fmt.Sprintf("%v", indivCat.Detection_logic),
+				RiskAssessment:             fmt.Sprintf("%v", indivCat.Risk_assessment),
+				FalsePositives:             fmt.Sprintf("%v", indivCat.False_positives),
+				Function:                   function,
+				STRIDE:                     stride,
+				ModelFailurePossibleReason: indivCat.Model_failure_possible_reason,
+				CWE:                        indivCat.CWE,
+			}
+			result.checkIdSyntax(id, "individual_risk_categories", title, "id")
+			if _, exists := model.ParsedModelRoot.IndividualRiskCategories[id]; exists {
+				result.addModelError(model.DiagnosticDuplicateId, "duplicate id used: "+id, "individual_risk_categories", title, "id")
+			}
+			model.ParsedModelRoot.IndividualRiskCategories[id] = cat
+			model.ParsedModelRoot.ComplianceControls[id] = model.MergeComplianceControls(indivCat.Compliance, model.ParsedModelRoot.ComplianceMappings[id])
+
+			// NOW THE INDIVIDUAL RISK INSTANCES:
+			//individualRiskInstances := make([]model.Risk, 0)
+			categoryTitle := title
+			if indivCat.Risks_identified != nil { // TODO: also add syntax checks of input YAML when linked asset is not found or when syntehtic-id is already used...
+				for title, indivRiskInstance := range indivCat.Risks_identified {
+					var severity model.RiskSeverity
+					var exploitationLikelihood model.RiskExploitationLikelihood
+					var exploitationImpact model.RiskExploitationImpact
+					var mostRelevantDataAssetId, mostRelevantTechnicalAssetId, mostRelevantCommunicationLinkId, mostRelevantTrustBoundaryId, mostRelevantSharedRuntimeId string
+					var dataBreachProbability model.DataBreachProbability
+					var dataBreachTechnicalAssetIDs []string
+
+					switch indivRiskInstance.Severity {
+					case model.LowSeverity.String():
+						severity = model.LowSeverity
+					case model.MediumSeverity.String():
+						severity = model.MediumSeverity
+					case model.ElevatedSeverity.String():
+						severity = model.ElevatedSeverity
+					case model.HighSeverity.String():
+						severity = model.HighSeverity
+					case model.CriticalSeverity.String():
+						severity = model.CriticalSeverity
+					case "": // added default
+						severity = model.MediumSeverity
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'severity' value of individual risk instance '"+title+"': "+fmt.Sprintf("%v", indivRiskInstance.Severity), "individual_risk_categories", categoryTitle, "risks_identified", title, "severity")
+					}
+
+					switch indivRiskInstance.Exploitation_likelihood {
+					case model.Unlikely.String():
+						exploitationLikelihood = model.Unlikely
+					case model.Likely.String():
+						exploitationLikelihood = model.Likely
+					case model.VeryLikely.String():
+						exploitationLikelihood = model.VeryLikely
+					case model.Frequent.String():
+						exploitationLikelihood = model.Frequent
+					case "": // added default
+						exploitationLikelihood = model.Likely
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'exploitation_likelihood' value of individual risk instance '"+title+"': "+fmt.Sprintf("%v", indivRiskInstance.Exploitation_likelihood), "individual_risk_categories", categoryTitle, "risks_identified", title, "exploitation_likelihood")
+					}
+
+					switch indivRiskInstance.Exploitation_impact {
+					case model.LowImpact.String():
+						exploitationImpact = model.LowImpact
+					case model.MediumImpact.String():
+						exploitationImpact = model.MediumImpact
+					case model.HighImpact.String():
+						exploitationImpact = model.HighImpact
+					case model.VeryHighImpact.String():
+						exploitationImpact = model.VeryHighImpact
+					case "": // added default
+						exploitationImpact = model.MediumImpact
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'exploitation_impact' value of individual risk instance '"+title+"': "+fmt.Sprintf("%v", indivRiskInstance.Exploitation_impact), "individual_risk_categories", categoryTitle, "risks_identified", title, "exploitation_impact")
+					}
+
+					if len(indivRiskInstance.Most_relevant_data_asset) > 0 {
+						mostRelevantDataAssetId = fmt.Sprintf("%v", indivRiskInstance.Most_relevant_data_asset)
+						result.checkDataAssetTargetExists(mostRelevantDataAssetId, "individual risk '"+title+"'",
+							"individual_risk_categories", categoryTitle, "risks_identified", title, "most_relevant_data_asset")
+					}
+
+					if len(indivRiskInstance.Most_relevant_technical_asset) > 0 {
+						mostRelevantTechnicalAssetId = fmt.Sprintf("%v", indivRiskInstance.Most_relevant_technical_asset)
+						result.checkTechnicalAssetExists(mostRelevantTechnicalAssetId, "individual risk '"+title+"'", false,
+							"individual_risk_categories", categoryTitle, "risks_identified", title, "most_relevant_technical_asset")
+					}
+
+					if len(indivRiskInstance.Most_relevant_communication_link) > 0 {
+						mostRelevantCommunicationLinkId = fmt.Sprintf("%v", indivRiskInstance.Most_relevant_communication_link)
+						result.checkCommunicationLinkExists(mostRelevantCommunicationLinkId, "individual risk '"+title+"'",
+							"individual_risk_categories", categoryTitle, "risks_identified", title, "most_relevant_communication_link")
+					}
+
+					if len(indivRiskInstance.Most_relevant_trust_boundary) > 0 {
+						mostRelevantTrustBoundaryId = fmt.Sprintf("%v", indivRiskInstance.Most_relevant_trust_boundary)
+						result.checkTrustBoundaryExists(mostRelevantTrustBoundaryId, "individual risk '"+title+"'",
+							"individual_risk_categories", categoryTitle, "risks_identified", title, "most_relevant_trust_boundary")
+					}
+
+					if len(indivRiskInstance.Most_relevant_shared_runtime) > 0 {
+						mostRelevantSharedRuntimeId = fmt.Sprintf("%v", indivRiskInstance.Most_relevant_shared_runtime)
+						result.checkSharedRuntimeExists(mostRelevantSharedRuntimeId, "individual risk '"+title+"'",
+							"individual_risk_categories", categoryTitle, "risks_identified", title, "most_relevant_shared_runtime")
+					}
+
+					switch indivRiskInstance.Data_breach_probability {
+					case model.Improbable.String():
+						dataBreachProbability = model.Improbable
+					case model.Possible.String():
+						dataBreachProbability = model.Possible
+					case model.Probable.String():
+						dataBreachProbability = model.Probable
+					case "": // added default
+						dataBreachProbability = model.Possible
+					default:
+						result.addModelError(model.DiagnosticUnknownValue, "unknown 'data_breach_probability' value of individual risk instance '"+title+"': "+fmt.Sprintf("%v", indivRiskInstance.Data_breach_probability), "individual_risk_categories", categoryTitle, "risks_identified", title, "data_breach_probability")
+					}
+
+					if indivRiskInstance.Data_breach_technical_assets != nil {
+						dataBreachTechnicalAssetIDs = make([]string, len(indivRiskInstance.Data_breach_technical_assets))
+						for i, parsedReferencedAsset := range indivRiskInstance.Data_breach_technical_assets {
+							assetId := fmt.Sprintf("%v", parsedReferencedAsset)
+							result.checkTechnicalAssetExists(assetId, "data breach technical assets of individual risk '"+title+"'", false,
+								"individual_risk_categories", categoryTitle, "risks_identified", title, "data_breach_technical_assets", strconv.Itoa(i))
+							dataBreachTechnicalAssetIDs[i] = assetId
+						}
+					}
+
+					indivRiskInstance := model.Risk{
+						SyntheticId:                     createSyntheticId(cat.Id, mostRelevantDataAssetId, mostRelevantTechnicalAssetId, mostRelevantCommunicationLinkId, mostRelevantTrustBoundaryId, mostRelevantSharedRuntimeId),
+						Title:                           fmt.Sprintf("%v", title),
+						Category:                        cat,
+						Severity:                        severity,
+						ExploitationLikelihood:          exploitationLikelihood,
+						ExploitationImpact:              exploitationImpact,
+						MostRelevantDataAssetId:         mostRelevantDataAssetId,
+						MostRelevantTechnicalAssetId:    mostRelevantTechnicalAssetId,
+						MostRelevantCommunicationLinkId: mostRelevantCommunicationLinkId,
+						MostRelevantTrustBoundaryId:     mostRelevantTrustBoundaryId,
+						MostRelevantSharedRuntimeId:     mostRelevantSharedRuntimeId,
+						DataBreachProbability:           dataBreachProbability,
+						DataBreachTechnicalAssetIDs:     dataBreachTechnicalAssetIDs,
+					}
+					model.GeneratedRisksByCategory[cat] = append(model.GeneratedRisksByCategory[cat], indivRiskInstance)
+				}
+			}
+		}
+
+		// Risk Tracking ===============================================================================
+		model.ParsedModelRoot.RiskTracking = make(map[string]model.RiskTracking)
+		for syntheticRiskId, riskTracking := range result.ModelInput.Risk_tracking {
+			justification := fmt.Sprintf("%v", riskTracking.Justification)
+			checkedBy := fmt.Sprintf("%v", riskTracking.Checked_by)
+			ticket := fmt.Sprintf("%v", riskTracking.Ticket)
+			var date time.Time
+			if len(riskTracking.Date) > 0 {
+				date, err = time.Parse("2006-01-02", riskTracking.Date)
+				if err != nil {
+					result.addModelError(model.DiagnosticInvalidDate, "unable to parse 'date' of risk tracking '"+syntheticRiskId+"': "+riskTracking.Date, "risk_tracking", syntheticRiskId, "date")
+				}
+			}
+
+			var status model.RiskStatus
+			switch riskTracking.Status {
+			case model.Unchecked.String():
+				status = model.Unchecked
+			case model.Mitigated.String():
+				status = model.Mitigated
+			case model.InProgress.String():
+				status = model.InProgress
+			case model.Accepted.String():
+				status = model.Accepted
+			case model.InDiscussion.String():
+				status = model.InDiscussion
+			case model.FalsePositive.String():
+				status = model.FalsePositive
+			default:
+				result.addModelError(model.DiagnosticUnknownValue, "unknown 'status' value of risk tracking '"+syntheticRiskId+"': "+riskTracking.Status, "risk_tracking", syntheticRiskId, "status")
+			}
+
+			tracking := model.RiskTracking{
+				SyntheticRiskId: strings.TrimSpace(syntheticRiskId),
+				Justification:   justification,
+				CheckedBy:       checkedBy,
+				Ticket:          ticket,
+				Date:            date,
+				Status:          status,
+			}
+			if strings.Contains(syntheticRiskId, "*") { // contains a wildcard char
+				result.deferredRiskTrackingDueToWildcardMatching[syntheticRiskId] = tracking
+			} else {
+				model.ParsedModelRoot.RiskTracking[syntheticRiskId] = tracking
+			}
+		}
+
+		// ====================== model consistency check (linking)
+		for _, technicalAsset := range model.ParsedModelRoot.TechnicalAssets {
+			for _, commLink := range technicalAsset.CommunicationLinks {
+				result.checkTechnicalAssetExists(commLink.TargetId, "communication link '"+commLink.Title+"' of technical asset '"+technicalAsset.Title+"'", false,
+					"technical_assets", technicalAsset.Title, "communication_links", commLink.Title, "target")
+			}
+		}
+
+		result.checkDiagramTweaks()
+		result.abortOnModelErrors()
+	} else {
+		result.addModelDiagnosticInFile(model.ErrorDiagnostic, model.DiagnosticUnreadableFile, "unable to read model file: "+err.Error(), inputFilename, 0)
+		result.abortOnModelErrors()
+	}
+}
+
+// resolves the model files listed under "includes:" (relative to the including file) and merges their maps into the
+// model input, so that large models can be split into several files (e.g. one per team) to avoid merge conflicts
+func (result *AnalysisResult) mergeIncludedModelFiles(inputFilename string) {
+	result.ModelFilenames = []string{inputFilename}
+	if len(result.ModelInput.Includes) == 0 {
+		return
+	}
+	modelFolder, err := filepath.Abs(filepath.Dir(inputFilename))
+	checkErr(err)
+	absoluteFilename, err := filepath.Abs(inputFilename)
+	checkErr(err)
+	sources := make(map[string]string)
+	result.registerModelElementSources(inputFilename, result.ModelInput, sources)
+	result.includeModelFiles(inputFilename, result.ModelInput.Includes, modelFolder, map[string]bool{absoluteFilename: true}, sources)
+}
+
+func (result *AnalysisResult) includeModelFiles(includingFilename string, includes []string, modelFolder string, alreadyIncluded map[string]bool, sources map[string]string) {
+	for i, include := range includes {
+		includePath := []string{"includes", strconv.Itoa(i)}
+		include = strings.TrimSpace(include)
+		if len(include) == 0 {
+			continue
+		}
+		filename := filepath.Join(filepath.Dir(includingFilename), include)
+		absoluteFilename, err := filepath.Abs(filename)
+		checkErr(err)
+		// in order to prevent Path-Traversal like stuff (especially for models uploaded to the server)...
+		if !strings.HasPrefix(absoluteFilename, modelFolder+string(os.PathSeparator)) {
+			result.addModelErrorInFile(model.DiagnosticInvalidInclude, "included model file must reside within the folder of the model file: "+include, includingFilename, includePath...)
+			continue
+		}
+		if alreadyIncluded[absoluteFilename] {
+			result.addModelErrorInFile(model.DiagnosticInvalidInclude, "model file included more than once (or cyclic includes): "+include, includingFilename, includePath...)
+			continue
+		}
+		alreadyIncluded[absoluteFilename] = true
+		if result.analyzer.Verbose {
+			fmt.Println("Including model file:", filename)
+		}
+		includedYaml, err := ioutil.ReadFile(filename)
+		if err != nil {
+			result.addModelErrorInFile(model.DiagnosticUnreadableFile, "unable to read included model file "+include+": "+err.Error(), includingFilename, includePath...)
+			continue
+		}
+		includedInput := model.ModelInput{}
+		err = yaml.Unmarshal(includedYaml, &includedInput)
+		if err != nil {
+			result.addYamlSyntaxDiagnostic(filename, err)
+			continue
+		}
+		result.ModelFilenames = append(result.ModelFilenames, filename)
+		result.registerModelElementPositions(filename, includedYaml)
+		result.registerModelElementSources(filename, includedInput, sources)
+		result.mergeModelInput(includedInput)
+		result.includeModelFiles(filename, includedInput.Includes, modelFolder, alreadyIncluded, sources)
+	}
+}
+
+// remembers in which file each element was defined in order to report duplicates with both source files
+func (result *AnalysisResult) registerModelElementSources(filename string, input model.ModelInput, sources map[string]string) {
+	for title, asset := range input.Data_assets {
+		result.registerModelElementSource(sources, "data asset", title, filename, "data_assets", title)
+		result.registerModelElementSource(sources, "data asset id", asset.ID, filename, "data_assets", title, "id")
+	}
+	for title, asset := range input.Technical_assets {
+		result.registerModelElementSource(sources, "technical asset", title, filename, "technical_assets", title)
+		result.registerModelElementSource(sources, "technical asset id", asset.ID, filename, "technical_assets", title, "id")
+	}
+	for title, boundary := range input.Trust_boundaries {
+		result.registerModelElementSource(sources, "trust boundary", title, filename, "trust_boundaries", title)
+		result.registerModelElementSource(sources, "trust boundary id", boundary.ID, filename, "trust_boundaries", title, "id")
+	}
+	for title, runtime := range input.Shared_runtimes {
+		result.registerModelElementSource(sources, "shared runtime", title, filename, "shared_runtimes", title)
+		result.registerModelElementSource(sources, "shared runtime id", runtime.ID, filename, "shared_runtimes", title, "id")
+	}
+	for title, category := range input.Individual_risk_categories {
+		result.registerModelElementSource(sources, "individual risk category", title, filename, "individual_risk_categories", title)
+		result.registerModelElementSource(sources, "individual risk category id", category.ID, filename, "individual_risk_categories", title, "id")
+	}
+	for syntheticRiskId := range input.Risk_tracking {
+		result.registerModelElementSource(sources, "risk tracking", syntheticRiskId, filename, "risk_tracking", syntheticRiskId)
+	}
+}
+
+func (result *AnalysisResult) registerModelElementSource(sources map[string]string, kind, id, filename string, path ...string) {
+	key := kind + ":" + id
+	if otherFilename, exists := sources[key]; exists && otherFilename != filename {
+		result.addModelErrorInFile(model.DiagnosticDuplicateId, "duplicate "+kind+" (already used in model file "+otherFilename+"): "+id, filename, path...)
+		return
+	}
+	sources[key] = filename
+}
+
+func (result *AnalysisResult) mergeModelInput(includedInput model.ModelInput) {
+	for _, tag := range includedInput.Tags_available {
+		if !model.Contains(result.ModelInput.Tags_available, tag) {
+			result.ModelInput.Tags_available = append(result.ModelInput.Tags_available, tag)
+		}
+	}
+	if result.ModelInput.Data_assets == nil {
+		result.ModelInput.Data_assets = make(map[string]model.InputDataAsset)
+	}
+	for title, asset := range includedInput.Data_assets {
+		result.ModelInput.Data_assets[title] = asset
+	}
+	if result.ModelInput.Technical_assets == nil {
+		result.ModelInput.Technical_assets = make(map[string]model.InputTechnicalAsset)
+	}
+	for title, asset := range includedInput.Technical_assets {
+		result.ModelInput.Technical_assets[title] = asset
+	}
+	if result.ModelInput.Trust_boundaries == nil {
+		result.ModelInput.Trust_boundaries = make(map[string]model.InputTrustBoundary)
+	}
+	for title, boundary := range includedInput.Trust_boundaries {
+		result.ModelInput.Trust_boundaries[title] = boundary
+	}
+	if result.ModelInput.Shared_runtimes == nil {
+		result.ModelInput.Shared_runtimes = make(map[string]model.InputSharedRuntime)
+	}
+	for title, runtime := range includedInput.Shared_runtimes {
+		result.ModelInput.Shared_runtimes[title] = runtime
+	}
+	if result.ModelInput.Individual_risk_categories == nil {
+		result.ModelInput.Individual_risk_categories = make(map[string]model.InputIndividualRiskCategory)
+	}
+	for title, category := range includedInput.Individual_risk_categories {
+		result.ModelInput.Individual_risk_categories[title] = category
+	}
+	if result.ModelInput.Risk_tracking == nil {
+		result.ModelInput.Risk_tracking = make(map[string]model.InputRiskTracking)
+	}
+	for syntheticRiskId, tracking := range includedInput.Risk_tracking {
+		result.ModelInput.Risk_tracking[syntheticRiskId] = tracking
+	}
+	if result.ModelInput.Compliance_mappings == nil {
+		result.ModelInput.Compliance_mappings = make(map[string]map[string][]string)
+	}
+	for categoryId, mapping := range includedInput.Compliance_mappings {
+		result.ModelInput.Compliance_mappings[categoryId] = model.MergeComplianceControls(result.ModelInput.Compliance_mappings[categoryId], mapping)
+	}
+}
+
+func lowerCaseAndTrim(tags []string) []string {
+	for i := range tags {
+		tags[i] = strings.ToLower(strings.TrimSpace(tags[i]))
+	}
+	return tags
+}
+
+func trimmed(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func (result *AnalysisResult) checkTags(tags []string, where string, path ...string) []string {
+	var tagsUsed = make([]string, 0)
+	if tags != nil {
+		tagsUsed = make([]string, len(tags))
+		for i, parsedEntry := range tags {
+			referencedTag := fmt.Sprintf("%v", parsedEntry)
+			result.checkTagExists(referencedTag, where, appendPath(path, "tags", strconv.Itoa(i))...)
+			tagsUsed[i] = referencedTag
+		}
+	}
+	return tagsUsed
+}
+
+// in order to prevent Path-Traversal like stuff...
+func removePathElementsFromImageFiles(overview model.Overview) model.Overview {
+	for i, _ := range overview.Images {
+		newValue := make(map[string]string)
+		for file, desc := range overview.Images[i] {
+			newValue[filepath.Base(file)] = desc
+		}
+		overview.Images[i] = newValue
+	}
+	return overview
+}
+
+func withDefault(value string, defaultWhenEmpty string) string {
+	trimmed := strings.TrimSpace(value)
+	if len(trimmed) > 0 && trimmed != "<nil>" {
+		return trimmed
+	}
+	return strings.TrimSpace(defaultWhenEmpty)
+}
+
+func createDataFlowId(sourceAssetId, title string) string {
+	reg, err := regexp.Compile("[^A-Za-z0-9]+")
+	checkErr(err)
+	return sourceAssetId + ">" + strings.Trim(reg.ReplaceAllString(strings.ToLower(title), "-"), "- ")
+}
+
+func createSyntheticId(categoryId string,
+	mostRelevantDataAssetId, mostRelevantTechnicalAssetId, mostRelevantCommunicationLinkId, mostRelevantTrustBoundaryId, mostRelevantSharedRuntimeId string) string {
+	result := categoryId
+	if len(mostRelevantTechnicalAssetId) > 0 {
+		result += "@" + mostRelevantTechnicalAssetId
+	}
+	if len(mostRelevantCommunicationLinkId) > 0 {
+		result += "@" + mostRelevantCommunicationLinkId
+	}
+	if len(mostRelevantTrustBoundaryId) > 0 {
+		result += "@" + mostRelevantTrustBoundaryId
+	}
+	if len(mostRelevantSharedRuntimeId) > 0 {
+		result += "@" + mostRelevantSharedRuntimeId
+	}
+	if len(mostRelevantDataAssetId) > 0 {
+		result += "@" + mostRelevantDataAssetId
+	}
+	return result
+}
+
+func (result *AnalysisResult) checkTagExists(referencedTag, where string, path ...string) {
+	if !model.Contains(model.ParsedModelRoot.TagsAvailable, referencedTag) {
+		result.addModelError(model.DiagnosticMissingTag, "missing referenced tag in overall tag list at "+where+": "+referencedTag, path...)
+	}
+}
+
+func (result *AnalysisResult) checkDataAssetTargetExists(referencedAsset, where string, path ...string) {
+	if _, ok := model.ParsedModelRoot.DataAssets[referencedAsset]; !ok {
+		result.addModelError(model.DiagnosticMissingReference, "missing referenced data asset target at "+where+": "+referencedAsset, path...)
+	}
+}
+
+func (result *AnalysisResult) checkTrustBoundaryExists(referencedId, where string, path ...string) {
+	if _, ok := model.ParsedModelRoot.TrustBoundaries[referencedId]; !ok {
+		result.addModelError(model.DiagnosticMissingReference, "missing referenced trust boundary at "+where+": "+referencedId, path...)
+	}
+}
+
+func (result *AnalysisResult) checkSharedRuntimeExists(referencedId, where string, path ...string) {
+	if _, ok := model.ParsedModelRoot.SharedRuntimes[referencedId]; !ok {
+		result.addModelError(model.DiagnosticMissingReference, "missing referenced shared runtime at "+where+": "+referencedId, path...)
+	}
+}
+
+func (result *AnalysisResult) checkCommunicationLinkExists(referencedId, where string, path ...string) {
+	if _, ok := model.CommunicationLinks[referencedId]; !ok {
+		result.addModelError(model.DiagnosticMissingReference, "missing referenced communication link at "+where+": "+referencedId, path...)
+	}
+}
+
+func (result *AnalysisResult) checkTechnicalAssetExists(referencedAsset, where string, onlyForTweak bool, path ...string) {
+	if _, ok := model.ParsedModelRoot.TechnicalAssets[referencedAsset]; !ok {
+		code, suffix := model.DiagnosticMissingReference, ""
+		if onlyForTweak {
+			code, suffix = model.DiagnosticInvalidDiagramTweak, " (only referenced in diagram tweak)"
+		}
+		result.addModelError(code, "missing referenced technical asset target"+suffix+" at "+where+": "+referencedAsset, path...)
+	}
+}
+
+func (result *AnalysisResult) checkNestedTrustBoundariesExisting() {
+	for _, trustBoundary := range model.ParsedModelRoot.TrustBoundaries {
+		for i, nestedId := range trustBoundary.TrustBoundariesNested {
+			if _, ok := model.ParsedModelRoot.TrustBoundaries[nestedId]; !ok {
+				result.addModelError(model.DiagnosticMissingReference, "missing referenced nested trust boundary: "+nestedId,
+					"trust_boundaries", trustBoundary.Title, "trust_boundaries_nested", strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+// checkDiagramTweaks validates the diagram tweaks upfront, so that their problems are reported along with all other
+// model diagnostics instead of failing later while rendering the diagrams
+func (result *AnalysisResult) checkDiagramTweaks() {
+	switch model.ParsedModelRoot.DiagramTweakEdgeLayout {
+	case "", "spline", "polyline", "ortho", "curved", "false":
+	default:
+		result.addModelError(model.DiagnosticInvalidDiagramTweak, "unknown 'diagram_tweak_edge_layout' value (spline, polyline, ortho, curved, false): "+
+			model.ParsedModelRoot.DiagramTweakEdgeLayout, "diagram_tweak_edge_layout")
+	}
+	for i, invisibleConnection := range model.ParsedModelRoot.DiagramTweakInvisibleConnectionsBetweenAssets {
+		path := []string{"diagram_tweak_invisible_connections_between_assets", strconv.Itoa(i)}
+		assetIDs := strings.Split(invisibleConnection, ":")
+		if len(assetIDs) != 2 {
+			result.addModelWarning(model.DiagnosticInvalidDiagramTweak, "ignoring diagram tweak connection not in the form 'asset-id:asset-id': "+invisibleConnection, path...)
+			continue
+		}
+		result.checkTechnicalAssetExists(assetIDs[0], "diagram tweak connections", true, path...)
+		result.checkTechnicalAssetExists(assetIDs[1], "diagram tweak connections", true, path...)
+	}
+	for i, sameRank := range model.ParsedModelRoot.DiagramTweakSameRankAssets {
+		path := []string{"diagram_tweak_same_rank_assets", strconv.Itoa(i)}
+		for _, id := range strings.Split(sameRank, ":") {
+			result.checkTechnicalAssetExists(id, "diagram tweak same-rank", true, path...)
+			if len(model.ParsedModelRoot.TechnicalAssets[id].GetTrustBoundaryId()) > 0 {
+				result.addModelError(model.DiagnosticInvalidDiagramTweak, "technical assets (referenced in same rank diagram tweak) are inside trust boundaries: "+
+					fmt.Sprintf("%v", model.ParsedModelRoot.DiagramTweakSameRankAssets), path...)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"plugin"
+
+	"github.com/threagile/threagile/model"
+)
+
+func (result *AnalysisResult) applyRAA() string {
+	if result.analyzer.Verbose {
+		fmt.Println("Applying RAA calculation:", result.analyzer.RAAPlugin)
+	}
+	// determine plugin to load
+	// load plugin: open the ".so" file to load the symbols
+	plug, err := plugin.Open(result.analyzer.RAAPlugin)
+	checkErr(err)
+	// look up a symbol (an exported function or variable): in this case, function CalculateRAA
+	symCalculateRAA, err := plug.Lookup("CalculateRAA")
+	checkErr(err)
+	// use the plugin
+	raaCalcFunc, ok := symCalculateRAA.(func() string) // symCalculateRAA.(func(model.ParsedModel) string)
+	if !ok {
+		panic(errors.New("RAA plugin has no 'CalculateRAA() string' function"))
+	}
+	// call it
+	return raaCalcFunc()
+}
+
+// LoadCustomRiskRules loads the given plugins (.so shared object files) each providing a 'CustomRiskRule' variable
+func LoadCustomRiskRules(pluginFiles []string, verbose bool) (customRiskRules map[string]model.CustomRiskRule, err error) {
+	defer recoverError(&err)
+	customRiskRules = make(map[string]model.CustomRiskRule, 0)
+	if len(pluginFiles) > 0 {
+		if verbose {
+			fmt.Println("Loading custom risk rules:", pluginFiles)
+		}
+		for _, pluginFile := range pluginFiles {
+			if len(pluginFile) > 0 {
+				// check that the plugin file to load exists
+				_, err := os.Stat(pluginFile)
+				if os.IsNotExist(err) {
+					panic(errors.New("custom risk rule implementation file not found: " + pluginFile))
+				}
+				// load plugin: open the ".so" file to load the symbols
+				plug, err := plugin.Open(pluginFile)
+				checkErr(err)
+				// look up a symbol (an exported function or variable): in this case variable CustomRiskRule
+				symCustomRiskRule, err := plug.Lookup("CustomRiskRule")
+				checkErr(err)
+				// register the risk rule plugin for later use: in this case interface type model.CustomRiskRule (defined above)
+				symCustomRiskRuleVar, ok := symCustomRiskRule.(model.CustomRiskRule)
+				if !ok {
+					panic(errors.New("custom risk rule plugin has no 'CustomRiskRule' variable"))
+				}
+				// simply add to a map (just convenience) where key is the category id and value the rule's execution function
+				ruleID := symCustomRiskRuleVar.Category().Id
+				customRiskRules[ruleID] = symCustomRiskRuleVar
+				if verbose {
+					fmt.Println("Custom risk rule loaded:", ruleID)
+				}
+			}
+		}
+		if verbose {
+			fmt.Println("Loaded custom risk rules:", len(customRiskRules))
+		}
+	}
+	return customRiskRules, nil
+}
@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
+	_ "github.com/threagile/threagile/risks/built-in"
+)
+
+func (result *AnalysisResult) applyRiskGeneration() {
+	if result.analyzer.Verbose {
+		fmt.Println("Applying risk generation")
+	}
+	skippedRules := make(map[string]interface{})
+	if len(result.analyzer.SkipRiskRules) > 0 {
+		for _, id := range strings.Split(result.analyzer.SkipRiskRules, ",") {
+			skippedRules[id] = true
+		}
+	}
+
+	for _, rule := range risks.AllRiskRules(result.analyzer.CustomRiskRules) {
+		if _, ok := skippedRules[rule.ID]; ok {
+			if rule.BuiltIn {
+				fmt.Println("Skipping risk rule:", rule.ID)
+			} else if result.analyzer.Verbose {
+				fmt.Println("Skipping custom risk rule:", rule.ID)
+			}
+			delete(skippedRules, rule.ID)
+			continue
+		}
+		if !rule.IsEnabled(result.analyzer.EnableRiskRules) {
+			if result.analyzer.Verbose {
+				fmt.Println("Not executing risk rule disabled by default:", rule.ID)
+			}
+			continue
+		}
+		if !rule.BuiltIn && result.analyzer.Verbose {
+			fmt.Println("Executing custom risk rule:", rule.ID)
+		}
+		model.AddToListOfSupportedTags(rule.SupportedTags())
+		model.ParsedModelRoot.ComplianceControls[rule.ID] = model.MergeComplianceControls(rule.ComplianceControls(), model.ParsedModelRoot.ComplianceMappings[rule.ID])
+		generatedRisks := rule.GenerateRisks()
+		if len(generatedRisks) > 0 {
+			model.GeneratedRisksByCategory[rule.Category()] = generatedRisks
+		}
+		if !rule.BuiltIn && result.analyzer.Verbose {
+			fmt.Println("Added custom risks:", len(generatedRisks))
+		}
+	}
+
+	if len(skippedRules) > 0 {
+		keys := make([]string, 0)
+		for k := range skippedRules {
+			keys = append(keys, k)
+		}
+		if len(keys) > 0 {
+			log.Println("Unknown risk rules to skip:", keys)
+		}
+	}
+
+	for categoryId := range model.ParsedModelRoot.ComplianceMappings {
+		if _, checked := model.ParsedModelRoot.ComplianceControls[categoryId]; !checked {
+			result.addModelWarning(model.DiagnosticMissingReference, "compliance mapping of unknown (or skipped) risk category: "+categoryId, "compliance_mappings", categoryId)
+		}
+	}
+
+	// save also in map keyed by synthetic risk-id
+	for _, category := range model.SortedRiskCategories() {
+		risks := model.SortedRisksOfCategory(category)
+		for _, risk := range risks {
+			model.GeneratedRisksBySyntheticId[strings.ToLower(risk.SyntheticId)] = risk
+		}
+	}
+}
+
+func (result *AnalysisResult) applyWildcardRiskTrackingEvaluation() {
+	if result.analyzer.Verbose {
+		fmt.Println("Executing risk tracking evaluation")
+	}
+	for syntheticRiskIdPattern, riskTracking := range result.deferredRiskTrackingDueToWildcardMatching {
+		foundSome := false
+		var matchingRiskIdExpression = regexp.MustCompile(strings.ReplaceAll(regexp.QuoteMeta(syntheticRiskIdPattern), `\*`, `[^@]+`))
+		for syntheticRiskId, _ := range model.GeneratedRisksBySyntheticId {
+			if matchingRiskIdExpression.Match([]byte(syntheticRiskId)) && hasNotYetAnyDirectNonWildcardRiskTrackings(syntheticRiskId) {
+				foundSome = true
+				model.ParsedModelRoot.RiskTracking[syntheticRiskId] = model.RiskTracking{
+					SyntheticRiskId: strings.TrimSpace(syntheticRiskId),
+					Justification:   riskTracking.Justification,
+					CheckedBy:       riskTracking.CheckedBy,
+					Ticket:          riskTracking.Ticket,
+					Status:          riskTracking.Status,
+					Date:            riskTracking.Date,
+				}
+			}
+		}
+		if !foundSome {
+			result.addOrphanedRiskTrackingDiagnostic("wildcard risk tracking does not match any risk id: "+syntheticRiskIdPattern, syntheticRiskIdPattern)
+		}
+	}
+}
+
+func hasNotYetAnyDirectNonWildcardRiskTrackings(syntheticRiskId string) bool {
+	if _, ok := model.ParsedModelRoot.RiskTracking[syntheticRiskId]; ok {
+		return false
+	}
+	return true
+}
+
+func (result *AnalysisResult) checkRiskTracking() {
+	if result.analyzer.Verbose {
+		fmt.Println("Checking risk tracking")
+	}
+	for _, tracking := range model.ParsedModelRoot.RiskTracking {
+		if _, ok := model.GeneratedRisksBySyntheticId[tracking.SyntheticRiskId]; !ok {
+			result.addOrphanedRiskTrackingDiagnostic("risk tracking references unknown risk (risk id not found): "+tracking.SyntheticRiskId, tracking.SyntheticRiskId)
+		}
+	}
+	result.abortOnModelErrors()
+
+	// save also the risk-category-id and risk-status directly in the risk for better JSON marshalling
+	for category, _ := range model.GeneratedRisksByCategory {
+		for i, _ := range model.GeneratedRisksByCategory[category] {
+			model.GeneratedRisksByCategory[category][i].CategoryId = category.Id
+			model.GeneratedRisksByCategory[category][i].RiskStatus = model.GeneratedRisksByCategory[category][i].GetRiskTrackingStatusDefaultingUnchecked()
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// ApplySASTFindings reads the SARIF files (comma-separated list of files or folders containing *.sarif files, each
+// optionally prefixed with "<repository>=" for runs without version control provenance) and attaches each finding to
+// the risks it confirms: risks of a category with the CWE reported by the finding (or a related one) whose most relevant
+// technical asset references the finding's repository via "sourcecode_repositories". Confirmed risks get their
+// exploitation likelihood raised when Analyzer.SASTRaiseLikelihood is set.
+func (result *AnalysisResult) ApplySASTFindings(sarifFiles string) (err error) {
+	defer recoverError(&err)
+	findings := make([]model.SASTFinding, 0)
+	for _, entry := range strings.Split(sarifFiles, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		repository := ""
+		if i := strings.Index(entry, "="); i > 0 {
+			repository, entry = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		findings = append(findings, result.readSARIFFiles(entry, repository)...)
+	}
+	result.WithModelState(func() {
+		model.ParsedModelRoot.SASTFindings = findings
+		result.correlateSASTFindings()
+	})
+	return nil
+}
+
+func (result *AnalysisResult) readSARIFFiles(filename, repository string) []model.SASTFinding {
+	/* #nosec filename is not tainted (read from command-line params) */
+	info, err := os.Stat(filename)
+	checkErr(err)
+	filenames := []string{filename}
+	if info.IsDir() {
+		filenames, err = filepath.Glob(filepath.Join(filename, "*.sarif"))
+		checkErr(err)
+		sort.Strings(filenames)
+	}
+	findings := make([]model.SASTFinding, 0)
+	for _, sarifFilename := range filenames {
+		if result.analyzer.Verbose {
+			fmt.Println("Reading SARIF file:", sarifFilename)
+		}
+		content, err := ioutil.ReadFile(sarifFilename)
+		checkErr(err)
+		defaultRepository := repository
+		if len(defaultRepository) == 0 { // like "webshop" for "webshop.sarif"
+			defaultRepository = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(sarifFilename), ".json"), ".sarif")
+		}
+		sarifFindings, err := model.ParseSARIF(content, defaultRepository)
+		if err != nil {
+			panic(errors.New("unable to read SARIF file " + sarifFilename + ": " + err.Error()))
+		}
+		findings = append(findings, sarifFindings...)
+	}
+	return findings
+}
+
+func (result *AnalysisResult) correlateSASTFindings() {
+	unmappedRepositories := make(map[string]bool)
+	for _, finding := range model.ParsedModelRoot.SASTFindings {
+		unmappedRepositories[finding.Repository] = true
+	}
+	for _, technicalAsset := range model.ParsedModelRoot.TechnicalAssets {
+		for repository := range unmappedRepositories {
+			if repositoryMatchesAny(repository, technicalAsset.SourcecodeRepositories) {
+				delete(unmappedRepositories, repository)
+			}
+		}
+	}
+	if len(unmappedRepositories) > 0 {
+		repositories := make([]string, 0, len(unmappedRepositories))
+		for repository := range unmappedRepositories {
+			repositories = append(repositories, repository)
+		}
+		sort.Strings(repositories)
+		log.Println("SARIF findings of repositories not referenced by any technical asset (via sourcecode_repositories):", repositories)
+	}
+
+	confirmedRisks := 0
+	for category, risks := range model.GeneratedRisksByCategory {
+		for i, risk := range risks {
+			// the most relevant technical asset is the one whose code contains the weakness (like the caller of a
+			// database for SQL injection risks)
+			repositories := model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId].SourcecodeRepositories
+			for _, finding := range model.ParsedModelRoot.SASTFindings {
+				if finding.MatchesCWE(category.CWE) && repositoryMatchesAny(finding.Repository, repositories) {
+					risk.SASTFindings = append(risk.SASTFindings, finding)
+				}
+			}
+			if !risk.IsConfirmedBySAST() {
+				continue
+			}
+			confirmedRisks++
+			if result.analyzer.SASTRaiseLikelihood && risk.ExploitationLikelihood < model.Frequent {
+				risk.ExploitationLikelihood++
+				risk.Severity = model.CalculateSeverity(risk.ExploitationLikelihood, risk.ExploitationImpact)
+			}
+			risks[i] = risk
+			model.GeneratedRisksBySyntheticId[strings.ToLower(risk.SyntheticId)] = risk
+		}
+	}
+	if result.analyzer.Verbose {
+		fmt.Println("Risks confirmed by SAST findings:", confirmedRisks)
+	}
+}
+
+func repositoryMatchesAny(repository string, repositories []string) bool {
+	for _, other := range repositories {
+		if model.RepositoryMatches(repository, other) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// reads the SBOM or vulnerability scan files referenced by a technical asset, which are resolved relative to the model
+// file defining the technical asset and must (like included model files) reside within the folder of the model file
+func (result *AnalysisResult) readVulnerabilityScans(title string, scans []string) []model.KnownVulnerability {
+	knownVulnerabilities := make([]model.KnownVulnerability, 0)
+	if len(scans) == 0 {
+		return knownVulnerabilities
+	}
+	modelFolder, err := filepath.Abs(filepath.Dir(result.ModelFilenames[0]))
+	checkErr(err)
+	definingFilename, _ := result.locateModelElement([]string{"technical_assets", title, "vulnerability_scans"})
+	for i, scan := range scans {
+		scanPath := []string{"technical_assets", title, "vulnerability_scans", strconv.Itoa(i)}
+		scan = strings.TrimSpace(scan)
+		if len(scan) == 0 {
+			continue
+		}
+		filename := filepath.Join(filepath.Dir(definingFilename), scan)
+		absoluteFilename, err := filepath.Abs(filename)
+		checkErr(err)
+		// in order to prevent Path-Traversal like stuff (especially for models uploaded to the server)...
+		if !strings.HasPrefix(absoluteFilename, modelFolder+string(os.PathSeparator)) {
+			result.addModelError(model.DiagnosticInvalidVulnerabilityScan, "vulnerability scan file of technical asset '"+title+"' must reside within the folder of the model file: "+scan, scanPath...)
+			continue
+		}
+		if result.analyzer.Verbose {
+			fmt.Println("Reading vulnerability scan:", filename)
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			result.addModelError(model.DiagnosticUnreadableFile, "unable to read vulnerability scan file of technical asset '"+title+"': "+err.Error(), scanPath...)
+			continue
+		}
+		vulnerabilities, err := model.ParseVulnerabilityScan(content, scan)
+		if err != nil {
+			result.addModelError(model.DiagnosticInvalidVulnerabilityScan, "unable to parse vulnerability scan file "+scan+" of technical asset '"+title+"': "+err.Error(), scanPath...)
+			continue
+		}
+		knownVulnerabilities = append(knownVulnerabilities, vulnerabilities...)
+	}
+	return model.UniqueKnownVulnerabilities(knownVulnerabilities)
+}
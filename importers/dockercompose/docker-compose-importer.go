@@ -0,0 +1,418 @@
+// Package dockercompose imports the services (as technical assets), their dependencies (as communication links), and
+// their networks (as trust boundaries) from docker-compose files into a starting model
+package dockercompose
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultNetwork = "default"
+
+// the file names looked up (in this order) when a directory is given
+var composeFilenames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// env values looking like a URL or host (with optional port), whose host might be the name of a service
+var serviceReferenceRegEx = regexp.MustCompile(`^(?:[a-z][a-z0-9+.-]*://)?(?:[^@/]+@)?([a-z0-9_.-]+)(?::(\d+))?(?:/.*)?$`)
+
+type composeFile struct {
+	Name     string             `yaml:"name"`
+	Services map[string]service `yaml:"services"`
+}
+
+// the fields with a short and a long syntax are kept as node to be read via the functions below
+type service struct {
+	Image       string    `yaml:"image"`
+	Build       yaml.Node `yaml:"build"`
+	DependsOn   yaml.Node `yaml:"depends_on"`
+	Networks    yaml.Node `yaml:"networks"`
+	NetworkMode string    `yaml:"network_mode"`
+	Ports       yaml.Node `yaml:"ports"`
+	Expose      []string  `yaml:"expose"`
+	Environment yaml.Node `yaml:"environment"`
+	Secrets     yaml.Node `yaml:"secrets"`
+	Labels      yaml.Node `yaml:"labels"`
+	Deploy      struct {
+		Replicas *int `yaml:"replicas"`
+	} `yaml:"deploy"`
+}
+
+type publishedPort struct {
+	hostIP                   string
+	published, containerPort int // the published port being 0 when ephemeral
+}
+
+// Import reads the docker-compose file given (or the compose file of the directory given)
+func Import(path string) (model.ModelInput, error) {
+	filename := path
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		filename = ""
+		for _, candidate := range composeFilenames {
+			if _, err := os.Stat(filepath.Join(path, candidate)); err == nil {
+				filename = filepath.Join(path, candidate)
+				break
+			}
+		}
+		if len(filename) == 0 {
+			return model.ModelInput{}, errors.New("no docker-compose file found in: " + path)
+		}
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return model.ModelInput{}, err
+	}
+	var compose composeFile
+	if err = yaml.Unmarshal(content, &compose); err != nil {
+		return model.ModelInput{}, fmt.Errorf("unable to parse docker-compose file %s: %v", filename, err)
+	}
+	if len(compose.Services) == 0 {
+		return model.ModelInput{}, errors.New("no services found in docker-compose file: " + filename)
+	}
+	if len(compose.Name) == 0 {
+		compose.Name = filepath.Base(filepath.Dir(filename))
+	}
+	return compose.toModelInput()
+}
+
+func (what *composeFile) toModelInput() (model.ModelInput, error) {
+	modelInput := importers.NewModelInput("Docker Compose Import of "+what.Name,
+		"Imported from the docker-compose file of "+what.Name+": Services as technical assets (running on the same Docker "+
+			"host), their dependencies (and service references in environment variables) as communication links, and their "+
+			"networks as trust boundaries.")
+	names := make([]string, 0)
+	for name := range what.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// services (the service names being unique already)
+	networkMembers := make(map[string][]string)
+	for _, name := range names {
+		technicalAsset, err := what.toTechnicalAsset(&modelInput, name)
+		if err != nil {
+			return model.ModelInput{}, fmt.Errorf("unable to import service %s: %v", name, err)
+		}
+		modelInput.Technical_assets[name] = technicalAsset
+		networks, err := what.networksOf(name)
+		if err != nil {
+			return model.ModelInput{}, fmt.Errorf("unable to read the networks of service %s: %v", name, err)
+		}
+		if len(networks) > 0 {
+			// as a technical asset can only be inside one trust boundary, the others are just mentioned
+			networkMembers[networks[0]] = append(networkMembers[networks[0]], technicalAsset.ID)
+			if len(networks) > 1 {
+				technicalAsset.Description += " (also attached to the network(s): " + strings.Join(networks[1:], ", ") + ")"
+				modelInput.Technical_assets[name] = technicalAsset
+			}
+		}
+	}
+
+	// networks as trust boundaries
+	for network, members := range networkMembers {
+		trustBoundary := model.InputTrustBoundary{
+			ID:                      model.MakeID("network-" + network),
+			Description:             "Docker network " + network,
+			Type:                    model.NetworkVirtualLAN.String(),
+			Tags:                    make([]string, 0),
+			Technical_assets_inside: members,
+			Trust_boundaries_nested: make([]string, 0),
+		}
+		importers.AddTags(&modelInput, &trustBoundary.Tags, "docker-compose")
+		modelInput.Trust_boundaries["Network "+network] = trustBoundary
+	}
+
+	// the Docker host as shared runtime of all services
+	sharedRuntime := model.InputSharedRuntime{
+		ID:                       "docker-host",
+		Description:              "Docker host running the services of " + what.Name,
+		Tags:                     make([]string, 0),
+		Technical_assets_running: make([]string, 0),
+	}
+	importers.AddTags(&modelInput, &sharedRuntime.Tags, "docker-compose")
+	for _, name := range names {
+		sharedRuntime.Technical_assets_running = append(sharedRuntime.Technical_assets_running, modelInput.Technical_assets[name].ID)
+	}
+	modelInput.Shared_runtimes["Docker Host"] = sharedRuntime
+
+	for _, name := range names {
+		if err := what.addLinks(&modelInput, name); err != nil {
+			return model.ModelInput{}, fmt.Errorf("unable to read the dependencies of service %s: %v", name, err)
+		}
+	}
+	return modelInput, nil
+}
+
+func (what *composeFile) toTechnicalAsset(modelInput *model.ModelInput, name string) (model.InputTechnicalAsset, error) {
+	service := what.Services[name]
+	description := "Docker Compose service " + name
+	if len(service.Image) > 0 {
+		description += " running the image " + service.Image
+	} else if !service.Build.IsZero() {
+		description += " built from source"
+	}
+	technicalAsset := importers.NewTechnicalAsset(model.MakeID(name), description)
+	if len(service.Image) > 0 {
+		technology, assetType := importers.TechnologyOfImage(service.Image)
+		technicalAsset.Technology, technicalAsset.Type = technology.String(), assetType.String()
+		// as the others are off-the-shelf products (unless built from source)
+		technicalAsset.Custom_developed_parts = technology == model.WebServiceREST || !service.Build.IsZero()
+	}
+	technicalAsset.Redundant = service.Deploy.Replicas != nil && *service.Deploy.Replicas > 1
+	labels, err := keyValuesOf(&service.Labels)
+	if err != nil {
+		return technicalAsset, err
+	}
+	for _, label := range []string{"owner", "team"} {
+		if owner, ok := labels[label]; ok && len(technicalAsset.Owner) == 0 {
+			technicalAsset.Owner = owner
+		}
+	}
+	importers.AddTags(modelInput, &technicalAsset.Tags, "docker-compose")
+
+	// published ports (unless bound to the loopback interface only) are considered to be reachable from the internet
+	ports, err := portsOf(&service.Ports)
+	if err != nil {
+		return technicalAsset, err
+	}
+	for _, port := range ports {
+		if port.hostIP != "127.0.0.1" && port.hostIP != "::1" && port.hostIP != "localhost" && !technicalAsset.Internet {
+			if port.published > 0 {
+				technicalAsset.Description += " (published on port " + strconv.Itoa(port.published) + ")"
+			} else { // on an ephemeral port of the host
+				technicalAsset.Description += " (published port " + strconv.Itoa(port.containerPort) + ")"
+			}
+			technicalAsset.Internet = true
+		}
+	}
+
+	// the secrets used become data assets processed by the service
+	secrets, err := sourcesOf(&service.Secrets)
+	if err != nil {
+		return technicalAsset, err
+	}
+	for _, secret := range secrets {
+		id := model.MakeID("secret-" + secret)
+		title := "Secret " + secret
+		if _, exists := modelInput.Data_assets[title]; !exists {
+			dataAsset := importers.NewDataAsset(id, "Docker secret "+secret, model.DevOps)
+			importers.AddTags(modelInput, &dataAsset.Tags, "docker-compose", "secret")
+			modelInput.Data_assets[title] = dataAsset
+		}
+		technicalAsset.Data_assets_processed = append(technicalAsset.Data_assets_processed, id)
+	}
+	return technicalAsset, nil
+}
+
+// networksOf returns the (sorted) networks the service is attached to, which is the default network unless declared
+func (what *composeFile) networksOf(name string) ([]string, error) {
+	service := what.Services[name]
+	if len(service.NetworkMode) > 0 {
+		return []string{}, nil // like host network or the one of another service, so no network of its own
+	}
+	if service.Networks.IsZero() {
+		return []string{defaultNetwork}, nil
+	}
+	networks := make([]string, 0)
+	switch service.Networks.Kind {
+	case yaml.SequenceNode:
+		if err := service.Networks.Decode(&networks); err != nil {
+			return nil, err
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(service.Networks.Content); i += 2 {
+			networks = append(networks, service.Networks.Content[i].Value)
+		}
+	}
+	sort.Strings(networks)
+	return networks, nil
+}
+
+// addLinks links the service to the services it references in environment variables (by their service name like
+// "http://orders:8080") and to the services it depends on
+func (what *composeFile) addLinks(modelInput *model.ModelInput, name string) error {
+	service := what.Services[name]
+	// the references first, as their URLs tell the protocol better than the ports of the dependencies
+	environment, err := keyValuesOf(&service.Environment)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0)
+	for key := range environment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strings.ToLower(strings.TrimSpace(environment[key]))
+		match := serviceReferenceRegEx.FindStringSubmatch(value)
+		if match == nil {
+			continue
+		}
+		if _, exists := what.Services[match[1]]; !exists {
+			continue
+		}
+		protocol := model.UnknownProtocol
+		if strings.Contains(value, "://") {
+			protocol = importers.ProtocolOfURL(value)
+		} else if port, err := strconv.Atoi(match[2]); err == nil {
+			protocol = importers.ProtocolOfPort(port)
+		}
+		if protocol == model.UnknownProtocol {
+			protocol = what.protocolOf(match[1])
+		}
+		what.addLink(modelInput, name, match[1], "Referenced in "+key, protocol)
+	}
+	dependencies, err := namesOf(&service.DependsOn)
+	if err != nil {
+		return err
+	}
+	for _, dependency := range dependencies {
+		if _, exists := what.Services[dependency]; exists {
+			what.addLink(modelInput, name, dependency, "Depends on "+dependency, what.protocolOf(dependency))
+		}
+	}
+	return nil
+}
+
+// protocolOf guesses the protocol of links to the service by the first well-known port it exposes
+func (what *composeFile) protocolOf(name string) model.Protocol {
+	service := what.Services[name]
+	containerPorts := make([]int, 0)
+	if ports, err := portsOf(&service.Ports); err == nil {
+		for _, port := range ports {
+			containerPorts = append(containerPorts, port.containerPort)
+		}
+	}
+	for _, expose := range service.Expose {
+		if port, err := strconv.Atoi(strings.Split(strings.Split(expose, "/")[0], "-")[0]); err == nil {
+			containerPorts = append(containerPorts, port)
+		}
+	}
+	for _, port := range containerPorts {
+		if protocol := importers.ProtocolOfPort(port); protocol != model.UnknownProtocol {
+			return protocol
+		}
+	}
+	return model.UnknownProtocol
+}
+
+// addLink adds a communication link unless the source is already linked to the target
+func (what *composeFile) addLink(modelInput *model.ModelInput, source string, target string, description string, protocol model.Protocol) {
+	if source == target {
+		return
+	}
+	technicalAsset := modelInput.Technical_assets[source]
+	targetId := modelInput.Technical_assets[target].ID
+	for _, link := range technicalAsset.Communication_links {
+		if link.Target == targetId {
+			return
+		}
+	}
+	technicalAsset.Communication_links[target+" Traffic"] = importers.NewCommunicationLink(targetId, description, protocol)
+	modelInput.Technical_assets[source] = technicalAsset
+}
+
+// portsOf reads the ports in short syntax (like "127.0.0.1:8080:80/tcp") and long syntax (with target and published)
+func portsOf(node *yaml.Node) ([]publishedPort, error) {
+	result := make([]publishedPort, 0)
+	for _, item := range node.Content {
+		var port publishedPort
+		if item.Kind == yaml.MappingNode {
+			var long struct {
+				Target    int    `yaml:"target"`
+				Published string `yaml:"published"`
+				HostIP    string `yaml:"host_ip"`
+			}
+			if err := item.Decode(&long); err != nil {
+				return nil, err
+			}
+			port.containerPort, port.published, port.hostIP = long.Target, firstPort(long.Published), long.HostIP
+		} else {
+			parts := strings.Split(strings.Split(item.Value, "/")[0], ":")
+			port.containerPort = firstPort(parts[len(parts)-1])
+			if len(parts) >= 2 {
+				port.published = firstPort(parts[len(parts)-2])
+				port.hostIP = strings.Trim(strings.Join(parts[:len(parts)-2], ":"), "[]")
+			}
+		}
+		result = append(result, port)
+	}
+	return result, nil
+}
+
+// firstPort returns the first port of a port or port range (like "8080-8081"), or else 0
+func firstPort(ports string) int {
+	port, err := strconv.Atoi(strings.Split(ports, "-")[0])
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
+// namesOf reads a list of names, also given as the keys of a mapping (like depends_on with conditions)
+func namesOf(node *yaml.Node) ([]string, error) {
+	result := make([]string, 0)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		if err := node.Decode(&result); err != nil {
+			return nil, err
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			result = append(result, node.Content[i].Value)
+		}
+	}
+	return result, nil
+}
+
+// sourcesOf reads the secrets in short syntax (just the name) and long syntax (with source)
+func sourcesOf(node *yaml.Node) ([]string, error) {
+	result := make([]string, 0)
+	for _, item := range node.Content {
+		if item.Kind == yaml.MappingNode {
+			var long struct {
+				Source string `yaml:"source"`
+			}
+			if err := item.Decode(&long); err != nil {
+				return nil, err
+			}
+			result = append(result, long.Source)
+		} else {
+			result = append(result, item.Value)
+		}
+	}
+	return result, nil
+}
+
+// keyValuesOf reads a mapping, also given as list of "KEY=value" entries (like environment and labels)
+func keyValuesOf(node *yaml.Node) (map[string]string, error) {
+	result := make(map[string]string)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		entries := make([]string, 0)
+		if err := node.Decode(&entries); err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			keyValue := strings.SplitN(entry, "=", 2)
+			if len(keyValue) == 2 {
+				result[keyValue[0]] = keyValue[1]
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result[node.Content[i].Value] = node.Content[i+1].Value
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,255 @@
+// Package importers contains what the importers (generating a starting model from other sources) have in common
+package importers
+
+import (
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+// the justification of all ratings an importer can only guess, to be reviewed when enriching the model
+const JustificationToBeReviewed = "Imported, to be reviewed"
+
+// NewModelInput returns an empty model (to be filled by an importer) passing the model validation
+func NewModelInput(title string, description string) model.ModelInput {
+	return model.ModelInput{
+		Threagile_version:     model.ThreagileVersion,
+		Title:                 title,
+		Date:                  time.Now().Format("2006-01-02"),
+		Business_criticality:  model.Important.String(),
+		Business_overview:     model.Overview{Description: description},
+		Technical_overview:    model.Overview{Description: description},
+		Questions:             make(map[string]string),
+		Abuse_cases:           make(map[string]string),
+		Security_requirements: make(map[string]string),
+		Tags_available:        make([]string, 0),
+		Data_assets:           make(map[string]model.InputDataAsset),
+		Technical_assets:      make(map[string]model.InputTechnicalAsset),
+		Trust_boundaries:      make(map[string]model.InputTrustBoundary),
+		Shared_runtimes:       make(map[string]model.InputSharedRuntime),
+	}
+}
+
+// NewTechnicalAsset returns a custom-developed containerized service with medium ratings, to be adjusted by the importer
+func NewTechnicalAsset(id string, description string) model.InputTechnicalAsset {
+	return model.InputTechnicalAsset{
+		ID:                       id,
+		Description:              description,
+		Type:                     model.Process.String(),
+		Usage:                    model.Business.String(),
+		Size:                     model.Service.String(),
+		Technology:               model.WebServiceREST.String(),
+		Tags:                     make([]string, 0),
+		Machine:                  model.Container.String(),
+		Encryption:               model.NoneEncryption.String(),
+		Confidentiality:          model.Internal.String(),
+		Integrity:                model.Important.String(),
+		Availability:             model.Important.String(),
+		Justification_cia_rating: JustificationToBeReviewed,
+		Custom_developed_parts:   true,
+		Data_assets_processed:    make([]string, 0),
+		Data_assets_stored:       make([]string, 0),
+		Data_formats_accepted:    make([]string, 0),
+		Communication_links:      make(map[string]model.InputCommunicationLink),
+	}
+}
+
+// NewCommunicationLink returns an unauthenticated business link, to be adjusted by the importer
+func NewCommunicationLink(targetId string, description string, protocol model.Protocol) model.InputCommunicationLink {
+	return model.InputCommunicationLink{
+		Target:               targetId,
+		Description:          description,
+		Protocol:             protocol.String(),
+		Authentication:       model.NoneAuthentication.String(),
+		Authorization:        model.NoneAuthorization.String(),
+		Tags:                 make([]string, 0),
+		Usage:                model.Business.String(),
+		Data_assets_sent:     make([]string, 0),
+		Data_assets_received: make([]string, 0),
+	}
+}
+
+// NewDataAsset returns a confidential data asset of the given usage, to be adjusted by the importer
+func NewDataAsset(id string, description string, usage model.Usage) model.InputDataAsset {
+	return model.InputDataAsset{
+		ID:                       id,
+		Description:              description,
+		Usage:                    usage.String(),
+		Tags:                     make([]string, 0),
+		Quantity:                 model.Few.String(),
+		Confidentiality:          model.Confidential.String(),
+		Integrity:                model.Important.String(),
+		Availability:             model.Important.String(),
+		Justification_cia_rating: JustificationToBeReviewed,
+	}
+}
+
+// UniqueTitle returns the title, suffixed by a counter when already used by one of the titles given
+func UniqueTitle(title string, usedTitles map[string]bool) string {
+	result := title
+	for i := 2; usedTitles[result]; i++ {
+		result = title + " " + strconv.Itoa(i)
+	}
+	return result
+}
+
+// AddTags adds the tags to the asset's tags and to the tags available in the model (both sorted without duplicates)
+func AddTags(modelInput *model.ModelInput, tags *[]string, tagsToAdd ...string) {
+	for _, tag := range tagsToAdd {
+		tag = model.NormalizeTag(tag)
+		if !model.Contains(*tags, tag) {
+			*tags = append(*tags, tag)
+		}
+		if !model.Contains(modelInput.Tags_available, tag) {
+			modelInput.Tags_available = append(modelInput.Tags_available, tag)
+		}
+	}
+	sort.Strings(*tags)
+	sort.Strings(modelInput.Tags_available)
+}
+
+// TechnologyOfImage guesses the technology (and type) of a technical asset by the name of its container image, matching
+// whole name tokens (split at "-", "_" and "/") so that like "codex" is no "dex". Monitoring images come first, as
+// exporters are named after what they monitor (like "prometheus/mysqld-exporter" or "oliver006/redis_exporter").
+func TechnologyOfImage(image string) (model.TechnicalAssetTechnology, model.TechnicalAssetType) {
+	name := strings.ToLower(image)
+	if at := strings.Index(name, "@"); at >= 0 { // digest
+		name = name[:at]
+	}
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") { // tag
+		name = name[:colon]
+	}
+	if slash := strings.Index(name, "/"); slash >= 0 && strings.ContainsAny(name[:slash], ".:") { // registry
+		name = name[slash+1:]
+	}
+	tokens := "-" + strings.NewReplacer("_", "-", "/", "-").Replace(name) + "-"
+	switch {
+	case hasAnyToken(tokens, "prometheus", "grafana", "jaeger", "zipkin", "kibana", "loki", "fluentd", "fluent-bit", "datadog", "exporter"):
+		return model.Monitoring, model.Process
+	case hasAnyToken(tokens, "jenkins", "gitlab-runner"):
+		return model.BuildPipeline, model.Process
+	case hasAnyToken(tokens, "postgres", "postgresql", "postgis", "mysql", "mariadb", "mongo", "mongodb", "redis", "cassandra", "couchdb", "mssql", "oracle", "memcached", "neo4j", "cockroach", "cockroachdb"):
+		return model.Database, model.Datastore
+	case hasAnyToken(tokens, "elasticsearch", "opensearch", "solr"):
+		return model.SearchIndex, model.Datastore
+	case hasAnyToken(tokens, "kafka", "rabbitmq", "activemq", "nats", "mosquitto", "pulsar"):
+		return model.MessageQueue, model.Process
+	case hasAnyToken(tokens, "minio", "nfs", "ftp", "vsftpd"):
+		return model.FileServer, model.Datastore
+	case hasAnyToken(tokens, "vault"):
+		return model.Vault, model.Datastore
+	case hasAnyToken(tokens, "keycloak", "dex", "oauth2-proxy"):
+		return model.IdentityProvider, model.Process
+	case hasAnyToken(tokens, "ldap", "openldap"):
+		return model.LDAPServer, model.Datastore
+	case hasAnyToken(tokens, "nginx", "traefik", "envoy", "haproxy", "caddy"):
+		return model.ReverseProxy, model.Process
+	case hasAnyToken(tokens, "httpd", "apache"):
+		return model.WebServer, model.Process
+	}
+	return model.WebServiceREST, model.Process
+}
+
+// hasAnyToken tells whether the tokens (joined by "-" and enclosed in "-") contain one of the names (as whole tokens)
+func hasAnyToken(tokens string, names ...string) bool {
+	for _, name := range names {
+		if strings.Contains(tokens, "-"+name+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtocolOfURL guesses the protocol of a link by the scheme (or else the port) of the URL (like "https://orders:8443")
+func ProtocolOfURL(rawURL string) model.Protocol {
+	parsed, err := url.Parse(rawURL)
+	if err == nil {
+		switch strings.ToLower(parsed.Scheme) {
+		case "http":
+			return model.HTTP
+		case "https":
+			return model.HTTPS
+		case "ws":
+			return model.WS
+		case "wss":
+			return model.WSS
+		case "jdbc":
+			return model.JDBC
+		case "mongodb", "redis", "mongodb+srv":
+			return model.NoSQL_access_protocol
+		case "postgres", "postgresql", "mysql":
+			return model.SQL_access_protocol
+		case "amqp", "nats", "kafka":
+			return model.BINARY
+		case "rediss":
+			return model.NoSQL_access_protocol_encrypted
+		case "amqps":
+			return model.BINARY_encrypted
+		case "mqtt":
+			return model.MQTT
+		case "ldap":
+			return model.LDAP
+		case "ldaps":
+			return model.LDAPS
+		}
+		if port, err := strconv.Atoi(parsed.Port()); err == nil {
+			return ProtocolOfPort(port)
+		}
+	}
+	return model.UnknownProtocol
+}
+
+// ProtocolOfPort guesses the protocol of a link by its well-known target port
+func ProtocolOfPort(port int) model.Protocol {
+	switch port {
+	case 443, 8443, 9443:
+		return model.HTTPS
+	case 80, 3000, 5000, 8000, 8080, 8081, 9000, 9090, 9200:
+		return model.HTTP
+	case 1433, 1521, 3306, 5432, 26257:
+		return model.SQL_access_protocol
+	case 6379, 9042, 11211, 27017:
+		return model.NoSQL_access_protocol
+	case 5671:
+		return model.BINARY_encrypted
+	case 4222, 5672, 9092:
+		return model.BINARY
+	case 1883, 8883:
+		return model.MQTT
+	case 389:
+		return model.LDAP
+	case 636:
+		return model.LDAPS
+	case 22:
+		return model.SSH
+	case 25:
+		return model.SMTP
+	case 465, 587:
+		return model.SMTP_encrypted
+	}
+	return model.UnknownProtocol
+}
+
+// WriteModelYAML writes the imported model using the same YAML marshalling as the model macros
+func WriteModelYAML(filename string, modelInput model.ModelInput) error {
+	yamlBytes, err := yaml.Marshal(modelInput)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, yamlBytes, 0644)
+}
+
+func containsAny(text string, parts ...string) bool {
+	for _, part := range parts {
+		if strings.Contains(text, part) {
+			return true
+		}
+	}
+	return false
+}
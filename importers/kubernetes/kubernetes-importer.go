@@ -0,0 +1,622 @@
+// Package kubernetes imports the workloads (as technical assets) and their traffic (as communication links) from
+// Kubernetes manifests into a starting model
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultNamespace = "default"
+
+// env values looking like a URL or host (with optional port), whose host might be the DNS name of a service
+var serviceReferenceRegEx = regexp.MustCompile(`^(?:[a-z][a-z0-9+.-]*://)?(?:[^@/]+@)?([a-z0-9.-]+)(?::\d+)?(?:/.*)?$`)
+
+type metadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+type labelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type container struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+	Env   []struct {
+		Name      string `yaml:"name"`
+		Value     string `yaml:"value"`
+		ValueFrom struct {
+			SecretKeyRef struct {
+				Name string `yaml:"name"`
+			} `yaml:"secretKeyRef"`
+		} `yaml:"valueFrom"`
+	} `yaml:"env"`
+	EnvFrom []struct {
+		SecretRef struct {
+			Name string `yaml:"name"`
+		} `yaml:"secretRef"`
+	} `yaml:"envFrom"`
+}
+
+type podSpec struct {
+	Containers     []container `yaml:"containers"`
+	InitContainers []container `yaml:"initContainers"`
+	Volumes        []struct {
+		Secret struct {
+			SecretName string `yaml:"secretName"`
+		} `yaml:"secret"`
+	} `yaml:"volumes"`
+}
+
+type podTemplate struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     podSpec  `yaml:"spec"`
+}
+
+// covers Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs, and CronJobs (having their pod template one level deeper)
+type workloadManifest struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     struct {
+		Replicas    *int        `yaml:"replicas"`
+		Template    podTemplate `yaml:"template"`
+		JobTemplate struct {
+			Spec struct {
+				Template podTemplate `yaml:"template"`
+			} `yaml:"spec"`
+		} `yaml:"jobTemplate"`
+	} `yaml:"spec"`
+}
+
+type podManifest struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     podSpec  `yaml:"spec"`
+}
+
+type serviceManifest struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     struct {
+		Type     string            `yaml:"type"`
+		Selector map[string]string `yaml:"selector"`
+		Ports    []struct {
+			Name string `yaml:"name"`
+			Port int    `yaml:"port"`
+		} `yaml:"ports"`
+	} `yaml:"spec"`
+}
+
+type ingressBackend struct {
+	Service struct {
+		Name string `yaml:"name"`
+		Port struct {
+			Number int    `yaml:"number"`
+			Name   string `yaml:"name"`
+		} `yaml:"port"`
+	} `yaml:"service"`
+	ServiceName string `yaml:"serviceName"` // before networking.k8s.io/v1
+}
+
+type ingressManifest struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     struct {
+		TLS []struct {
+			Hosts []string `yaml:"hosts"`
+		} `yaml:"tls"`
+		DefaultBackend ingressBackend `yaml:"defaultBackend"`
+		Backend        ingressBackend `yaml:"backend"` // before networking.k8s.io/v1
+		Rules          []struct {
+			Host string `yaml:"host"`
+			HTTP struct {
+				Paths []struct {
+					Backend ingressBackend `yaml:"backend"`
+				} `yaml:"paths"`
+			} `yaml:"http"`
+		} `yaml:"rules"`
+	} `yaml:"spec"`
+}
+
+type networkPolicyManifest struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     struct {
+		PodSelector labelSelector `yaml:"podSelector"`
+		Ingress     []struct {
+			From []struct {
+				PodSelector       *labelSelector `yaml:"podSelector"`
+				NamespaceSelector *labelSelector `yaml:"namespaceSelector"`
+			} `yaml:"from"`
+			Ports []struct {
+				Port interface{} `yaml:"port"` // number or name
+			} `yaml:"ports"`
+		} `yaml:"ingress"`
+	} `yaml:"spec"`
+}
+
+type workload struct {
+	kind, namespace, name string
+	labels                map[string]string // of the pods
+	replicas              int
+	pod                   podSpec
+	id, title             string
+}
+
+type manifests struct {
+	workloads       []*workload
+	services        []serviceManifest
+	ingresses       []ingressManifest
+	networkPolicies []networkPolicyManifest
+	namespaceLabels map[string]map[string]string
+}
+
+// Import reads the Kubernetes manifests (YAML files) of the directory (recursively) or single file given
+func Import(path string) (model.ModelInput, error) {
+	found := &manifests{namespaceLabels: make(map[string]map[string]string)}
+	err := filepath.Walk(path, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		extension := strings.ToLower(filepath.Ext(filename))
+		if info.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			return nil
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		if err = found.parse(content); err != nil {
+			return fmt.Errorf("unable to parse Kubernetes manifest %s: %v", filename, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.ModelInput{}, err
+	}
+	if len(found.workloads) == 0 {
+		return model.ModelInput{}, errors.New("no Kubernetes workloads found in: " + path)
+	}
+	return found.toModelInput(filepath.Base(path)), nil
+}
+
+// parse reads all YAML documents of the file, ignoring the ones being no Kubernetes manifests
+func (what *manifests) parse(content []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := what.parseDocument(&document); err != nil {
+			return err
+		}
+	}
+}
+
+func (what *manifests) parseDocument(document *yaml.Node) error {
+	var kind struct {
+		Kind  string      `yaml:"kind"`
+		Items []yaml.Node `yaml:"items"`
+	}
+	if err := document.Decode(&kind); err != nil {
+		return nil // not a manifest at all
+	}
+	switch kind.Kind {
+	case "List":
+		for i := range kind.Items {
+			if err := what.parseDocument(&kind.Items[i]); err != nil {
+				return err
+			}
+		}
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "CronJob":
+		var manifest workloadManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		template := manifest.Spec.Template
+		if kind.Kind == "CronJob" {
+			template = manifest.Spec.JobTemplate.Spec.Template
+		}
+		replicas := 1
+		if manifest.Spec.Replicas != nil {
+			replicas = *manifest.Spec.Replicas
+		} else if kind.Kind == "DaemonSet" {
+			replicas = 2 // running on each node, so redundant as long as the cluster has multiple nodes
+		}
+		what.addWorkload(kind.Kind, manifest.Metadata, template.Metadata.Labels, replicas, template.Spec)
+	case "Pod":
+		var manifest podManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		what.addWorkload(kind.Kind, manifest.Metadata, manifest.Metadata.Labels, 1, manifest.Spec)
+	case "Service":
+		var manifest serviceManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		manifest.Metadata.Namespace = namespaceOf(manifest.Metadata)
+		what.services = append(what.services, manifest)
+	case "Ingress":
+		var manifest ingressManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		manifest.Metadata.Namespace = namespaceOf(manifest.Metadata)
+		what.ingresses = append(what.ingresses, manifest)
+	case "NetworkPolicy":
+		var manifest networkPolicyManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		manifest.Metadata.Namespace = namespaceOf(manifest.Metadata)
+		what.networkPolicies = append(what.networkPolicies, manifest)
+	case "Namespace":
+		var manifest podManifest
+		if err := document.Decode(&manifest); err != nil {
+			return err
+		}
+		what.namespaceLabels[manifest.Metadata.Name] = manifest.Metadata.Labels
+	}
+	return nil
+}
+
+func (what *manifests) addWorkload(kind string, metadata metadata, labels map[string]string, replicas int, pod podSpec) {
+	what.workloads = append(what.workloads, &workload{
+		kind:      kind,
+		namespace: namespaceOf(metadata),
+		name:      metadata.Name,
+		labels:    labels,
+		replicas:  replicas,
+		pod:       pod,
+	})
+}
+
+func (what *manifests) toModelInput(source string) model.ModelInput {
+	modelInput := importers.NewModelInput("Kubernetes Import of "+source,
+		"Imported from the Kubernetes manifests of "+source+": Workloads as technical assets (running in shared runtimes per "+
+			"namespace) and their traffic (by network policies and service references in environment variables) as communication links.")
+	usedTitles := make(map[string]bool)
+
+	// workloads, being qualified by their namespace when the name is used in multiple namespaces
+	nameCount := make(map[string]int)
+	for _, workload := range what.workloads {
+		nameCount[workload.name]++
+	}
+	namespaces := make([]string, 0)
+	for _, workload := range what.workloads {
+		workload.title = workload.name
+		if nameCount[workload.name] > 1 {
+			workload.title += " (" + workload.namespace + ")"
+		}
+		workload.title = importers.UniqueTitle(workload.title, usedTitles)
+		usedTitles[workload.title] = true
+		workload.id = model.MakeID(workload.title)
+		if !model.Contains(namespaces, workload.namespace) {
+			namespaces = append(namespaces, workload.namespace)
+		}
+		modelInput.Technical_assets[workload.title] = what.toTechnicalAsset(&modelInput, workload)
+	}
+
+	// the cluster itself, as it is attackable via its workloads (container escape)
+	cluster := importers.NewTechnicalAsset("kubernetes-cluster", "Kubernetes cluster running the workloads of all namespaces")
+	cluster.Technology, cluster.Machine, cluster.Usage, cluster.Size = model.ContainerPlatform.String(), model.Virtual.String(), model.DevOps.String(), model.System.String()
+	cluster.Custom_developed_parts, cluster.Redundant = false, true
+	cluster.Integrity, cluster.Availability = model.Critical.String(), model.Critical.String()
+	importers.AddTags(&modelInput, &cluster.Tags, "kubernetes")
+	modelInput.Technical_assets[importers.UniqueTitle("Kubernetes Cluster", usedTitles)] = cluster
+
+	// namespaces as shared runtimes
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		sharedRuntime := model.InputSharedRuntime{
+			ID:                       model.MakeID("namespace-" + namespace),
+			Description:              "Kubernetes namespace " + namespace + " (container platform)",
+			Tags:                     make([]string, 0),
+			Technical_assets_running: make([]string, 0),
+		}
+		importers.AddTags(&modelInput, &sharedRuntime.Tags, "kubernetes")
+		for _, workload := range what.workloads {
+			if workload.namespace == namespace {
+				sharedRuntime.Technical_assets_running = append(sharedRuntime.Technical_assets_running, workload.id)
+			}
+		}
+		modelInput.Shared_runtimes["Namespace "+namespace] = sharedRuntime
+	}
+
+	what.addServiceReferenceLinks(&modelInput)
+	what.addNetworkPolicyLinks(&modelInput)
+	what.addIngresses(&modelInput, usedTitles)
+	what.markLoadBalancedWorkloads(&modelInput)
+	return modelInput
+}
+
+func (what *manifests) toTechnicalAsset(modelInput *model.ModelInput, workload *workload) model.InputTechnicalAsset {
+	images := make([]string, 0)
+	for _, container := range workload.pod.Containers {
+		images = append(images, container.Image)
+	}
+	technicalAsset := importers.NewTechnicalAsset(workload.id, "Kubernetes "+workload.kind+" "+workload.namespace+"/"+workload.name+
+		" running the image(s): "+strings.Join(images, ", "))
+	if len(images) > 0 {
+		technology, assetType := importers.TechnologyOfImage(images[0])
+		technicalAsset.Technology, technicalAsset.Type = technology.String(), assetType.String()
+		technicalAsset.Custom_developed_parts = technology == model.WebServiceREST // as the others are off-the-shelf products
+	}
+	if workload.kind == "Job" || workload.kind == "CronJob" {
+		technicalAsset.Technology = model.BatchProcessing.String()
+	}
+	technicalAsset.Redundant = workload.replicas > 1
+	for _, label := range []string{"owner", "team", "app.kubernetes.io/part-of"} {
+		if owner, ok := workload.labels[label]; ok && len(technicalAsset.Owner) == 0 {
+			technicalAsset.Owner = owner
+		}
+	}
+	importers.AddTags(modelInput, &technicalAsset.Tags, "kubernetes", strings.ToLower(workload.kind))
+
+	// the secrets referenced become data assets processed by the workload
+	for _, secret := range workload.secretsReferenced() {
+		id := model.MakeID("secret-" + workload.namespace + "-" + secret)
+		title := "Secret " + workload.namespace + "/" + secret
+		if _, exists := modelInput.Data_assets[title]; !exists {
+			dataAsset := importers.NewDataAsset(id, "Kubernetes secret "+workload.namespace+"/"+secret, model.DevOps)
+			importers.AddTags(modelInput, &dataAsset.Tags, "kubernetes", "secret")
+			modelInput.Data_assets[title] = dataAsset
+		}
+		technicalAsset.Data_assets_processed = append(technicalAsset.Data_assets_processed, id)
+	}
+	return technicalAsset
+}
+
+func (what *workload) secretsReferenced() []string {
+	result := make([]string, 0)
+	add := func(secret string) {
+		if len(secret) > 0 && !model.Contains(result, secret) {
+			result = append(result, secret)
+		}
+	}
+	for _, container := range append(what.pod.InitContainers, what.pod.Containers...) {
+		for _, env := range container.Env {
+			add(env.ValueFrom.SecretKeyRef.Name)
+		}
+		for _, envFrom := range container.EnvFrom {
+			add(envFrom.SecretRef.Name)
+		}
+	}
+	for _, volume := range what.pod.Volumes {
+		add(volume.Secret.SecretName)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// addServiceReferenceLinks links the workloads to the workloads of the services they reference in environment variables
+// (by the service's DNS name like "http://orders:8080" or "orders.shop.svc.cluster.local")
+func (what *manifests) addServiceReferenceLinks(modelInput *model.ModelInput) {
+	for _, source := range what.workloads {
+		for _, container := range append(source.pod.InitContainers, source.pod.Containers...) {
+			for _, env := range container.Env {
+				value := strings.ToLower(strings.TrimSpace(env.Value))
+				match := serviceReferenceRegEx.FindStringSubmatch(value)
+				if match == nil {
+					continue
+				}
+				service, found := what.serviceOfHost(match[1], source.namespace)
+				if !found {
+					continue
+				}
+				if !strings.Contains(value, "://") {
+					value = "tcp://" + value // to parse the port
+				}
+				protocol := importers.ProtocolOfURL(value)
+				for _, target := range what.workloadsOfService(service) {
+					what.addLink(modelInput, source, target, "Referenced via service "+service.Metadata.Name+" in "+env.Name, protocol)
+				}
+			}
+		}
+	}
+}
+
+func (what *manifests) serviceOfHost(host string, namespace string) (serviceManifest, bool) {
+	for _, service := range what.services {
+		if (host == service.Metadata.Name && service.Metadata.Namespace == namespace) ||
+			host == service.Metadata.Name+"."+service.Metadata.Namespace ||
+			strings.HasPrefix(host, service.Metadata.Name+"."+service.Metadata.Namespace+".svc") {
+			return service, true
+		}
+	}
+	return serviceManifest{}, false
+}
+
+func (what *manifests) serviceByName(name string, namespace string) (serviceManifest, bool) {
+	for _, service := range what.services {
+		if service.Metadata.Name == name && service.Metadata.Namespace == namespace {
+			return service, true
+		}
+	}
+	return serviceManifest{}, false
+}
+
+func (what *manifests) workloadsOfService(service serviceManifest) []*workload {
+	result := make([]*workload, 0)
+	if len(service.Spec.Selector) == 0 {
+		return result
+	}
+	for _, workload := range what.workloads {
+		if workload.namespace == service.Metadata.Namespace && matchesLabels(workload.labels, service.Spec.Selector) {
+			result = append(result, workload)
+		}
+	}
+	return result
+}
+
+// addNetworkPolicyLinks links the workloads allowed by network policies (only explicit pod and namespace selectors,
+// as allowing everything doesn't tell which traffic actually exists)
+func (what *manifests) addNetworkPolicyLinks(modelInput *model.ModelInput) {
+	for _, policy := range what.networkPolicies {
+		targets := what.workloadsSelected(policy.Metadata.Namespace, &policy.Spec.PodSelector, nil)
+		for _, ingress := range policy.Spec.Ingress {
+			protocol := model.UnknownProtocol
+			for _, port := range ingress.Ports {
+				if number, ok := port.Port.(int); ok {
+					protocol = importers.ProtocolOfPort(number)
+					break
+				}
+			}
+			for _, from := range ingress.From {
+				if isEmptySelector(from.PodSelector) && isEmptySelector(from.NamespaceSelector) {
+					continue
+				}
+				for _, source := range what.workloadsSelected(policy.Metadata.Namespace, from.PodSelector, from.NamespaceSelector) {
+					for _, target := range targets {
+						what.addLink(modelInput, source, target, "Allowed by network policy "+policy.Metadata.Namespace+"/"+policy.Metadata.Name, protocol)
+					}
+				}
+			}
+		}
+	}
+}
+
+// workloadsSelected returns the workloads of the namespace (or else of the namespaces selected) matching the pod selector
+func (what *manifests) workloadsSelected(namespace string, podSelector *labelSelector, namespaceSelector *labelSelector) []*workload {
+	result := make([]*workload, 0)
+	for _, workload := range what.workloads {
+		if namespaceSelector == nil {
+			if workload.namespace != namespace {
+				continue
+			}
+		} else {
+			namespaceLabels := map[string]string{"kubernetes.io/metadata.name": workload.namespace}
+			for key, value := range what.namespaceLabels[workload.namespace] {
+				namespaceLabels[key] = value
+			}
+			if !matchesLabels(namespaceLabels, namespaceSelector.MatchLabels) {
+				continue
+			}
+		}
+		if podSelector == nil || matchesLabels(workload.labels, podSelector.MatchLabels) {
+			result = append(result, workload)
+		}
+	}
+	return result
+}
+
+// addIngresses adds the ingresses as technical assets accessible from the internet, linked to their backend workloads
+func (what *manifests) addIngresses(modelInput *model.ModelInput, usedTitles map[string]bool) {
+	for _, ingress := range what.ingresses {
+		backends := make([]string, 0)
+		addBackend := func(backend ingressBackend) {
+			name := backend.Service.Name
+			if len(name) == 0 {
+				name = backend.ServiceName
+			}
+			if len(name) > 0 && !model.Contains(backends, name) {
+				backends = append(backends, name)
+			}
+		}
+		addBackend(ingress.Spec.DefaultBackend)
+		addBackend(ingress.Spec.Backend)
+		hosts := make([]string, 0)
+		for _, rule := range ingress.Spec.Rules {
+			if len(rule.Host) > 0 {
+				hosts = append(hosts, rule.Host)
+			}
+			for _, path := range rule.HTTP.Paths {
+				addBackend(path.Backend)
+			}
+		}
+		title := importers.UniqueTitle("Ingress "+ingress.Metadata.Name, usedTitles)
+		usedTitles[title] = true
+		encryption := "unencrypted HTTP"
+		if len(ingress.Spec.TLS) > 0 {
+			encryption = "HTTPS"
+		}
+		technicalAsset := importers.NewTechnicalAsset(model.MakeID(title), "Kubernetes Ingress "+ingress.Metadata.Namespace+"/"+
+			ingress.Metadata.Name+" serving the host(s) "+strings.Join(hosts, ", ")+" via "+encryption)
+		technicalAsset.Technology, technicalAsset.Internet, technicalAsset.Custom_developed_parts = model.ReverseProxy.String(), true, false
+		importers.AddTags(modelInput, &technicalAsset.Tags, "kubernetes", "ingress")
+		for _, backend := range backends {
+			service, found := what.serviceByName(backend, ingress.Metadata.Namespace)
+			if !found {
+				continue
+			}
+			protocol := model.HTTP
+			for _, port := range service.Spec.Ports {
+				if port.Port == 443 || port.Name == "https" {
+					protocol = model.HTTPS
+				}
+			}
+			for _, target := range what.workloadsOfService(service) {
+				linkTitle := target.title + " Traffic"
+				if _, exists := technicalAsset.Communication_links[linkTitle]; !exists {
+					technicalAsset.Communication_links[linkTitle] = importers.NewCommunicationLink(target.id,
+						"Routed by the ingress via service "+service.Metadata.Name, protocol)
+				}
+			}
+		}
+		modelInput.Technical_assets[title] = technicalAsset
+	}
+}
+
+// markLoadBalancedWorkloads sets the workloads exposed via services of type LoadBalancer as accessible from the internet
+func (what *manifests) markLoadBalancedWorkloads(modelInput *model.ModelInput) {
+	for _, service := range what.services {
+		if service.Spec.Type != "LoadBalancer" {
+			continue
+		}
+		for _, workload := range what.workloadsOfService(service) {
+			technicalAsset := modelInput.Technical_assets[workload.title]
+			technicalAsset.Internet = true
+			technicalAsset.Description += " (exposed via load balancer service " + service.Metadata.Name + ")"
+			modelInput.Technical_assets[workload.title] = technicalAsset
+		}
+	}
+}
+
+// addLink adds a communication link unless the source is already linked to the target
+func (what *manifests) addLink(modelInput *model.ModelInput, source *workload, target *workload, description string, protocol model.Protocol) {
+	if source.id == target.id {
+		return
+	}
+	technicalAsset := modelInput.Technical_assets[source.title]
+	for _, link := range technicalAsset.Communication_links {
+		if link.Target == target.id {
+			return
+		}
+	}
+	technicalAsset.Communication_links[target.title+" Traffic"] = importers.NewCommunicationLink(target.id, description, protocol)
+	modelInput.Technical_assets[source.title] = technicalAsset
+}
+
+func namespaceOf(metadata metadata) string {
+	if len(metadata.Namespace) == 0 {
+		return defaultNamespace
+	}
+	return metadata.Namespace
+}
+
+func matchesLabels(labels map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptySelector(selector *labelSelector) bool {
+	return selector == nil || len(selector.MatchLabels) == 0
+}
@@ -0,0 +1,341 @@
+package importers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadModelInput reads a model file as it is (without merging its includes, as the merge proposal is based on it)
+func ReadModelInput(filename string) (model.ModelInput, error) {
+	var modelInput model.ModelInput
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return modelInput, err
+	}
+	err = yaml.Unmarshal(content, &modelInput)
+	return modelInput, err
+}
+
+// MergeProposal adds the elements of the imported model to the existing one (matching them by their IDs) without
+// changing any of the elements existing: New data assets, technical assets, communication links (also of existing
+// technical assets), trust boundaries, and shared runtimes are added, where technical assets not yet inside a trust
+// boundary are put into the imported ones and new technical assets also into the existing shared runtimes. Existing
+// technical assets only get the data assets and data formats missing. The IDs of the included model files (given
+// relative to the model file) are considered as existing as well. It returns the changes made along with notes about
+// the differences to be reviewed by hand, like technical assets tagged with one of the given base tags but missing in
+// the import or existing communication links to the technical assets imported using another authentication.
+func MergeProposal(existing *model.ModelInput, modelFilename string, imported model.ModelInput, baseTags ...string) (changes []string, notes []string) {
+	changes, notes = make([]string, 0), make([]string, 0)
+	existingIds := make(map[string]bool)
+	existingTitles := make(map[string]bool)
+	placedAssetIds := make(map[string]bool) // the technical assets already inside a trust boundary
+	existingAssets := make(map[string]model.InputTechnicalAsset)
+	collectExisting(*existing, modelFilename, map[string]bool{}, existingIds, existingTitles, placedAssetIds, existingAssets)
+	if existing.Data_assets == nil {
+		existing.Data_assets = make(map[string]model.InputDataAsset)
+	}
+	if existing.Technical_assets == nil {
+		existing.Technical_assets = make(map[string]model.InputTechnicalAsset)
+	}
+	if existing.Trust_boundaries == nil {
+		existing.Trust_boundaries = make(map[string]model.InputTrustBoundary)
+	}
+	if existing.Shared_runtimes == nil {
+		existing.Shared_runtimes = make(map[string]model.InputSharedRuntime)
+	}
+
+	for _, title := range sortedKeys(imported.Data_assets) {
+		dataAsset := imported.Data_assets[title]
+		if !existingIds[dataAsset.ID] {
+			newTitle := UniqueTitle(title, existingTitles)
+			existingTitles[newTitle] = true
+			existing.Data_assets[newTitle] = dataAsset
+			changes = append(changes, "added data asset "+dataAsset.ID)
+		}
+	}
+
+	addedAssetIds := make(map[string]bool)
+	for _, title := range sortedKeys(imported.Technical_assets) {
+		technicalAsset := imported.Technical_assets[title]
+		existingAsset, exists := existingAssets[technicalAsset.ID]
+		if !exists {
+			newTitle := UniqueTitle(title, existingTitles)
+			existingTitles[newTitle] = true
+			existing.Technical_assets[newTitle] = technicalAsset
+			addedAssetIds[technicalAsset.ID] = true
+			changes = append(changes, "added technical asset "+technicalAsset.ID)
+			continue
+		}
+		for _, field := range [][3]string{
+			{"technology", existingAsset.Technology, technicalAsset.Technology},
+			{"type", existingAsset.Type, technicalAsset.Type},
+			{"machine", existingAsset.Machine, technicalAsset.Machine},
+			{"encryption", existingAsset.Encryption, technicalAsset.Encryption},
+		} {
+			if len(field[1]) > 0 && field[1] != field[2] {
+				notes = append(notes, "technical asset "+technicalAsset.ID+": "+field[0]+" is "+field[1]+" in the model but "+field[2]+" in the import")
+			}
+		}
+		if technicalAsset.Internet && !existingAsset.Internet {
+			notes = append(notes, "technical asset "+technicalAsset.ID+": accessible from the internet according to the import but not in the model")
+		}
+		// the links of existing technical assets are added when their target isn't linked yet
+		for title, candidate := range existing.Technical_assets {
+			if candidate.ID != technicalAsset.ID {
+				continue
+			}
+			for _, linkTitle := range sortedKeys(technicalAsset.Communication_links) {
+				link := technicalAsset.Communication_links[linkTitle]
+				linked := false
+				for _, existingLink := range candidate.Communication_links {
+					linked = linked || existingLink.Target == link.Target
+				}
+				if !linked {
+					if candidate.Communication_links == nil {
+						candidate.Communication_links = make(map[string]model.InputCommunicationLink)
+					}
+					usedLinkTitles := make(map[string]bool)
+					for existingLinkTitle := range candidate.Communication_links {
+						usedLinkTitles[existingLinkTitle] = true
+					}
+					candidate.Communication_links[UniqueTitle(linkTitle, usedLinkTitles)] = link
+					changes = append(changes, "added communication link from "+technicalAsset.ID+" to "+link.Target)
+				}
+			}
+			// the lists of existing technical assets only get the items missing
+			for _, list := range []struct {
+				name     string
+				existing *[]string
+				imported []string
+			}{
+				{"processed data asset", &candidate.Data_assets_processed, technicalAsset.Data_assets_processed},
+				{"stored data asset", &candidate.Data_assets_stored, technicalAsset.Data_assets_stored},
+				{"accepted data format", &candidate.Data_formats_accepted, technicalAsset.Data_formats_accepted},
+			} {
+				for _, item := range list.imported {
+					if !model.Contains(*list.existing, item) {
+						*list.existing = append(*list.existing, item)
+						changes = append(changes, "added "+list.name+" "+item+" to technical asset "+technicalAsset.ID)
+					}
+				}
+			}
+			existing.Technical_assets[title] = candidate
+		}
+	}
+	// the existing links to the technical assets imported (like calls of an API) differing from the imported ones
+	for _, technicalAsset := range imported.Technical_assets {
+		for _, link := range technicalAsset.Communication_links {
+			if _, exists := existingAssets[link.Target]; !exists {
+				continue
+			}
+			for _, existingAsset := range existingAssets {
+				for linkTitle, existingLink := range existingAsset.Communication_links {
+					if existingLink.Target != link.Target || existingAsset.ID == technicalAsset.ID {
+						continue
+					}
+					for _, field := range [][3]string{
+						{"protocol", existingLink.Protocol, link.Protocol},
+						{"authentication", existingLink.Authentication, link.Authentication},
+						{"authorization", existingLink.Authorization, link.Authorization},
+					} {
+						note := "communication link '" + linkTitle + "' of technical asset " + existingAsset.ID + ": " + field[0] + " is " +
+							field[1] + " in the model but " + field[2] + " in the import"
+						if len(field[1]) > 0 && field[1] != field[2] && !model.Contains(notes, note) {
+							notes = append(notes, note)
+						}
+					}
+				}
+			}
+		}
+	}
+	for id, existingAsset := range existingAssets {
+		if _, found := technicalAssetById(imported, id); !found && isTaggedWithAnyBaseTag(existingAsset.Tags, baseTags) {
+			notes = append(notes, "technical asset "+id+": not part of the import anymore (although tagged with one of: "+strings.Join(baseTags, ", ")+")")
+		}
+	}
+
+	for _, title := range sortedKeys(imported.Trust_boundaries) {
+		trustBoundary := imported.Trust_boundaries[title]
+		trustBoundary.Technical_assets_inside = onlyUnplaced(trustBoundary.Technical_assets_inside, placedAssetIds)
+		existingTitle, exists := titleOfTrustBoundary(*existing, trustBoundary.ID)
+		if !exists {
+			if existingIds[trustBoundary.ID] {
+				continue // inside an included model file, so not to be changed here
+			}
+			newTitle := UniqueTitle(title, existingTitles)
+			existingTitles[newTitle] = true
+			trustBoundary.Trust_boundaries_nested = make([]string, 0) // see below
+			existing.Trust_boundaries[newTitle] = trustBoundary
+			changes = append(changes, "added trust boundary "+trustBoundary.ID)
+			continue
+		}
+		existingBoundary := existing.Trust_boundaries[existingTitle]
+		for _, id := range trustBoundary.Technical_assets_inside {
+			existingBoundary.Technical_assets_inside = append(existingBoundary.Technical_assets_inside, id)
+			changes = append(changes, "added technical asset "+id+" to trust boundary "+trustBoundary.ID)
+		}
+		existing.Trust_boundaries[existingTitle] = existingBoundary
+	}
+	// the nesting of the trust boundaries (after all of them being added), unless already nested elsewhere
+	nested := make(map[string]bool)
+	for _, trustBoundary := range existing.Trust_boundaries {
+		for _, id := range trustBoundary.Trust_boundaries_nested {
+			nested[id] = true
+		}
+	}
+	for _, title := range sortedKeys(imported.Trust_boundaries) {
+		importedBoundary := imported.Trust_boundaries[title]
+		existingTitle, exists := titleOfTrustBoundary(*existing, importedBoundary.ID)
+		if !exists {
+			continue
+		}
+		existingBoundary := existing.Trust_boundaries[existingTitle]
+		for _, id := range importedBoundary.Trust_boundaries_nested {
+			if !nested[id] && !model.Contains(existingBoundary.Trust_boundaries_nested, id) {
+				existingBoundary.Trust_boundaries_nested = append(existingBoundary.Trust_boundaries_nested, id)
+				nested[id] = true
+				changes = append(changes, "nested trust boundary "+id+" into "+importedBoundary.ID)
+			}
+		}
+		existing.Trust_boundaries[existingTitle] = existingBoundary
+	}
+
+	for _, title := range sortedKeys(imported.Shared_runtimes) {
+		sharedRuntime := imported.Shared_runtimes[title]
+		existingTitle := ""
+		for candidate, existingRuntime := range existing.Shared_runtimes {
+			if existingRuntime.ID == sharedRuntime.ID {
+				existingTitle = candidate
+			}
+		}
+		if len(existingTitle) == 0 {
+			if !existingIds[sharedRuntime.ID] {
+				newTitle := UniqueTitle(title, existingTitles)
+				existingTitles[newTitle] = true
+				existing.Shared_runtimes[newTitle] = sharedRuntime
+				changes = append(changes, "added shared runtime "+sharedRuntime.ID)
+			}
+			continue
+		}
+		existingRuntime := existing.Shared_runtimes[existingTitle]
+		for _, id := range sharedRuntime.Technical_assets_running {
+			if addedAssetIds[id] {
+				existingRuntime.Technical_assets_running = append(existingRuntime.Technical_assets_running, id)
+				changes = append(changes, "added technical asset "+id+" to shared runtime "+sharedRuntime.ID)
+			}
+		}
+		existing.Shared_runtimes[existingTitle] = existingRuntime
+	}
+
+	for _, tag := range imported.Tags_available {
+		if !model.Contains(existing.Tags_available, tag) {
+			existing.Tags_available = append(existing.Tags_available, tag)
+		}
+	}
+	sort.Strings(existing.Tags_available)
+	sort.Strings(notes)
+	return changes, notes
+}
+
+// collectExisting collects the IDs and titles of the model and its included model files (recursively)
+func collectExisting(modelInput model.ModelInput, filename string, alreadyIncluded map[string]bool,
+	ids map[string]bool, titles map[string]bool, placedAssetIds map[string]bool, technicalAssets map[string]model.InputTechnicalAsset) {
+	alreadyIncluded[filename] = true
+	for title, dataAsset := range modelInput.Data_assets {
+		ids[dataAsset.ID], titles[title] = true, true
+	}
+	for title, technicalAsset := range modelInput.Technical_assets {
+		ids[technicalAsset.ID], titles[title] = true, true
+		technicalAssets[technicalAsset.ID] = technicalAsset
+	}
+	for title, trustBoundary := range modelInput.Trust_boundaries {
+		ids[trustBoundary.ID], titles[title] = true, true
+		for _, id := range trustBoundary.Technical_assets_inside {
+			placedAssetIds[id] = true
+		}
+	}
+	for title, sharedRuntime := range modelInput.Shared_runtimes {
+		ids[sharedRuntime.ID], titles[title] = true, true
+	}
+	for _, include := range modelInput.Includes {
+		includeFilename := filepath.Join(filepath.Dir(filename), strings.TrimSpace(include))
+		if len(strings.TrimSpace(include)) == 0 || alreadyIncluded[includeFilename] {
+			continue
+		}
+		if included, err := ReadModelInput(includeFilename); err == nil {
+			collectExisting(included, includeFilename, alreadyIncluded, ids, titles, placedAssetIds, technicalAssets)
+		}
+	}
+}
+
+// onlyUnplaced returns the technical assets not yet inside a trust boundary (as they can only be inside one)
+func onlyUnplaced(ids []string, placedAssetIds map[string]bool) []string {
+	result := make([]string, 0)
+	for _, id := range ids {
+		if !placedAssetIds[id] {
+			result = append(result, id)
+			placedAssetIds[id] = true
+		}
+	}
+	return result
+}
+
+func titleOfTrustBoundary(modelInput model.ModelInput, id string) (string, bool) {
+	for title, trustBoundary := range modelInput.Trust_boundaries {
+		if trustBoundary.ID == id {
+			return title, true
+		}
+	}
+	return "", false
+}
+
+func technicalAssetById(modelInput model.ModelInput, id string) (model.InputTechnicalAsset, bool) {
+	for _, technicalAsset := range modelInput.Technical_assets {
+		if technicalAsset.ID == id {
+			return technicalAsset, true
+		}
+	}
+	return model.InputTechnicalAsset{}, false
+}
+
+func isTaggedWithAnyBaseTag(tags []string, baseTags []string) bool {
+	for _, baseTag := range baseTags {
+		if model.IsTaggedWithBaseTag(tags, baseTag) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(items interface{}) []string {
+	result := make([]string, 0)
+	switch typed := items.(type) {
+	case map[string]model.InputDataAsset:
+		for key := range typed {
+			result = append(result, key)
+		}
+	case map[string]model.InputTechnicalAsset:
+		for key := range typed {
+			result = append(result, key)
+		}
+	case map[string]model.InputCommunicationLink:
+		for key := range typed {
+			result = append(result, key)
+		}
+	case map[string]model.InputTrustBoundary:
+		for key := range typed {
+			result = append(result, key)
+		}
+	case map[string]model.InputSharedRuntime:
+		for key := range typed {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
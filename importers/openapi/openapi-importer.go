@@ -0,0 +1,382 @@
+// Package openapi imports a REST API (as technical asset accepting the data formats of its request bodies), its schemas
+// (as data assets with a hint for PII fields), and its clients (as incoming communication link secured according to the
+// security schemes) from an OpenAPI 3 document into a starting model
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+// field names hinting at personally identifiable information (matched case-insensitive and ignoring separators)
+var piiFieldRegEx = regexp.MustCompile(`^(.*(email|mail|phone|mobile|address|street|zip|postal|birth|dob|ssn|socialsecurity|passport|` +
+	`iban|creditcard|cardnumber|taxid|nationalid|gender|ipaddress|geolocation|latitude|longitude)|(first|last|full|given|family|sur|user|display)name)$`)
+
+var separatorRegEx = regexp.MustCompile(`[^a-z0-9]+`)
+
+type document struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Security   []map[string][]string           `yaml:"security"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"` // the path items also have non-operation fields like parameters
+	Components struct {
+		Schemas         map[string]*schema         `yaml:"schemas"`
+		RequestBodies   map[string]*requestBody    `yaml:"requestBodies"`
+		Responses       map[string]*response       `yaml:"responses"`
+		SecuritySchemes map[string]*securityScheme `yaml:"securitySchemes"`
+	} `yaml:"components"`
+}
+
+type operation struct {
+	Security    *[]map[string][]string `yaml:"security"` // nil when inheriting the global security requirements
+	RequestBody *requestBody           `yaml:"requestBody"`
+	Responses   map[string]*response   `yaml:"responses"`
+}
+
+type requestBody struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]mediaType `yaml:"content"`
+}
+
+type response struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]mediaType `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref         string             `yaml:"$ref"`
+	Type        string             `yaml:"type"`
+	Format      string             `yaml:"format"`
+	Description string             `yaml:"description"`
+	Properties  map[string]*schema `yaml:"properties"`
+	Items       *schema            `yaml:"items"`
+	AllOf       []*schema          `yaml:"allOf"`
+	OneOf       []*schema          `yaml:"oneOf"`
+	AnyOf       []*schema          `yaml:"anyOf"`
+}
+
+type securityScheme struct {
+	Type   string `yaml:"type"`   // apiKey, http, mutualTLS, oauth2, or openIdConnect
+	Scheme string `yaml:"scheme"` // of type http, like basic or bearer
+	In     string `yaml:"in"`     // of type apiKey, like header or cookie
+}
+
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Import reads the OpenAPI 3 document (YAML or JSON) given
+func Import(filename string) (model.ModelInput, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return model.ModelInput{}, err
+	}
+	var api document
+	if err = yaml.Unmarshal(content, &api); err != nil {
+		return model.ModelInput{}, fmt.Errorf("unable to parse OpenAPI document %s: %v", filename, err)
+	}
+	if !strings.HasPrefix(api.OpenAPI, "3.") {
+		return model.ModelInput{}, errors.New("no OpenAPI 3 document (as field openapi is not 3.x): " + filename)
+	}
+	if len(api.Info.Title) == 0 {
+		return model.ModelInput{}, errors.New("no title given in the info of the OpenAPI document: " + filename)
+	}
+	return api.toModelInput()
+}
+
+func (what *document) toModelInput() (model.ModelInput, error) {
+	modelInput := importers.NewModelInput("OpenAPI Import of "+what.Info.Title,
+		"Imported from the OpenAPI document of "+what.Info.Title+": The API as technical asset processing its schemas as data "+
+			"assets and accepting the data formats of its request bodies, accessed by its clients via a communication link.")
+	apiId := model.MakeID(what.Info.Title)
+
+	// the schemas used in request bodies (being sent) and in responses (being received) become data assets
+	sent, received, dataFormats := make([]string, 0), make([]string, 0), make([]string, 0)
+	authentication, authorization := model.NoneAuthentication, model.NoneAuthorization
+	schemesUsed := make([]string, 0)
+	for _, path := range sortedPaths(what.Paths) {
+		for _, method := range operationMethods {
+			node, ok := what.Paths[path][method]
+			if !ok {
+				continue
+			}
+			var operation operation
+			if err := node.Decode(&operation); err != nil {
+				return model.ModelInput{}, fmt.Errorf("unable to parse operation %s %s: %v", strings.ToUpper(method), path, err)
+			}
+			if body := what.resolveRequestBody(operation.RequestBody); body != nil {
+				for contentType, media := range body.Content {
+					if format, ok := dataFormatOf(contentType, media.Schema); ok && !model.Contains(dataFormats, format.String()) {
+						dataFormats = append(dataFormats, format.String())
+					}
+					sent = what.appendSchemaNames(sent, media.Schema)
+				}
+			}
+			for status, candidate := range operation.Responses {
+				if response := what.resolveResponse(candidate); response != nil && strings.HasPrefix(status, "2") {
+					for _, media := range response.Content {
+						received = what.appendSchemaNames(received, media.Schema)
+					}
+				}
+			}
+			security := what.Security
+			if operation.Security != nil {
+				security = *operation.Security
+			}
+			for _, requirement := range security {
+				for name := range requirement {
+					if !model.Contains(schemesUsed, name) {
+						schemesUsed = append(schemesUsed, name)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(sent)
+	sort.Strings(received)
+	for _, name := range sortedSchemaNames(what.Components.Schemas) {
+		if model.Contains(sent, name) || model.Contains(received, name) {
+			modelInput.Data_assets[name] = what.toDataAsset(&modelInput, name)
+		}
+	}
+	sort.Strings(dataFormats)
+	sort.Strings(schemesUsed)
+	// the strongest authentication of the security schemes used
+	for _, name := range schemesUsed {
+		if scheme, ok := what.Components.SecuritySchemes[name]; ok {
+			schemeAuthentication, schemeAuthorization := scheme.authentication()
+			if schemeAuthentication > authentication || (schemeAuthentication == authentication && schemeAuthorization > authorization) {
+				authentication, authorization = schemeAuthentication, schemeAuthorization
+			}
+		}
+	}
+
+	description := what.Info.Title
+	if len(what.Info.Version) > 0 {
+		description += " (version " + what.Info.Version + ")"
+	}
+	if len(what.Info.Description) > 0 {
+		description += ": " + strings.TrimSpace(what.Info.Description)
+	}
+	api := importers.NewTechnicalAsset(apiId, description)
+	api.Data_formats_accepted = dataFormats
+	for _, name := range sortedSchemaNames(what.Components.Schemas) {
+		if dataAsset, ok := modelInput.Data_assets[name]; ok {
+			api.Data_assets_processed = append(api.Data_assets_processed, dataAsset.ID)
+		}
+	}
+	importers.AddTags(&modelInput, &api.Tags, "openapi")
+	modelInput.Technical_assets[what.Info.Title] = api
+
+	// the clients as placeholder (out of scope) to hold the incoming communication link
+	clientTitle := importers.UniqueTitle("Client of "+what.Info.Title, map[string]bool{what.Info.Title: true})
+	client := importers.NewTechnicalAsset(model.MakeID(clientTitle), "Placeholder for the clients of "+what.Info.Title+
+		", to be replaced by the actual clients")
+	client.Type, client.Technology, client.Custom_developed_parts = model.ExternalEntity.String(), model.ClientSystem.String(), false
+	client.Out_of_scope, client.Justification_out_of_scope = true, "Placeholder for the clients of the API"
+	importers.AddTags(&modelInput, &client.Tags, "openapi")
+	link := importers.NewCommunicationLink(apiId, "Calls of the API"+describeSchemes(schemesUsed), what.protocol())
+	link.Authentication, link.Authorization = authentication.String(), authorization.String()
+	for _, name := range sent {
+		link.Data_assets_sent = append(link.Data_assets_sent, modelInput.Data_assets[name].ID)
+	}
+	for _, name := range received {
+		link.Data_assets_received = append(link.Data_assets_received, modelInput.Data_assets[name].ID)
+	}
+	client.Communication_links[what.Info.Title+" Calls"] = link
+	modelInput.Technical_assets[clientTitle] = client
+	return modelInput, nil
+}
+
+// toDataAsset proposes the schema as data asset, rated confidential when having fields which might contain PII
+func (what *document) toDataAsset(modelInput *model.ModelInput, name string) model.InputDataAsset {
+	schema := what.Components.Schemas[name]
+	fields := what.fieldsOf(schema, map[string]bool{name: true})
+	piiFields := make([]string, 0)
+	for _, field := range fields {
+		if piiFieldRegEx.MatchString(separatorRegEx.ReplaceAllString(strings.ToLower(field), "")) {
+			piiFields = append(piiFields, field)
+		}
+	}
+	description := "Schema " + name + " of the API " + what.Info.Title
+	if schema != nil && len(schema.Description) > 0 {
+		description += ": " + strings.TrimSpace(schema.Description)
+	}
+	if len(fields) > 0 {
+		description += " (fields: " + strings.Join(fields, ", ") + ")"
+	}
+	dataAsset := importers.NewDataAsset(model.MakeID(name), description, model.Business)
+	dataAsset.Confidentiality = model.Internal.String()
+	importers.AddTags(modelInput, &dataAsset.Tags, "openapi")
+	if len(piiFields) > 0 {
+		dataAsset.Confidentiality = model.Confidential.String()
+		dataAsset.Justification_cia_rating = importers.JustificationToBeReviewed + " (potential PII fields: " + strings.Join(piiFields, ", ") + ")"
+		importers.AddTags(modelInput, &dataAsset.Tags, "pii")
+	}
+	return dataAsset
+}
+
+// fieldsOf returns the (sorted) property names of the schema, including the ones of the schemas it is composed of
+func (what *document) fieldsOf(schema *schema, visited map[string]bool) []string {
+	result := make([]string, 0)
+	if schema == nil {
+		return result
+	}
+	if name := schemaNameOf(schema.Ref); len(name) > 0 {
+		if visited[name] {
+			return result
+		}
+		visited[name] = true
+		return what.fieldsOf(what.Components.Schemas[name], visited)
+	}
+	for field := range schema.Properties {
+		result = append(result, field)
+	}
+	for _, composed := range append(append(schema.AllOf, schema.OneOf...), schema.AnyOf...) {
+		for _, field := range what.fieldsOf(composed, visited) {
+			if !model.Contains(result, field) {
+				result = append(result, field)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// appendSchemaNames appends the names of the component schemas referenced by the schema (also within arrays, composed
+// schemas, and properties)
+func (what *document) appendSchemaNames(names []string, schema *schema) []string {
+	if schema == nil {
+		return names
+	}
+	if name := schemaNameOf(schema.Ref); len(name) > 0 {
+		if model.Contains(names, name) {
+			return names
+		}
+		names = append(names, name)
+		return what.appendSchemaNames(names, what.Components.Schemas[name])
+	}
+	names = what.appendSchemaNames(names, schema.Items)
+	for _, composed := range append(append(schema.AllOf, schema.OneOf...), schema.AnyOf...) {
+		names = what.appendSchemaNames(names, composed)
+	}
+	for _, property := range schema.Properties {
+		names = what.appendSchemaNames(names, property)
+	}
+	return names
+}
+
+func (what *document) resolveRequestBody(body *requestBody) *requestBody {
+	if body != nil && len(body.Ref) > 0 {
+		return what.Components.RequestBodies[strings.TrimPrefix(body.Ref, "#/components/requestBodies/")]
+	}
+	return body
+}
+
+func (what *document) resolveResponse(response *response) *response {
+	if response != nil && len(response.Ref) > 0 {
+		return what.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+	}
+	return response
+}
+
+// protocol returns the protocol of the first server with an absolute URL, or else HTTPS (to be reviewed)
+func (what *document) protocol() model.Protocol {
+	for _, server := range what.Servers {
+		if protocol := importers.ProtocolOfURL(server.URL); protocol != model.UnknownProtocol {
+			return protocol
+		}
+	}
+	return model.HTTPS
+}
+
+// authentication maps the security scheme to the authentication and authorization of the link
+func (what *securityScheme) authentication() (model.Authentication, model.Authorization) {
+	switch strings.ToLower(what.Type) {
+	case "http":
+		if strings.ToLower(what.Scheme) == "bearer" {
+			return model.Token, model.EnduserIdentityPropagation
+		}
+		return model.Credentials, model.TechnicalUser
+	case "apikey":
+		if strings.ToLower(what.In) == "cookie" {
+			return model.SessionId, model.EnduserIdentityPropagation
+		}
+		return model.Token, model.TechnicalUser
+	case "mutualtls":
+		return model.ClientCertificate, model.TechnicalUser
+	case "oauth2", "openidconnect":
+		return model.Externalized, model.EnduserIdentityPropagation
+	}
+	return model.NoneAuthentication, model.NoneAuthorization
+}
+
+// dataFormatOf maps the content type (and schema) of a request body to the data format accepted
+func dataFormatOf(contentType string, schema *schema) (model.DataFormat, bool) {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case contentType == "application/json" || strings.HasSuffix(contentType, "+json"):
+		return model.JSON, true
+	case contentType == "application/xml" || contentType == "text/xml" || strings.HasSuffix(contentType, "+xml"):
+		return model.XML, true
+	case contentType == "text/csv":
+		return model.CSV, true
+	case contentType == "application/x-java-serialized-object" || contentType == "application/x-protobuf" ||
+		contentType == "application/octet-stream" && schema != nil && schema.Format != "binary":
+		return model.Serialization, true
+	case contentType == "multipart/form-data" || contentType == "application/octet-stream" || strings.HasPrefix(contentType, "image/") ||
+		strings.HasPrefix(contentType, "video/") || strings.HasPrefix(contentType, "audio/") || contentType == "application/pdf" ||
+		contentType == "application/zip":
+		return model.File, true
+	}
+	return model.JSON, false
+}
+
+func describeSchemes(schemes []string) string {
+	if len(schemes) == 0 {
+		return " (without security requirements)"
+	}
+	return " (secured via " + strings.Join(schemes, ", ") + ")"
+}
+
+func schemaNameOf(ref string) string {
+	if strings.HasPrefix(ref, "#/components/schemas/") {
+		return strings.TrimPrefix(ref, "#/components/schemas/")
+	}
+	return ""
+}
+
+func sortedSchemaNames(schemas map[string]*schema) []string {
+	result := make([]string, 0)
+	for name := range schemas {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedPaths(paths map[string]map[string]yaml.Node) []string {
+	result := make([]string, 0)
+	for path := range paths {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,860 @@
+// Package terraform imports the cloud topology (resources as technical assets, networks as trust boundaries, and the
+// traffic allowed by security groups, firewalls, and load balancers as communication links) from the JSON output of
+// "terraform show -json" (of a state or a plan) into a starting model
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+)
+
+// the base tags of the cloud providers as understood by the missing-cloud-hardening rule
+var CloudTags = []string{"aws", "azure", "gcp", "ocp"}
+
+type assetMapping struct {
+	technology model.TechnicalAssetTechnology
+	assetType  model.TechnicalAssetType
+	machine    model.TechnicalAssetMachine
+	tag        string         // the (sub)tag understood by the missing-cloud-hardening rule
+	protocol   model.Protocol // used by the clients to access it
+	managed    bool           // being a managed service, which is redundant and encrypted at rest by default
+}
+
+// the resource types imported as technical assets
+var assetMappings = map[string]assetMapping{
+	// AWS
+	"aws_instance":                      {model.ApplicationServer, model.Process, model.Virtual, "aws:ec2", model.HTTPS, false},
+	"aws_autoscaling_group":             {model.ApplicationServer, model.Process, model.Virtual, "aws:ec2", model.HTTPS, false},
+	"aws_db_instance":                   {model.Database, model.Datastore, model.Virtual, "aws:rds", model.SQL_access_protocol_encrypted, false},
+	"aws_rds_cluster":                   {model.Database, model.Datastore, model.Virtual, "aws:rds", model.SQL_access_protocol_encrypted, false},
+	"aws_dynamodb_table":                {model.Database, model.Datastore, model.Serverless, "aws:dynamodb", model.HTTPS, true},
+	"aws_elasticache_cluster":           {model.Database, model.Datastore, model.Virtual, "aws", model.NoSQL_access_protocol, false},
+	"aws_s3_bucket":                     {model.FileServer, model.Datastore, model.Serverless, "aws:s3", model.HTTPS, true},
+	"aws_lambda_function":               {model.Function, model.Process, model.Serverless, "aws:lambda", model.HTTPS, true},
+	"aws_lb":                            {model.LoadBalancer, model.Process, model.Virtual, "aws", model.HTTPS, true},
+	"aws_alb":                           {model.LoadBalancer, model.Process, model.Virtual, "aws", model.HTTPS, true},
+	"aws_elb":                           {model.LoadBalancer, model.Process, model.Virtual, "aws", model.HTTPS, true},
+	"aws_api_gateway_rest_api":          {model.Gateway, model.Process, model.Serverless, "aws:apigateway", model.HTTPS, true},
+	"aws_apigatewayv2_api":              {model.Gateway, model.Process, model.Serverless, "aws:apigateway", model.HTTPS, true},
+	"aws_cloudfront_distribution":       {model.ReverseProxy, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_sqs_queue":                     {model.MessageQueue, model.Process, model.Serverless, "aws:sqs", model.HTTPS, true},
+	"aws_sns_topic":                     {model.MessageQueue, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_ecs_service":                   {model.WebServiceREST, model.Process, model.Container, "aws", model.HTTPS, false},
+	"aws_eks_cluster":                   {model.ContainerPlatform, model.Process, model.Virtual, "aws", model.HTTPS, true},
+	"aws_secretsmanager_secret":         {model.Vault, model.Datastore, model.Serverless, "aws", model.HTTPS, true},
+	"aws_elasticsearch_domain":          {model.SearchIndex, model.Datastore, model.Virtual, "aws", model.HTTPS, false},
+	"aws_opensearch_domain":             {model.SearchIndex, model.Datastore, model.Virtual, "aws", model.HTTPS, false},
+	"aws_kinesis_stream":                {model.StreamProcessing, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_cognito_user_pool":             {model.IdentityProvider, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_mq_broker":                     {model.MessageQueue, model.Process, model.Virtual, "aws", model.BINARY_encrypted, false},
+	"aws_msk_cluster":                   {model.MessageQueue, model.Process, model.Virtual, "aws", model.BINARY_encrypted, false},
+	"aws_wafv2_web_acl":                 {model.WAF, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_efs_file_system":               {model.FileServer, model.Datastore, model.Serverless, "aws", model.NFS, true},
+	"aws_ebs_volume":                    {model.BlockStorage, model.Datastore, model.Virtual, "aws:ebs", model.LocalFileAccess, false},
+	"aws_redshift_cluster":              {model.DataLake, model.Datastore, model.Virtual, "aws", model.SQL_access_protocol_encrypted, false},
+	"aws_codebuild_project":             {model.BuildPipeline, model.Process, model.Container, "aws", model.HTTPS, true},
+	"aws_ecr_repository":                {model.ArtifactRegistry, model.Datastore, model.Serverless, "aws", model.HTTPS, true},
+	"aws_sfn_state_machine":             {model.BatchProcessing, model.Process, model.Serverless, "aws", model.HTTPS, true},
+	"aws_elasticache_replication_group": {model.Database, model.Datastore, model.Virtual, "aws", model.NoSQL_access_protocol, false},
+	// Azure
+	"azurerm_linux_virtual_machine":           {model.ApplicationServer, model.Process, model.Virtual, "azure", model.HTTPS, false},
+	"azurerm_windows_virtual_machine":         {model.ApplicationServer, model.Process, model.Virtual, "azure", model.HTTPS, false},
+	"azurerm_virtual_machine":                 {model.ApplicationServer, model.Process, model.Virtual, "azure", model.HTTPS, false},
+	"azurerm_linux_virtual_machine_scale_set": {model.ApplicationServer, model.Process, model.Virtual, "azure", model.HTTPS, false},
+	"azurerm_lb":                         {model.LoadBalancer, model.Process, model.Virtual, "azure", model.HTTPS, true},
+	"azurerm_application_gateway":        {model.ReverseProxy, model.Process, model.Virtual, "azure", model.HTTPS, true},
+	"azurerm_mssql_server":               {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_sql_server":                 {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_postgresql_server":          {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_postgresql_flexible_server": {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_mysql_server":               {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_mysql_flexible_server":      {model.Database, model.Datastore, model.Serverless, "azure", model.SQL_access_protocol_encrypted, true},
+	"azurerm_cosmosdb_account":           {model.Database, model.Datastore, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_redis_cache":                {model.Database, model.Datastore, model.Serverless, "azure", model.NoSQL_access_protocol_encrypted, true},
+	"azurerm_storage_account":            {model.FileServer, model.Datastore, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_function_app":               {model.Function, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_linux_function_app":         {model.Function, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_windows_function_app":       {model.Function, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_app_service":                {model.WebApplication, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_linux_web_app":              {model.WebApplication, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_windows_web_app":            {model.WebApplication, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_kubernetes_cluster":         {model.ContainerPlatform, model.Process, model.Virtual, "azure", model.HTTPS, true},
+	"azurerm_key_vault":                  {model.Vault, model.Datastore, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_servicebus_namespace":       {model.MessageQueue, model.Process, model.Serverless, "azure", model.BINARY_encrypted, true},
+	"azurerm_eventhub_namespace":         {model.StreamProcessing, model.Process, model.Serverless, "azure", model.BINARY_encrypted, true},
+	"azurerm_api_management":             {model.Gateway, model.Process, model.Serverless, "azure", model.HTTPS, true},
+	"azurerm_container_group":            {model.WebServiceREST, model.Process, model.Container, "azure", model.HTTPS, false},
+	// GCP
+	"google_compute_instance":               {model.ApplicationServer, model.Process, model.Virtual, "gcp", model.HTTPS, false},
+	"google_compute_instance_group_manager": {model.ApplicationServer, model.Process, model.Virtual, "gcp", model.HTTPS, false},
+	"google_compute_forwarding_rule":        {model.LoadBalancer, model.Process, model.Virtual, "gcp", model.HTTPS, true},
+	"google_compute_global_forwarding_rule": {model.LoadBalancer, model.Process, model.Virtual, "gcp", model.HTTPS, true},
+	"google_sql_database_instance":          {model.Database, model.Datastore, model.Serverless, "gcp", model.SQL_access_protocol_encrypted, true},
+	"google_spanner_instance":               {model.Database, model.Datastore, model.Serverless, "gcp", model.HTTPS, true},
+	"google_bigtable_instance":              {model.Database, model.Datastore, model.Serverless, "gcp", model.HTTPS, true},
+	"google_redis_instance":                 {model.Database, model.Datastore, model.Serverless, "gcp", model.NoSQL_access_protocol, true},
+	"google_bigquery_dataset":               {model.DataLake, model.Datastore, model.Serverless, "gcp", model.HTTPS, true},
+	"google_storage_bucket":                 {model.FileServer, model.Datastore, model.Serverless, "gcp", model.HTTPS, true},
+	"google_cloudfunctions_function":        {model.Function, model.Process, model.Serverless, "gcp", model.HTTPS, true},
+	"google_cloudfunctions2_function":       {model.Function, model.Process, model.Serverless, "gcp", model.HTTPS, true},
+	"google_cloud_run_service":              {model.WebServiceREST, model.Process, model.Container, "gcp", model.HTTPS, true},
+	"google_cloud_run_v2_service":           {model.WebServiceREST, model.Process, model.Container, "gcp", model.HTTPS, true},
+	"google_container_cluster":              {model.ContainerPlatform, model.Process, model.Virtual, "gcp", model.HTTPS, true},
+	"google_pubsub_topic":                   {model.MessageQueue, model.Process, model.Serverless, "gcp", model.HTTPS, true},
+	"google_secret_manager_secret":          {model.Vault, model.Datastore, model.Serverless, "gcp", model.HTTPS, true},
+	"google_api_gateway_gateway":            {model.Gateway, model.Process, model.Serverless, "gcp", model.HTTPS, true},
+}
+
+// the resource types imported as trust boundaries (subnets being nested into their networks)
+var networkTypes = map[string]string{"aws_vpc": "aws:vpc", "azurerm_virtual_network": "azure", "google_compute_network": "gcp"}
+var subnetTypes = map[string]string{"aws_subnet": "aws:vpc", "azurerm_subnet": "azure", "google_compute_subnetwork": "gcp"}
+
+// the resource types granting the technical assets they are attached to a public IP
+var publicIPTypes = []string{"aws_eip", "azurerm_public_ip"}
+
+// the attributes holding the environment (like app settings) of a technical asset, whose references become links
+var environmentAttributes = []string{"environment", "app_settings", "environment_variables", "service_config", "template", "container"}
+
+type showOutput struct {
+	Values        *values `json:"values"`         // of a state
+	PlannedValues *values `json:"planned_values"` // of a plan
+	Configuration struct {
+		RootModule configModule `json:"root_module"`
+	} `json:"configuration"`
+}
+
+type values struct {
+	RootModule valuesModule `json:"root_module"`
+}
+
+type valuesModule struct {
+	Resources    []*terraformResource `json:"resources"`
+	ChildModules []valuesModule       `json:"child_modules"`
+}
+
+type configModule struct {
+	Resources []struct {
+		Address     string                 `json:"address"`
+		Expressions map[string]interface{} `json:"expressions"`
+	} `json:"resources"`
+	ModuleCalls map[string]struct {
+		Module configModule `json:"module"`
+	} `json:"module_calls"`
+}
+
+type terraformResource struct {
+	Address string                 `json:"address"`
+	Mode    string                 `json:"mode"`
+	Type    string                 `json:"type"`
+	Name    string                 `json:"name"`
+	Values  map[string]interface{} `json:"values"`
+
+	baseAddress string                          // without the index of count and for_each
+	references  map[string][]*terraformResource // by the top-level attribute referencing them
+	title, id   string                          // when imported as technical asset or trust boundary
+}
+
+type topology struct {
+	resources      []*terraformResource
+	byBaseAddress  map[string][]*terraformResource
+	byIdentifier   map[string]*terraformResource // by id, arn, and self link
+	byName         map[string]*terraformResource // by name, url, and endpoint (only used for environment references)
+	modelInput     *model.ModelInput
+	assetTitleById map[string]string
+}
+
+// Import reads the JSON file written by "terraform show -json" for a state or a plan
+func Import(filename string) (model.ModelInput, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return model.ModelInput{}, err
+	}
+	var output showOutput
+	if err = json.Unmarshal(content, &output); err != nil {
+		return model.ModelInput{}, fmt.Errorf("unable to parse Terraform JSON %s: %v", filename, err)
+	}
+	resourceValues := output.Values
+	if output.PlannedValues != nil {
+		resourceValues = output.PlannedValues
+	}
+	if resourceValues == nil {
+		return model.ModelInput{}, errors.New("neither values nor planned_values found (expecting the output of \"terraform show -json\"): " + filename)
+	}
+	what := &topology{
+		byBaseAddress:  make(map[string][]*terraformResource),
+		byIdentifier:   make(map[string]*terraformResource),
+		byName:         make(map[string]*terraformResource),
+		assetTitleById: make(map[string]string),
+	}
+	what.collect(resourceValues.RootModule)
+	if len(what.resources) == 0 {
+		return model.ModelInput{}, errors.New("no managed resources found in: " + filename)
+	}
+	what.resolveReferences(output.Configuration.RootModule, "")
+	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	return what.toModelInput(name), nil
+}
+
+func (what *topology) collect(module valuesModule) {
+	for _, resource := range module.Resources {
+		if resource.Mode != "managed" {
+			continue // data sources exist already elsewhere
+		}
+		resource.baseAddress = resource.Address
+		if index := strings.Index(resource.Address, "["); index > 0 && strings.HasSuffix(resource.Address, "]") {
+			resource.baseAddress = resource.Address[:index]
+		}
+		resource.references = make(map[string][]*terraformResource)
+		what.resources = append(what.resources, resource)
+		what.byBaseAddress[resource.baseAddress] = append(what.byBaseAddress[resource.baseAddress], resource)
+		for _, attribute := range []string{"id", "arn", "self_link", "invoke_arn"} {
+			if value, ok := resource.Values[attribute].(string); ok && len(value) > 0 {
+				what.byIdentifier[value] = resource
+			}
+		}
+		for _, attribute := range []string{"name", "url", "endpoint", "address", "bucket"} {
+			if value, ok := resource.Values[attribute].(string); ok && len(value) > 0 {
+				what.byName[value] = resource
+			}
+		}
+	}
+	for _, child := range module.ChildModules {
+		what.collect(child)
+	}
+}
+
+// resolveReferences resolves the references between the resources by the identifiers in their values (of a state) and
+// by the references in the expressions of their configuration (of a plan, where the identifiers are still unknown)
+func (what *topology) resolveReferences(configuration configModule, modulePrefix string) {
+	if len(modulePrefix) == 0 {
+		for _, resource := range what.resources {
+			for attribute, value := range resource.Values {
+				for _, text := range stringsOf(value) {
+					if referenced, ok := what.byIdentifier[text]; ok && referenced != resource {
+						resource.addReference(attribute, referenced)
+					}
+				}
+			}
+		}
+	}
+	for _, configured := range configuration.Resources {
+		for attribute, expression := range configured.Expressions {
+			for _, reference := range referencesOf(expression) {
+				for _, referenced := range what.resourcesOfReference(modulePrefix + reference) {
+					for _, resource := range what.byBaseAddress[modulePrefix+configured.Address] {
+						if referenced != resource {
+							resource.addReference(attribute, referenced)
+						}
+					}
+				}
+			}
+		}
+	}
+	for name, call := range configuration.ModuleCalls {
+		what.resolveReferences(call.Module, modulePrefix+"module."+name+".")
+	}
+}
+
+// resourcesOfReference returns the resources of a reference (like "aws_vpc.main.id"), being a prefix of it
+func (what *topology) resourcesOfReference(reference string) []*terraformResource {
+	for address := reference; len(address) > 0; {
+		if index := strings.Index(address, "["); index > 0 {
+			address = address[:index]
+		}
+		if resources, ok := what.byBaseAddress[address]; ok {
+			return resources
+		}
+		dot := strings.LastIndex(address, ".")
+		if dot < 0 {
+			break
+		}
+		address = address[:dot]
+	}
+	return nil
+}
+
+func (what *terraformResource) addReference(attribute string, referenced *terraformResource) {
+	for _, existing := range what.references[attribute] {
+		if existing == referenced {
+			return
+		}
+	}
+	what.references[attribute] = append(what.references[attribute], referenced)
+}
+
+// allReferences returns the resources referenced by any attribute (sorted by address)
+func (what *terraformResource) allReferences() []*terraformResource {
+	result := make([]*terraformResource, 0)
+	for _, referenced := range what.references {
+		for _, resource := range referenced {
+			if !containsResource(result, resource) {
+				result = append(result, resource)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Address < result[j].Address })
+	return result
+}
+
+func (what *topology) toModelInput(source string) model.ModelInput {
+	modelInput := importers.NewModelInput("Terraform Import of "+source,
+		"Imported from the Terraform resources of "+source+": Cloud resources as technical assets, networks and subnets as "+
+			"trust boundaries, and the traffic allowed by security groups, firewalls, and load balancers as communication links.")
+	what.modelInput = &modelInput
+	usedTitles := make(map[string]bool)
+	sort.Slice(what.resources, func(i, j int) bool { return what.resources[i].Address < what.resources[j].Address })
+
+	// resources as technical assets
+	for _, resource := range what.resources {
+		mapping, ok := assetMappings[resource.Type]
+		if !ok {
+			continue
+		}
+		resource.title = importers.UniqueTitle(titleOf(resource), usedTitles)
+		usedTitles[resource.title] = true
+		resource.id = model.MakeID(resource.title)
+		what.assetTitleById[resource.id] = resource.title
+		technicalAsset := importers.NewTechnicalAsset(resource.id, "Terraform resource "+resource.Address+" ("+resource.Type+")")
+		technicalAsset.Technology, technicalAsset.Type, technicalAsset.Machine = mapping.technology.String(), mapping.assetType.String(), mapping.machine.String()
+		technicalAsset.Custom_developed_parts = mapping.technology == model.Function || mapping.technology == model.WebServiceREST ||
+			mapping.technology == model.WebApplication || mapping.technology == model.ApplicationServer
+		technicalAsset.Redundant = mapping.managed || isTrue(resource.Values["multi_az"]) || numberOf(resource.Values["max_size"]) > 1 ||
+			numberOf(resource.Values["desired_count"]) > 1 || numberOf(resource.Values["target_size"]) > 1
+		if mapping.managed || isTrue(resource.Values["storage_encrypted"]) || isTrue(resource.Values["encrypted"]) {
+			technicalAsset.Encryption = model.Transparent.String()
+		}
+		technicalAsset.Internet = what.isPublic(resource)
+		if owner, ok := cloudTagsOf(resource)["Owner"]; ok {
+			technicalAsset.Owner = owner
+		} else if owner, ok := cloudTagsOf(resource)["owner"]; ok {
+			technicalAsset.Owner = owner
+		}
+		importers.AddTags(&modelInput, &technicalAsset.Tags, providerOf(resource.Type), mapping.tag)
+		modelInput.Technical_assets[resource.title] = technicalAsset
+	}
+
+	what.addTrustBoundaries(usedTitles)
+	what.addSecurityGroupLinks()
+	what.addFirewallLinks()
+	what.addLoadBalancerLinks()
+	what.addReferenceLinks()
+	return modelInput
+}
+
+// addTrustBoundaries adds the networks (with their subnets nested) as trust boundaries, containing the technical assets
+// placed into them (directly or via an intermediate resource like a network interface or subnet group)
+func (what *topology) addTrustBoundaries(usedTitles map[string]bool) {
+	boundaryTitles := make(map[*terraformResource]string)
+	for _, resource := range what.resources {
+		tag, isNetwork := networkTypes[resource.Type]
+		if subnetTag, isSubnet := subnetTypes[resource.Type]; isSubnet {
+			tag = subnetTag
+		} else if !isNetwork {
+			continue
+		}
+		title := importers.UniqueTitle(titleOf(resource), usedTitles)
+		usedTitles[title] = true
+		boundaryTitles[resource] = title
+		resource.id = model.MakeID(title)
+		trustBoundary := model.InputTrustBoundary{
+			ID:                      resource.id,
+			Description:             "Terraform resource " + resource.Address + " (" + resource.Type + ")",
+			Type:                    model.NetworkCloudProvider.String(),
+			Tags:                    make([]string, 0),
+			Technical_assets_inside: make([]string, 0),
+			Trust_boundaries_nested: make([]string, 0),
+		}
+		importers.AddTags(what.modelInput, &trustBoundary.Tags, providerOf(resource.Type), tag)
+		what.modelInput.Trust_boundaries[title] = trustBoundary
+	}
+	// the subnets nested into their networks
+	for subnet, title := range boundaryTitles {
+		if _, isSubnet := subnetTypes[subnet.Type]; !isSubnet {
+			continue
+		}
+		candidates := subnet.allReferences()
+		if name, ok := subnet.Values["virtual_network_name"].(string); ok && what.byName[name] != nil { // referenced by name in Azure
+			candidates = append(candidates, what.byName[name])
+		}
+		for _, referenced := range candidates {
+			if _, isNetwork := networkTypes[referenced.Type]; isNetwork {
+				network := what.modelInput.Trust_boundaries[boundaryTitles[referenced]]
+				network.Trust_boundaries_nested = append(network.Trust_boundaries_nested, what.modelInput.Trust_boundaries[title].ID)
+				sort.Strings(network.Trust_boundaries_nested)
+				what.modelInput.Trust_boundaries[boundaryTitles[referenced]] = network
+				break
+			}
+		}
+	}
+	// the technical assets placed into the most specific boundary (a subnet rather than a network)
+	for _, resource := range what.resources {
+		if len(resource.title) == 0 {
+			continue
+		}
+		var placement *terraformResource
+		for _, referenced := range what.referencesViaIntermediate(resource) {
+			if _, isSubnet := subnetTypes[referenced.Type]; isSubnet {
+				placement = referenced
+				break
+			}
+			if _, isNetwork := networkTypes[referenced.Type]; isNetwork && placement == nil {
+				placement = referenced
+			}
+		}
+		if placement != nil {
+			trustBoundary := what.modelInput.Trust_boundaries[boundaryTitles[placement]]
+			trustBoundary.Technical_assets_inside = append(trustBoundary.Technical_assets_inside, resource.id)
+			what.modelInput.Trust_boundaries[boundaryTitles[placement]] = trustBoundary
+		}
+	}
+}
+
+// referencesViaIntermediate returns the resources referenced directly, followed by the ones referenced via an
+// intermediate resource not being imported itself (like a network interface, subnet group, or security group)
+func (what *topology) referencesViaIntermediate(resource *terraformResource) []*terraformResource {
+	result := resource.allReferences()
+	for _, referenced := range resource.allReferences() {
+		if len(referenced.title) > 0 {
+			continue
+		}
+		if _, isSubnet := subnetTypes[referenced.Type]; isSubnet {
+			continue
+		}
+		for _, indirect := range referenced.allReferences() {
+			if !containsResource(result, indirect) {
+				result = append(result, indirect)
+			}
+		}
+	}
+	return result
+}
+
+// isPublic tells whether the resource is accessible from the internet (by its attributes, a public IP, or an ingress
+// rule allowing any address)
+func (what *topology) isPublic(resource *terraformResource) bool {
+	switch resource.Type {
+	case "aws_api_gateway_rest_api", "aws_apigatewayv2_api", "aws_cloudfront_distribution", "azurerm_application_gateway",
+		"google_api_gateway_gateway":
+		return true
+	case "aws_lb", "aws_alb", "aws_elb":
+		return !isTrue(resource.Values["internal"])
+	case "google_compute_forwarding_rule", "google_compute_global_forwarding_rule":
+		scheme, _ := resource.Values["load_balancing_scheme"].(string)
+		return len(scheme) == 0 || strings.HasPrefix(scheme, "EXTERNAL")
+	case "aws_s3_bucket":
+		acl, _ := resource.Values["acl"].(string)
+		return strings.HasPrefix(acl, "public-")
+	case "google_compute_instance":
+		for _, networkInterface := range listOf(resource.Values["network_interface"]) {
+			if len(listOf(networkInterface["access_config"])) > 0 {
+				return true
+			}
+		}
+	}
+	if isTrue(resource.Values["associate_public_ip_address"]) || isTrue(resource.Values["publicly_accessible"]) {
+		return true
+	}
+	if publicIP, ok := resource.Values["public_ip"].(string); ok && len(publicIP) > 0 {
+		return true
+	}
+	for _, referenced := range what.referencesViaIntermediate(resource) {
+		if model.Contains(publicIPTypes, referenced.Type) {
+			return true
+		}
+	}
+	for _, other := range what.resources {
+		if model.Contains(publicIPTypes, other.Type) && containsResource(other.allReferences(), resource) {
+			return true
+		}
+	}
+	for _, securityGroup := range what.securityGroupsOf(resource) {
+		for _, rule := range what.ingressRulesOf(securityGroup) {
+			if rule.fromAnywhere {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type ingressRule struct {
+	port         int
+	sources      []*terraformResource // security groups
+	fromAnywhere bool
+}
+
+// ingressRulesOf returns the ingress rules of an AWS security group, being inline or separate resources
+func (what *topology) ingressRulesOf(securityGroup *terraformResource) []ingressRule {
+	result := make([]ingressRule, 0)
+	inline := listOf(securityGroup.Values["ingress"])
+	for _, values := range inline {
+		rule := ingressRule{port: int(numberOf(values["from_port"])), fromAnywhere: anyFromAnywhere(values["cidr_blocks"], values["ipv6_cidr_blocks"])}
+		for _, id := range stringsOf(values["security_groups"]) {
+			if source, ok := what.byIdentifier[id]; ok {
+				rule.sources = append(rule.sources, source)
+			}
+		}
+		if isTrue(values["self"]) {
+			rule.sources = append(rule.sources, securityGroup)
+		}
+		result = append(result, rule)
+	}
+	if len(inline) > 0 && len(securityGroup.references["ingress"]) > 0 {
+		// the security groups of a plan being only known by the references in the configuration
+		result[0].sources = appendResources(result[0].sources, securityGroup.references["ingress"]...)
+	}
+	for _, other := range what.resources {
+		switch other.Type {
+		case "aws_security_group_rule":
+			if ruleType, _ := other.Values["type"].(string); ruleType != "ingress" {
+				continue
+			}
+		case "aws_vpc_security_group_ingress_rule":
+		default:
+			continue
+		}
+		if !containsResource(other.references["security_group_id"], securityGroup) {
+			continue
+		}
+		rule := ingressRule{port: int(numberOf(other.Values["from_port"])),
+			fromAnywhere: anyFromAnywhere(other.Values["cidr_blocks"], other.Values["ipv6_cidr_blocks"], other.Values["cidr_ipv4"], other.Values["cidr_ipv6"])}
+		rule.sources = appendResources(rule.sources, other.references["source_security_group_id"]...)
+		rule.sources = appendResources(rule.sources, other.references["referenced_security_group_id"]...)
+		result = append(result, rule)
+	}
+	return result
+}
+
+// securityGroupsOf returns the AWS security groups the technical asset is attached to (directly or via an intermediate
+// like a network interface or launch template, but not via other security groups referenced by their rules)
+func (what *topology) securityGroupsOf(resource *terraformResource) []*terraformResource {
+	result := make([]*terraformResource, 0)
+	if len(resource.title) == 0 {
+		return result
+	}
+	for _, referenced := range resource.allReferences() {
+		if referenced.Type == "aws_security_group" {
+			result = appendResources(result, referenced)
+		} else if len(referenced.title) == 0 {
+			for _, indirect := range referenced.allReferences() {
+				if indirect.Type == "aws_security_group" {
+					result = appendResources(result, indirect)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// addSecurityGroupLinks links the technical assets of the source security groups to the ones of the security groups
+// allowing ingress from them
+func (what *topology) addSecurityGroupLinks() {
+	members := make(map[*terraformResource][]*terraformResource)
+	for _, resource := range what.resources {
+		for _, securityGroup := range what.securityGroupsOf(resource) {
+			members[securityGroup] = append(members[securityGroup], resource)
+		}
+	}
+	for _, securityGroup := range what.resources {
+		if len(members[securityGroup]) == 0 {
+			continue
+		}
+		for _, rule := range what.ingressRulesOf(securityGroup) {
+			for _, sourceGroup := range rule.sources {
+				for _, source := range members[sourceGroup] {
+					for _, target := range members[securityGroup] {
+						what.addLink(source, target, "Allowed by security group "+securityGroup.Address, what.protocolOf(target, rule.port))
+					}
+				}
+			}
+		}
+	}
+}
+
+// addFirewallLinks links the GCP instances allowed by firewall rules (via source and target tags) and marks the targets
+// of rules allowing any address as accessible from the internet
+func (what *topology) addFirewallLinks() {
+	for _, firewall := range what.resources {
+		if firewall.Type != "google_compute_firewall" {
+			continue
+		}
+		if direction, _ := firewall.Values["direction"].(string); direction == "EGRESS" {
+			continue
+		}
+		port := 0
+		for _, allow := range listOf(firewall.Values["allow"]) {
+			if ports := stringsOf(allow["ports"]); len(ports) > 0 {
+				port, _ = strconv.Atoi(strings.Split(ports[0], "-")[0])
+				break
+			}
+		}
+		targetTags := stringsOf(firewall.Values["target_tags"])
+		sourceTags := stringsOf(firewall.Values["source_tags"])
+		for _, target := range what.resources {
+			if target.Type != "google_compute_instance" || (len(targetTags) > 0 && !anyContained(stringsOf(target.Values["tags"]), targetTags)) {
+				continue
+			}
+			if anyFromAnywhere(firewall.Values["source_ranges"]) {
+				technicalAsset := what.modelInput.Technical_assets[target.title]
+				technicalAsset.Internet = true
+				what.modelInput.Technical_assets[target.title] = technicalAsset
+			}
+			for _, source := range what.resources {
+				if source.Type == "google_compute_instance" && len(sourceTags) > 0 && anyContained(stringsOf(source.Values["tags"]), sourceTags) {
+					what.addLink(source, target, "Allowed by firewall "+firewall.Address, what.protocolOf(target, port))
+				}
+			}
+		}
+	}
+}
+
+// addLoadBalancerLinks links the AWS load balancers to the technical assets registered in the target groups of their
+// listeners (directly or via target group attachments)
+func (what *topology) addLoadBalancerLinks() {
+	for _, listener := range what.resources {
+		if listener.Type != "aws_lb_listener" && listener.Type != "aws_alb_listener" {
+			continue
+		}
+		for _, loadBalancer := range listener.references["load_balancer_arn"] {
+			targetGroups := listener.references["default_action"]
+			for _, rule := range what.resources {
+				if (rule.Type == "aws_lb_listener_rule" || rule.Type == "aws_alb_listener_rule") && containsResource(rule.references["listener_arn"], listener) {
+					targetGroups = appendResources(targetGroups, rule.references["action"]...)
+				}
+			}
+			for _, targetGroup := range targetGroups {
+				protocol := model.HTTP
+				if targetProtocol, _ := targetGroup.Values["protocol"].(string); targetProtocol == "HTTPS" || targetProtocol == "TLS" {
+					protocol = model.HTTPS
+				}
+				for _, target := range what.resources {
+					if len(target.title) > 0 && containsResource(target.allReferences(), targetGroup) {
+						what.addLink(loadBalancer, target, "Forwarded via target group "+targetGroup.Address, protocol)
+					} else if target.Type == "aws_lb_target_group_attachment" && containsResource(target.references["target_group_arn"], targetGroup) {
+						for _, attached := range target.references["target_id"] {
+							what.addLink(loadBalancer, attached, "Forwarded via target group "+targetGroup.Address, protocol)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// addReferenceLinks links the technical assets referencing others in their environment (like the table name of a
+// function), and the ones connected by integrations (like an API gateway invoking a function or a CDN its origin)
+func (what *topology) addReferenceLinks() {
+	for _, source := range what.resources {
+		switch source.Type {
+		case "aws_api_gateway_integration", "aws_apigatewayv2_integration":
+			for _, api := range append(source.references["rest_api_id"], source.references["api_id"]...) {
+				for _, target := range append(source.references["uri"], source.references["integration_uri"]...) {
+					what.addLink(api, target, "Integrated via "+source.Address, model.HTTPS)
+				}
+			}
+		case "aws_lambda_event_source_mapping":
+			for _, function := range source.references["function_name"] {
+				for _, target := range source.references["event_source_arn"] {
+					what.addLink(function, target, "Polled via "+source.Address, model.HTTPS)
+				}
+			}
+		case "aws_cloudfront_distribution":
+			for _, target := range source.references["origin"] {
+				what.addLink(source, target, "Origin of the distribution", model.HTTPS)
+			}
+		}
+		if len(source.title) == 0 {
+			continue
+		}
+		for _, attribute := range environmentAttributes {
+			targets := source.references[attribute]
+			for _, text := range stringsOf(source.Values[attribute]) {
+				if target, ok := what.byName[text]; ok {
+					targets = appendResources(targets, target)
+				}
+			}
+			for _, target := range targets {
+				what.addLink(source, target, "Referenced in the "+strings.ReplaceAll(attribute, "_", " "), what.protocolOf(target, 0))
+			}
+		}
+	}
+}
+
+// addLink adds a communication link between technical assets unless the source is already linked to the target
+func (what *topology) addLink(source *terraformResource, target *terraformResource, description string, protocol model.Protocol) {
+	if len(source.title) == 0 || len(target.title) == 0 || source == target {
+		return
+	}
+	technicalAsset := what.modelInput.Technical_assets[source.title]
+	for _, link := range technicalAsset.Communication_links {
+		if link.Target == target.id {
+			return
+		}
+	}
+	link := importers.NewCommunicationLink(target.id, description, protocol)
+	if mapping := assetMappings[source.Type]; mapping.managed {
+		link.Authentication = model.Token.String() // as managed services are accessed via the cloud provider's IAM
+	}
+	technicalAsset.Communication_links[target.title+" Traffic"] = link
+	what.modelInput.Technical_assets[source.title] = technicalAsset
+}
+
+// protocolOf guesses the protocol by the port allowed (if well-known) or else by the target's technology
+func (what *topology) protocolOf(target *terraformResource, port int) model.Protocol {
+	if protocol := importers.ProtocolOfPort(port); protocol != model.UnknownProtocol {
+		return protocol
+	}
+	if mapping, ok := assetMappings[target.Type]; ok {
+		return mapping.protocol
+	}
+	return model.UnknownProtocol
+}
+
+// titleOf returns the name of the resource (by its name tag or name attribute), or else its address
+func titleOf(resource *terraformResource) string {
+	if name, ok := cloudTagsOf(resource)["Name"]; ok && len(name) > 0 {
+		return name
+	}
+	for _, attribute := range []string{"name", "bucket"} {
+		if name, ok := resource.Values[attribute].(string); ok && len(name) > 0 {
+			return name
+		}
+	}
+	return strings.Replace(strings.ReplaceAll(resource.Address, "module.", ""), resource.Type+".", "", 1)
+}
+
+func cloudTagsOf(resource *terraformResource) map[string]string {
+	result := make(map[string]string)
+	for _, attribute := range []string{"tags", "labels"} {
+		if tags, ok := resource.Values[attribute].(map[string]interface{}); ok {
+			for key, value := range tags {
+				if text, ok := value.(string); ok {
+					result[key] = text
+				}
+			}
+		}
+	}
+	return result
+}
+
+func providerOf(resourceType string) string {
+	switch {
+	case strings.HasPrefix(resourceType, "aws_"):
+		return "aws"
+	case strings.HasPrefix(resourceType, "azurerm_"):
+		return "azure"
+	case strings.HasPrefix(resourceType, "google_"):
+		return "gcp"
+	case strings.HasPrefix(resourceType, "oci_"):
+		return "ocp"
+	}
+	return "terraform"
+}
+
+// stringsOf returns all strings of a value (recursively within lists and objects)
+func stringsOf(value interface{}) []string {
+	result := make([]string, 0)
+	switch typed := value.(type) {
+	case string:
+		result = append(result, typed)
+	case []interface{}:
+		for _, item := range typed {
+			result = append(result, stringsOf(item)...)
+		}
+	case map[string]interface{}:
+		for _, item := range typed {
+			result = append(result, stringsOf(item)...)
+		}
+	}
+	return result
+}
+
+// referencesOf returns all references of a configuration expression (recursively within nested blocks)
+func referencesOf(expression interface{}) []string {
+	result := make([]string, 0)
+	switch typed := expression.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			result = append(result, referencesOf(item)...)
+		}
+	case map[string]interface{}:
+		for key, item := range typed {
+			if key == "references" {
+				result = append(result, stringsOf(item)...)
+			} else {
+				result = append(result, referencesOf(item)...)
+			}
+		}
+	}
+	return result
+}
+
+// listOf returns the objects of a nested block
+func listOf(value interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	if items, ok := value.([]interface{}); ok {
+		for _, item := range items {
+			if object, ok := item.(map[string]interface{}); ok {
+				result = append(result, object)
+			}
+		}
+	}
+	return result
+}
+
+func isTrue(value interface{}) bool {
+	flag, ok := value.(bool)
+	return ok && flag
+}
+
+func numberOf(value interface{}) float64 {
+	number, _ := value.(float64)
+	return number
+}
+
+func anyFromAnywhere(values ...interface{}) bool {
+	for _, value := range values {
+		for _, cidr := range stringsOf(value) {
+			if cidr == "0.0.0.0/0" || cidr == "::/0" || cidr == "*" || cidr == "Internet" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyContained(values []string, candidates []string) bool {
+	for _, value := range values {
+		if model.Contains(candidates, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsResource(resources []*terraformResource, resource *terraformResource) bool {
+	for _, candidate := range resources {
+		if candidate == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func appendResources(resources []*terraformResource, toAppend ...*terraformResource) []*terraformResource {
+	for _, resource := range toAppend {
+		if !containsResource(resources, resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
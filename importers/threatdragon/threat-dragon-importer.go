@@ -0,0 +1,432 @@
+// Package threatdragon imports the processes, stores and actors (as technical assets), the flows (as communication
+// links) and the trust boundary boxes (as trust boundaries) of all diagrams of an OWASP Threat Dragon model into a
+// starting model
+package threatdragon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+)
+
+const (
+	processType     = "tm.Process"
+	storeType       = "tm.Store"
+	actorType       = "tm.Actor"
+	flowType        = "tm.Flow"
+	boundaryBoxType = "tm.BoundaryBox"
+)
+
+type threatModel struct {
+	Summary struct {
+		Title       string `json:"title"`
+		Owner       string `json:"owner"`
+		Description string `json:"description"`
+	} `json:"summary"`
+	Detail struct {
+		Diagrams []diagram `json:"diagrams"`
+	} `json:"detail"`
+}
+
+type diagram struct {
+	Title       string   `json:"title"`
+	Cells       []cell   `json:"cells"`
+	DiagramJson struct { // of Threat Dragon 1.x models
+		Cells []cell `json:"cells"`
+	} `json:"diagramJson"`
+}
+
+// cell holds the properties within its data (Threat Dragon 2.x) or else directly (Threat Dragon 1.x)
+type cell struct {
+	elementProperties
+	ID       string      `json:"id"`
+	Shape    string      `json:"shape"`
+	Position *point      `json:"position"`
+	Size     *dimensions `json:"size"`
+	Source   endpoint    `json:"source"`
+	Target   endpoint    `json:"target"`
+	Attrs    struct {
+		Text       label `json:"text"`
+		HeaderText label `json:"headerText"`
+	} `json:"attrs"`
+	Labels []json.RawMessage  `json:"labels"` // plain strings (2.x) or label objects (1.x)
+	Data   *elementProperties `json:"data"`
+}
+
+type elementProperties struct {
+	Type                   string `json:"type"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	OutOfScope             bool   `json:"outOfScope"`
+	ReasonOutOfScope       string `json:"reasonOutOfScope"`
+	PrivilegeLevel         string `json:"privilegeLevel"`
+	IsWebApplication       bool   `json:"isWebApplication"`
+	HandlesCardPayment     bool   `json:"handlesCardPayment"`
+	ProvidesAuthentication bool   `json:"providesAuthentication"`
+	IsALog                 bool   `json:"isALog"`
+	IsEncrypted            bool   `json:"isEncrypted"`
+	StoresCredentials      bool   `json:"storesCredentials"`
+	Protocol               string `json:"protocol"`
+	IsPublicNetwork        bool   `json:"isPublicNetwork"`
+	IsBidirectional        bool   `json:"isBidirectional"`
+}
+
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type dimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type endpoint struct {
+	Cell string `json:"cell"` // 2.x
+	ID   string `json:"id"`   // 1.x
+}
+
+type label struct {
+	Text string `json:"text"`
+}
+
+// Import reads the Threat Dragon model (version 1.x or 2.x) given: elements having the same name in several diagrams
+// become the same technical asset, while the (not closed) trust boundary curves are skipped
+func Import(filename string) (model.ModelInput, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return model.ModelInput{}, err
+	}
+	var threatModel threatModel
+	if err = json.Unmarshal(content, &threatModel); err != nil {
+		return model.ModelInput{}, fmt.Errorf("unable to parse Threat Dragon model %s: %v", filename, err)
+	}
+	if len(threatModel.Detail.Diagrams) == 0 {
+		return model.ModelInput{}, errors.New("no diagrams found in the Threat Dragon model: " + filename)
+	}
+	return threatModel.toModelInput(), nil
+}
+
+func (what *threatModel) toModelInput() model.ModelInput {
+	title := what.Summary.Title
+	if len(title) == 0 {
+		title = "Threat Dragon Model"
+	}
+	description := "Imported from the Threat Dragon model " + title + ": The processes, stores and actors as technical " +
+		"assets, the flows as communication links, and the trust boundary boxes as trust boundaries."
+	if len(what.Summary.Description) > 0 {
+		description += " " + strings.TrimSpace(what.Summary.Description)
+	}
+	modelInput := importers.NewModelInput("Threat Dragon Import of "+title, description)
+	if len(what.Summary.Owner) > 0 {
+		modelInput.Author.Name = what.Summary.Owner
+	}
+
+	// the elements first (as the flows and boundaries refer to them), titled by their name unique across all diagrams
+	assetTitles := make(map[string]string) // by cell id
+	for _, diagram := range what.Detail.Diagrams {
+		for _, cell := range diagram.cells() {
+			properties := cell.properties()
+			switch properties.Type {
+			case processType, storeType, actorType:
+			default:
+				continue
+			}
+			assetTitle := cell.name()
+			if len(assetTitle) == 0 {
+				assetTitle = importers.UniqueTitle(strings.TrimPrefix(properties.Type, "tm."), titlesOf(modelInput.Technical_assets))
+			}
+			assetTitles[cell.ID] = assetTitle
+			if _, exists := modelInput.Technical_assets[assetTitle]; !exists {
+				modelInput.Technical_assets[assetTitle] = toTechnicalAsset(&modelInput, assetTitle, properties)
+			}
+		}
+	}
+
+	for _, diagram := range what.Detail.Diagrams {
+		for _, cell := range diagram.cells() {
+			properties := cell.properties()
+			if properties.Type != flowType {
+				continue
+			}
+			sourceTitle, sourceExists := assetTitles[cell.Source.cellId()]
+			targetTitle, targetExists := assetTitles[cell.Target.cellId()]
+			if !sourceExists || !targetExists { // like flows not (yet) connected to elements
+				continue
+			}
+			source := modelInput.Technical_assets[sourceTitle]
+			if properties.IsPublicNetwork {
+				source.Internet = true
+			}
+			linkTitle := cell.name()
+			if len(linkTitle) == 0 {
+				linkTitle = "Flow to " + targetTitle
+			}
+			linkTitle = importers.UniqueTitle(linkTitle, titlesOf(source.Communication_links))
+			description := properties.Description
+			if len(description) == 0 {
+				description = "Flow from " + sourceTitle + " to " + targetTitle
+			}
+			if properties.IsBidirectional {
+				description += " (bidirectional)"
+			}
+			if properties.OutOfScope {
+				description += " (out of scope in Threat Dragon" + reasonOf(properties) + ")"
+			}
+			link := importers.NewCommunicationLink(model.MakeID(targetTitle), description, protocolOf(properties))
+			importers.AddTags(&modelInput, &link.Tags, "threat-dragon")
+			source.Communication_links[linkTitle] = link
+			modelInput.Technical_assets[sourceTitle] = source
+		}
+	}
+
+	what.addTrustBoundaries(&modelInput, assetTitles)
+	return modelInput
+}
+
+func toTechnicalAsset(modelInput *model.ModelInput, title string, properties elementProperties) model.InputTechnicalAsset {
+	description := properties.Description
+	if len(description) == 0 {
+		description = title
+	}
+	if len(properties.PrivilegeLevel) > 0 {
+		description += " (privilege level: " + properties.PrivilegeLevel + ")"
+	}
+	asset := importers.NewTechnicalAsset(model.MakeID(title), description)
+	switch properties.Type {
+	case processType:
+		if properties.IsWebApplication {
+			asset.Technology = model.WebApplication.String()
+		}
+		if properties.HandlesCardPayment {
+			dataAsset := importers.NewDataAsset("card-payment-data", "Card payment data handled according to Threat Dragon", model.Business)
+			dataAsset.Confidentiality = model.StrictlyConfidential.String()
+			modelInput.Data_assets["Card Payment Data"] = dataAsset
+			asset.Data_assets_processed = append(asset.Data_assets_processed, dataAsset.ID)
+		}
+	case storeType:
+		asset.Type, asset.Technology, asset.Custom_developed_parts = model.Datastore.String(), model.Database.String(), false
+		if properties.IsALog {
+			asset.Description += " (a log)"
+		}
+		if properties.IsEncrypted {
+			asset.Encryption = model.Transparent.String()
+		}
+		if properties.StoresCredentials {
+			dataAsset := importers.NewDataAsset("credentials", "Credentials stored according to Threat Dragon", model.DevOps)
+			dataAsset.Confidentiality = model.StrictlyConfidential.String()
+			modelInput.Data_assets["Credentials"] = dataAsset
+			asset.Data_assets_stored = append(asset.Data_assets_stored, dataAsset.ID)
+		}
+	case actorType:
+		asset.Type, asset.Technology, asset.Custom_developed_parts = model.ExternalEntity.String(), model.ClientSystem.String(), false
+		asset.Machine = model.Physical.String()
+		asset.Used_as_client_by_human = true
+		if properties.ProvidesAuthentication {
+			asset.Technology, asset.Used_as_client_by_human = model.IdentityProvider.String(), false
+		}
+	}
+	if properties.OutOfScope {
+		asset.Out_of_scope, asset.Justification_out_of_scope = true, "Out of scope in Threat Dragon"+reasonOf(properties)
+	}
+	importers.AddTags(modelInput, &asset.Tags, "threat-dragon")
+	return asset
+}
+
+// addTrustBoundaries places each element into the innermost boundary box containing its center, and nests each box
+// into the innermost box containing it entirely (boxes of the same name in several diagrams become the same boundary)
+func (what *threatModel) addTrustBoundaries(modelInput *model.ModelInput, assetTitles map[string]string) {
+	placed := make(map[string]bool) // as an asset can only be inside one trust boundary
+	nested := make(map[string]bool)
+	for _, diagram := range what.Detail.Diagrams {
+		boxes := make([]cell, 0)
+		for _, cell := range diagram.cells() {
+			if cell.properties().Type == boundaryBoxType && cell.Position != nil && cell.Size != nil {
+				boxes = append(boxes, cell)
+			}
+		}
+		// the smaller boxes first, so that the first box containing something is the innermost one
+		sort.SliceStable(boxes, func(i, j int) bool {
+			return boxes[i].Size.Width*boxes[i].Size.Height < boxes[j].Size.Width*boxes[j].Size.Height
+		})
+		boundaryTitles := make(map[string]string) // by cell id
+		for _, box := range boxes {
+			boundaryTitle := box.name()
+			if len(boundaryTitle) == 0 {
+				boundaryTitle = importers.UniqueTitle("Trust Boundary", titlesOf(modelInput.Trust_boundaries))
+			}
+			boundaryTitles[box.ID] = boundaryTitle
+			if _, exists := modelInput.Trust_boundaries[boundaryTitle]; !exists {
+				description := box.properties().Description
+				if len(description) == 0 {
+					description = "Trust boundary " + boundaryTitle
+				}
+				boundary := model.InputTrustBoundary{
+					ID:                      model.MakeID(boundaryTitle),
+					Description:             description,
+					Type:                    model.NetworkOnPrem.String(),
+					Tags:                    make([]string, 0),
+					Technical_assets_inside: make([]string, 0),
+					Trust_boundaries_nested: make([]string, 0),
+				}
+				importers.AddTags(modelInput, &boundary.Tags, "threat-dragon")
+				modelInput.Trust_boundaries[boundaryTitle] = boundary
+			}
+		}
+		for _, cell := range diagram.cells() {
+			assetTitle, isAsset := assetTitles[cell.ID]
+			if !isAsset || cell.Position == nil || placed[assetTitle] {
+				continue
+			}
+			center := *cell.Position
+			if cell.Size != nil {
+				center.X, center.Y = center.X+cell.Size.Width/2, center.Y+cell.Size.Height/2
+			}
+			for _, box := range boxes {
+				if box.contains(center.X, center.Y) {
+					boundary := modelInput.Trust_boundaries[boundaryTitles[box.ID]]
+					boundary.Technical_assets_inside = append(boundary.Technical_assets_inside, model.MakeID(assetTitle))
+					modelInput.Trust_boundaries[boundaryTitles[box.ID]] = boundary
+					placed[assetTitle] = true
+					break
+				}
+			}
+		}
+		for i, inner := range boxes {
+			innerTitle := boundaryTitles[inner.ID]
+			if nested[innerTitle] {
+				continue
+			}
+			for _, outer := range boxes[i+1:] {
+				outerTitle := boundaryTitles[outer.ID]
+				if outerTitle != innerTitle && outer.contains(inner.Position.X, inner.Position.Y) &&
+					outer.contains(inner.Position.X+inner.Size.Width, inner.Position.Y+inner.Size.Height) {
+					boundary := modelInput.Trust_boundaries[outerTitle]
+					boundary.Trust_boundaries_nested = append(boundary.Trust_boundaries_nested, model.MakeID(innerTitle))
+					modelInput.Trust_boundaries[outerTitle] = boundary
+					nested[innerTitle] = true
+					break
+				}
+			}
+		}
+	}
+	for title, boundary := range modelInput.Trust_boundaries {
+		sort.Strings(boundary.Technical_assets_inside)
+		sort.Strings(boundary.Trust_boundaries_nested)
+		modelInput.Trust_boundaries[title] = boundary
+	}
+}
+
+// protocolOf matches the free text protocol of the flow against the protocols (preferring an encrypted variant when
+// the flow is encrypted), or else guesses it like from a URL scheme
+func protocolOf(properties elementProperties) model.Protocol {
+	name := strings.ToLower(strings.TrimSpace(properties.Protocol))
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '_' || r == '/' }), "-")
+	if len(name) == 0 {
+		return model.UnknownProtocol
+	}
+	candidates := []string{name}
+	if properties.IsEncrypted {
+		candidates = append([]string{name + "-encrypted"}, candidates...)
+		switch name {
+		case "http":
+			candidates = append([]string{"https"}, candidates...)
+		case "ws":
+			candidates = append([]string{"wss"}, candidates...)
+		}
+	}
+	for _, candidate := range candidates {
+		for _, protocol := range model.ProtocolValues() {
+			if protocol.String() == candidate {
+				return protocol.(model.Protocol)
+			}
+		}
+	}
+	return importers.ProtocolOfURL(name + "://" + name)
+}
+
+func reasonOf(properties elementProperties) string {
+	if len(properties.ReasonOutOfScope) > 0 {
+		return ": " + properties.ReasonOutOfScope
+	}
+	return ""
+}
+
+func (what diagram) cells() []cell {
+	if len(what.Cells) > 0 {
+		return what.Cells
+	}
+	return what.DiagramJson.Cells
+}
+
+func (what cell) properties() elementProperties {
+	if what.Data != nil {
+		return *what.Data
+	}
+	return what.elementProperties
+}
+
+// name returns the name of the cell, or else the text of its label
+func (what cell) name() string {
+	if name := strings.TrimSpace(what.properties().Name); len(name) > 0 {
+		return name
+	}
+	if text := strings.TrimSpace(what.Attrs.Text.Text); len(text) > 0 {
+		return text
+	}
+	if text := strings.TrimSpace(what.Attrs.HeaderText.Text); len(text) > 0 {
+		return text
+	}
+	for _, rawLabel := range what.Labels {
+		var text string
+		var object struct {
+			Attrs struct {
+				Text label `json:"text"`
+			} `json:"attrs"`
+		}
+		if json.Unmarshal(rawLabel, &text) == nil && len(strings.TrimSpace(text)) > 0 {
+			return strings.TrimSpace(text)
+		}
+		if json.Unmarshal(rawLabel, &object) == nil && len(strings.TrimSpace(object.Attrs.Text.Text)) > 0 {
+			return strings.TrimSpace(object.Attrs.Text.Text)
+		}
+	}
+	return ""
+}
+
+func (what cell) contains(x, y float64) bool {
+	return x >= what.Position.X && x <= what.Position.X+what.Size.Width &&
+		y >= what.Position.Y && y <= what.Position.Y+what.Size.Height
+}
+
+func (what endpoint) cellId() string {
+	if len(what.Cell) > 0 {
+		return what.Cell
+	}
+	return what.ID
+}
+
+func titlesOf(elements interface{}) map[string]bool {
+	result := make(map[string]bool)
+	switch elements := elements.(type) {
+	case map[string]model.InputTechnicalAsset:
+		for title := range elements {
+			result[title] = true
+		}
+	case map[string]model.InputCommunicationLink:
+		for title := range elements {
+			result[title] = true
+		}
+	case map[string]model.InputTrustBoundary:
+		for title := range elements {
+			result[title] = true
+		}
+	}
+	return result
+}
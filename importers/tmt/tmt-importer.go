@@ -0,0 +1,704 @@
+// Package tmt imports the stencils (as technical assets with their technology mapped), data flows (as communication
+// links) and border boundaries (as trust boundaries) of all diagrams of a Microsoft Threat Modeling Tool model (.tm7)
+// into a starting model, reporting the stencils it was unable to map
+package tmt
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/threagile/threagile/importers"
+	"github.com/threagile/threagile/model"
+)
+
+// the generic types all stencils (of any template) are derived from
+const (
+	genericProcess             = "GE.P"
+	genericDataStore           = "GE.DS"
+	genericExternalInteractor  = "GE.EI"
+	genericDataFlow            = "GE.DF"
+	genericBorderBoundary      = "GE.TB.B"
+	genericLineBoundary        = "GE.TB.L"
+	justificationOutOfScopeTMT = "Out of scope in the Threat Modeling Tool"
+)
+
+var nonAlphanumericRegEx = regexp.MustCompile(`[^a-z0-9]+`)
+
+// technologyMapping maps stencils whose (normalized) name or type id contains one of the parts to the technology
+type technologyMapping struct {
+	parts      []string
+	technology model.TechnicalAssetTechnology
+	machine    model.TechnicalAssetMachine
+}
+
+// the more specific ones first, as the first mapping matching is used (like "application gateway" before "gateway")
+var technologyMappings = []technologyMapping{
+	{[]string{"browser"}, model.Browser, model.Physical},
+	{[]string{"mobile", "windowsphone", "android", "iosapp"}, model.MobileApp, model.Physical},
+	{[]string{"thickclient", "desktop", "winapp", "windowsstore", "winrt", "windowsrt"}, model.Desktop, model.Physical},
+	{[]string{"iot", "device", "fieldgateway"}, model.IoTDevice, model.Physical},
+	{[]string{"authprovider", "identityprovider", "activedirectory", "azuread", "aad", "entraid", "adfs"}, model.IdentityProvider, model.Virtual},
+	{[]string{"keyvault", "vault", "secretstore"}, model.Vault, model.Virtual},
+	{[]string{"hsm"}, model.HSM, model.Physical},
+	{[]string{"applicationgateway", "reverseproxy", "frontdoor"}, model.ReverseProxy, model.Virtual},
+	{[]string{"loadbalancer", "trafficmanager"}, model.LoadBalancer, model.Virtual},
+	{[]string{"firewall", "waf"}, model.WAF, model.Virtual},
+	{[]string{"apimanagement", "apim", "apigateway", "gateway"}, model.Gateway, model.Virtual},
+	{[]string{"function", "lambda", "serverless"}, model.Function, model.Serverless},
+	{[]string{"servicebus", "eventhub", "eventgrid", "queue", "iothub", "messagebroker"}, model.MessageQueue, model.Virtual},
+	{[]string{"streamanalytics", "streamprocessing"}, model.StreamProcessing, model.Virtual},
+	{[]string{"datalake"}, model.DataLake, model.Virtual},
+	{[]string{"search"}, model.SearchIndex, model.Virtual},
+	{[]string{"sql", "database", "cosmos", "documentdb", "mongo", "postgres", "mysql", "cache", "redis", "tablestorage"}, model.Database, model.Virtual},
+	{[]string{"cloudstorage", "blob", "storageaccount", "bucket", "filestorage"}, model.FileServer, model.Virtual},
+	{[]string{"filesystem", "configfile", "registry", "html5ls", "localstorage", "cookie"}, model.LocalFileSystem, model.Physical},
+	{[]string{"ldap"}, model.LDAPServer, model.Virtual},
+	{[]string{"mail", "smtp"}, model.MailServer, model.Virtual},
+	{[]string{"monitor", "insights", "loganalytics", "siem"}, model.Monitoring, model.Virtual},
+	{[]string{"kubernetes", "aks", "servicefabric", "container"}, model.ContainerPlatform, model.Virtual},
+	{[]string{"devops", "pipeline"}, model.BuildPipeline, model.Virtual},
+	{[]string{"github", "gitlab", "sourcecontrol", "repository"}, model.SourcecodeRepository, model.Virtual},
+	{[]string{"scheduler", "cron"}, model.Scheduler, model.Virtual},
+	{[]string{"mainframe"}, model.Mainframe, model.Physical},
+	{[]string{"plugin", "library"}, model.Library, model.Virtual},
+	{[]string{"webserver", "iis"}, model.WebServer, model.Virtual},
+	{[]string{"webapp", "webapplication", "appservice", "webrole", "cloudservice"}, model.WebApplication, model.Virtual},
+	{[]string{"soap", "wcf"}, model.WebServiceSOAP, model.Virtual},
+	{[]string{"webapi", "webservice", "restapi", "megaservice"}, model.WebServiceREST, model.Virtual},
+	{[]string{"virtualmachine", "workerrole"}, model.ApplicationServer, model.Virtual},
+	{[]string{"user", "human", "person"}, model.ClientSystem, model.Physical},
+}
+
+// stencil type ids (their last segment) too short to be matched as part of a name
+var technologyOfTypeId = map[string]technologyMapping{
+	"fs": {technology: model.LocalFileSystem, machine: model.Physical},
+	"vm": {technology: model.ApplicationServer, machine: model.Virtual},
+}
+
+var protocolOfTypeId = map[string]model.Protocol{
+	"http":      model.HTTP,
+	"https":     model.HTTPS,
+	"binary":    model.BINARY,
+	"tcp":       model.BINARY,
+	"udp":       model.BINARY,
+	"rpc":       model.BINARY,
+	"namedpipe": model.BINARY,
+	"ipsec":     model.BINARY_encrypted,
+	"alpc":      model.InProcessLibraryCall,
+	"ioctl":     model.InProcessLibraryCall,
+	"smb":       model.SMB,
+	"sql":       model.SQL_access_protocol,
+	"amqp":      model.BINARY,
+	"amqps":     model.BINARY_encrypted,
+}
+
+type threatModel struct {
+	Surfaces    []drawingSurface `xml:"DrawingSurfaceList>DrawingSurfaceModel"`
+	Information struct {
+		Name        string `xml:"ThreatModelName"`
+		Owner       string `xml:"Owner"`
+		Description string `xml:"HighLevelSystemDescription"`
+	} `xml:"MetaInformation"`
+	KnowledgeBase struct {
+		GenericElements  []elementType `xml:"GenericElements>ElementType"`
+		StandardElements []elementType `xml:"StandardElements>ElementType"`
+	} `xml:"KnowledgeBase"`
+}
+
+type elementType struct {
+	Name          string `xml:"Name"`
+	ID            string `xml:"ID"`
+	ParentElement string `xml:"ParentElement"`
+}
+
+type drawingSurface struct {
+	Header  string    `xml:"Header"`
+	Borders []stencil `xml:"Borders>KeyValueOfguidanyType>Value"`
+	Lines   []stencil `xml:"Lines>KeyValueOfguidanyType>Value"`
+}
+
+// stencil holds the elements and border boundaries (having a rectangle) as well as the data flows and line boundaries
+// (having a source and target)
+type stencil struct {
+	GenericTypeId string     `xml:"GenericTypeId"`
+	Guid          string     `xml:"Guid"`
+	TypeId        string     `xml:"TypeId"`
+	Properties    []property `xml:"Properties>anyType"`
+	Left          float64    `xml:"Left"`
+	Top           float64    `xml:"Top"`
+	Width         float64    `xml:"Width"`
+	Height        float64    `xml:"Height"`
+	SourceGuid    string     `xml:"SourceGuid"`
+	TargetGuid    string     `xml:"TargetGuid"`
+	SourceX       float64    `xml:"SourceX"`
+	SourceY       float64    `xml:"SourceY"`
+	TargetX       float64    `xml:"TargetX"`
+	TargetY       float64    `xml:"TargetY"`
+}
+
+type property struct {
+	Type        string `xml:"type,attr"` // like HeaderDisplayAttribute, StringDisplayAttribute, or ListDisplayAttribute
+	DisplayName string `xml:"DisplayName"`
+	Value       struct {
+		Text    string   `xml:",chardata"`
+		Strings []string `xml:"string"` // of lists
+	} `xml:"Value"`
+	SelectedIndex int `xml:"SelectedIndex"`
+}
+
+// UnmappedStencil is a stencil type the importer was unable to map (imported as unknown technology or protocol), or a
+// line boundary (which does not enclose any elements), along with the elements using it
+type UnmappedStencil struct {
+	TypeId, Name, Kind string
+	Elements           []string // as "element name (model file)"
+}
+
+type element struct {
+	title string
+	kind  string
+	asset model.InputTechnicalAsset
+	x, y  float64 // the center
+}
+
+type importer struct {
+	threatModel threatModel
+	modelFile   string
+	stencilName map[string]string // by type id
+	parentOf    map[string]string // by type id
+	unmapped    map[string]*UnmappedStencil
+}
+
+// Import reads the Threat Modeling Tool model (.tm7) given and returns the stencils it was unable to map
+func Import(filename string) (model.ModelInput, []UnmappedStencil, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return model.ModelInput{}, nil, err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(toUTF8(content)))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil // as already converted to UTF-8
+	}
+	what := &importer{
+		modelFile:   filepath.Base(filename),
+		stencilName: make(map[string]string),
+		parentOf:    make(map[string]string),
+		unmapped:    make(map[string]*UnmappedStencil),
+	}
+	if err = decoder.Decode(&what.threatModel); err != nil {
+		return model.ModelInput{}, nil, fmt.Errorf("unable to parse Threat Modeling Tool model %s: %v", filename, err)
+	}
+	if len(what.threatModel.Surfaces) == 0 {
+		return model.ModelInput{}, nil, errors.New("no diagrams found in the Threat Modeling Tool model: " + filename)
+	}
+	for _, elementType := range append(what.threatModel.KnowledgeBase.GenericElements, what.threatModel.KnowledgeBase.StandardElements...) {
+		what.stencilName[elementType.ID] = strings.TrimSpace(elementType.Name)
+		what.parentOf[elementType.ID] = elementType.ParentElement
+	}
+	modelInput := what.toModelInput(strings.TrimSuffix(what.modelFile, filepath.Ext(what.modelFile)))
+	unmapped := make([]UnmappedStencil, 0)
+	for _, stencil := range what.unmapped {
+		sort.Strings(stencil.Elements)
+		unmapped = append(unmapped, *stencil)
+	}
+	sortUnmappedStencils(unmapped)
+	return modelInput, unmapped, nil
+}
+
+func (what *importer) toModelInput(defaultTitle string) model.ModelInput {
+	information := what.threatModel.Information
+	title := strings.TrimSpace(information.Name)
+	if len(title) == 0 {
+		title = defaultTitle
+	}
+	description := "Imported from the Threat Modeling Tool model " + what.modelFile + ": The processes, data stores, and " +
+		"external interactors as technical assets, the data flows as communication links, and the border boundaries as " +
+		"trust boundaries."
+	if text := strings.TrimSpace(information.Description); len(text) > 0 {
+		description += " " + text
+	}
+	modelInput := importers.NewModelInput("Threat Modeling Tool Import of "+title, description)
+	if owner := strings.TrimSpace(information.Owner); len(owner) > 0 {
+		modelInput.Author.Name = owner
+	}
+
+	// the elements first (as the flows and boundaries refer to them), titled by their name unique across all diagrams
+	elementsByGuid := make(map[string]*element)
+	elementsByTitle := make(map[string]*element)
+	for _, surface := range what.threatModel.Surfaces {
+		for _, stencil := range surface.Borders {
+			kind := what.genericTypeOf(stencil)
+			if kind != genericProcess && kind != genericDataStore && kind != genericExternalInteractor {
+				continue
+			}
+			title := stencil.name()
+			if len(title) == 0 {
+				title = importers.UniqueTitle(what.nameOfType(stencil), titlesOf(elementsByTitle))
+			}
+			if existing, exists := elementsByTitle[title]; exists {
+				elementsByGuid[stencil.Guid] = existing
+				continue
+			}
+			element := &element{title: title, kind: kind, x: stencil.Left + stencil.Width/2, y: stencil.Top + stencil.Height/2}
+			element.asset = what.toTechnicalAsset(&modelInput, title, kind, stencil)
+			elementsByGuid[stencil.Guid], elementsByTitle[title] = element, element
+		}
+	}
+
+	for _, surface := range what.threatModel.Surfaces {
+		internetBoundaries := what.internetBoundariesOf(surface)
+		flows := make([]stencil, 0)
+		for _, stencil := range surface.Lines {
+			if what.genericTypeOf(stencil) == genericDataFlow {
+				flows = append(flows, stencil)
+			}
+		}
+		for _, flow := range flows {
+			source, sourceExists := elementsByGuid[flow.SourceGuid]
+			target, targetExists := elementsByGuid[flow.TargetGuid]
+			if !sourceExists || !targetExists || source == target { // like flows not (yet) connected to elements
+				continue
+			}
+			if isResponse(flow, flows) {
+				continue
+			}
+			linkTitle := flow.name()
+			if len(linkTitle) == 0 {
+				linkTitle = "Flow to " + target.title
+			}
+			linkTitle = importers.UniqueTitle(linkTitle, titlesOf(source.asset.Communication_links))
+			description := "Data flow from " + source.title + " to " + target.title
+			if len(flow.value("Description")) > 0 {
+				description = flow.value("Description")
+			}
+			protocol := what.protocolOf(flow, source.title+": "+linkTitle)
+			link := importers.NewCommunicationLink(model.MakeID(target.title), description, protocol)
+			if strings.EqualFold(flow.value("Source Authenticated"), "Yes") {
+				link.Authentication = model.Credentials.String()
+			}
+			for _, format := range []struct {
+				property string
+				format   model.DataFormat
+			}{{"JSON Payload", model.JSON}, {"Transmits XML", model.XML}, {"SOAP Payload", model.XML}} {
+				if strings.EqualFold(flow.value(format.property), "Yes") && !model.Contains(target.asset.Data_formats_accepted, format.format.String()) {
+					target.asset.Data_formats_accepted = append(target.asset.Data_formats_accepted, format.format.String())
+				}
+			}
+			// external interactors connected across an internet boundary are accessed from (or accessing) the internet
+			for _, boundary := range internetBoundaries {
+				if intersects(source.x, source.y, target.x, target.y, boundary.SourceX, boundary.SourceY, boundary.TargetX, boundary.TargetY) {
+					for _, element := range []*element{source, target} {
+						if element.kind == genericExternalInteractor {
+							element.asset.Internet = true
+						}
+					}
+				}
+			}
+			importers.AddTags(&modelInput, &link.Tags, "tmt")
+			source.asset.Communication_links[linkTitle] = link
+		}
+	}
+	for _, element := range elementsByTitle {
+		sort.Strings(element.asset.Data_formats_accepted)
+		modelInput.Technical_assets[element.title] = element.asset
+	}
+
+	what.addTrustBoundaries(&modelInput, elementsByGuid)
+	return modelInput
+}
+
+func (what *importer) toTechnicalAsset(modelInput *model.ModelInput, title string, kind string, stencil stencil) model.InputTechnicalAsset {
+	description := stencil.value("Description")
+	if len(description) == 0 {
+		description = title + " (" + what.nameOfType(stencil) + ")"
+	}
+	asset := importers.NewTechnicalAsset(model.MakeID(title), description)
+	mapping, mapped := what.technologyOf(stencil)
+	if !mapped {
+		what.addUnmapped(stencil, kind, title)
+	}
+	asset.Technology, asset.Machine = mapping.technology.String(), mapping.machine.String()
+	switch kind {
+	case genericDataStore:
+		asset.Type, asset.Custom_developed_parts = model.Datastore.String(), false
+	case genericExternalInteractor:
+		asset.Type, asset.Custom_developed_parts = model.ExternalEntity.String(), false
+		asset.Used_as_client_by_human = mapping.technology == model.ClientSystem || mapping.technology == model.Browser ||
+			mapping.technology == model.Desktop || mapping.technology == model.MobileApp
+	}
+	if strings.EqualFold(stencil.value("Out Of Scope"), "true") {
+		asset.Out_of_scope, asset.Justification_out_of_scope = true, justificationOutOfScopeTMT
+		if reason := stencil.value("Reason For Out Of Scope"); len(reason) > 0 {
+			asset.Justification_out_of_scope += ": " + reason
+		}
+	}
+	importers.AddTags(modelInput, &asset.Tags, "tmt")
+	return asset
+}
+
+// technologyOf maps the stencil type (or else the one it is derived from) by its name and type id
+func (what *importer) technologyOf(stencil stencil) (technologyMapping, bool) {
+	for typeId := stencil.TypeId; len(typeId) > 0 && !strings.HasPrefix(typeId, "GE."); typeId = what.parentOf[typeId] {
+		suffix := strings.ToLower(typeId[strings.LastIndex(typeId, ".")+1:])
+		if mapping, ok := technologyOfTypeId[suffix]; ok {
+			return mapping, true
+		}
+		name := nonAlphanumericRegEx.ReplaceAllString(strings.ToLower(what.stencilName[typeId]), "")
+		if typeId == stencil.TypeId && len(name) == 0 {
+			name = nonAlphanumericRegEx.ReplaceAllString(strings.ToLower(stencil.header()), "")
+		}
+		for _, mapping := range technologyMappings {
+			for _, part := range mapping.parts {
+				if strings.Contains(name, part) || strings.Contains(suffix, part) {
+					return mapping, true
+				}
+			}
+		}
+	}
+	machine := model.Virtual
+	if what.genericTypeOf(stencil) == genericExternalInteractor {
+		machine = model.Physical
+	}
+	return technologyMapping{technology: model.UnknownTechnology, machine: machine}, false
+}
+
+// protocolOf maps the data flow type (or else the one it is derived from) by its type id or name
+func (what *importer) protocolOf(flow stencil, elementName string) model.Protocol {
+	for typeId := flow.TypeId; len(typeId) > 0 && !strings.HasPrefix(typeId, "GE."); typeId = what.parentOf[typeId] {
+		candidates := []string{strings.ToLower(typeId[strings.LastIndex(typeId, ".")+1:]),
+			nonAlphanumericRegEx.ReplaceAllString(strings.ToLower(what.stencilName[typeId]), "")}
+		for _, candidate := range candidates {
+			if protocol, ok := protocolOfTypeId[candidate]; ok {
+				return protocol
+			}
+		}
+	}
+	what.addUnmapped(flow, genericDataFlow, elementName)
+	return model.UnknownProtocol
+}
+
+// addTrustBoundaries places each element into the innermost border boundary containing its center, and nests each
+// border boundary into the innermost one containing it entirely
+func (what *importer) addTrustBoundaries(modelInput *model.ModelInput, elementsByGuid map[string]*element) {
+	placed := make(map[string]bool) // as an asset can only be inside one trust boundary
+	nested := make(map[string]bool)
+	for _, surface := range what.threatModel.Surfaces {
+		boxes := make([]stencil, 0)
+		for _, stencil := range surface.Borders {
+			if what.genericTypeOf(stencil) == genericBorderBoundary {
+				boxes = append(boxes, stencil)
+			}
+		}
+		// the smaller boxes first, so that the first box containing something is the innermost one
+		sort.SliceStable(boxes, func(i, j int) bool {
+			return boxes[i].Width*boxes[i].Height < boxes[j].Width*boxes[j].Height
+		})
+		boundaryTitles := make(map[string]string) // by guid
+		for _, box := range boxes {
+			boundaryTitle := box.name()
+			if len(boundaryTitle) == 0 {
+				boundaryTitle = importers.UniqueTitle(what.nameOfType(box), boundaryTitlesOf(modelInput.Trust_boundaries))
+			}
+			boundaryTitles[box.Guid] = boundaryTitle
+			if _, exists := modelInput.Trust_boundaries[boundaryTitle]; !exists {
+				boundary := model.InputTrustBoundary{
+					ID:                      model.MakeID(boundaryTitle),
+					Description:             boundaryTitle + " (" + what.nameOfType(box) + ")",
+					Type:                    what.trustBoundaryTypeOf(box).String(),
+					Tags:                    make([]string, 0),
+					Technical_assets_inside: make([]string, 0),
+					Trust_boundaries_nested: make([]string, 0),
+				}
+				importers.AddTags(modelInput, &boundary.Tags, "tmt")
+				modelInput.Trust_boundaries[boundaryTitle] = boundary
+			}
+		}
+		for _, stencil := range surface.Borders {
+			element, isElement := elementsByGuid[stencil.Guid]
+			if !isElement || placed[element.title] {
+				continue
+			}
+			for _, box := range boxes {
+				if box.contains(stencil.Left+stencil.Width/2, stencil.Top+stencil.Height/2) {
+					boundary := modelInput.Trust_boundaries[boundaryTitles[box.Guid]]
+					boundary.Technical_assets_inside = append(boundary.Technical_assets_inside, element.asset.ID)
+					modelInput.Trust_boundaries[boundaryTitles[box.Guid]] = boundary
+					placed[element.title] = true
+					break
+				}
+			}
+		}
+		for i, inner := range boxes {
+			innerTitle := boundaryTitles[inner.Guid]
+			if nested[innerTitle] {
+				continue
+			}
+			for _, outer := range boxes[i+1:] {
+				outerTitle := boundaryTitles[outer.Guid]
+				if outerTitle != innerTitle && outer.contains(inner.Left, inner.Top) &&
+					outer.contains(inner.Left+inner.Width, inner.Top+inner.Height) {
+					boundary := modelInput.Trust_boundaries[outerTitle]
+					boundary.Trust_boundaries_nested = append(boundary.Trust_boundaries_nested, model.MakeID(innerTitle))
+					modelInput.Trust_boundaries[outerTitle] = boundary
+					nested[innerTitle] = true
+					break
+				}
+			}
+		}
+	}
+	for title, boundary := range modelInput.Trust_boundaries {
+		sort.Strings(boundary.Technical_assets_inside)
+		sort.Strings(boundary.Trust_boundaries_nested)
+		modelInput.Trust_boundaries[title] = boundary
+	}
+}
+
+// internetBoundariesOf returns the line boundaries of the diagram separating the internet, while reporting all line
+// boundaries as unmapped (as they do not enclose any elements)
+func (what *importer) internetBoundariesOf(surface drawingSurface) []stencil {
+	result := make([]stencil, 0)
+	for _, stencil := range surface.Lines {
+		if what.genericTypeOf(stencil) != genericLineBoundary {
+			continue
+		}
+		name := stencil.name()
+		if len(name) == 0 {
+			name = what.nameOfType(stencil)
+		}
+		what.addUnmapped(stencil, genericLineBoundary, name)
+		if strings.Contains(strings.ToLower(stencil.TypeId+" "+what.nameOfType(stencil)+" "+name), "internet") {
+			result = append(result, stencil)
+		}
+	}
+	return result
+}
+
+func (what *importer) trustBoundaryTypeOf(box stencil) model.TrustBoundaryType {
+	name := nonAlphanumericRegEx.ReplaceAllString(strings.ToLower(box.TypeId+" "+what.nameOfType(box)+" "+box.name()), "")
+	switch {
+	case strings.Contains(name, "azure") || strings.Contains(name, "aws") || strings.Contains(name, "cloud"):
+		return model.NetworkCloudProvider
+	case strings.Contains(name, "vnet") || strings.Contains(name, "virtualnetwork") || strings.Contains(name, "vlan"):
+		return model.NetworkVirtualLAN
+	case strings.Contains(name, "sandbox") || strings.Contains(name, "appcontainer") || strings.Contains(name, "machine") ||
+		strings.Contains(name, "kernel") || strings.Contains(name, "process"):
+		return model.ExecutionEnvironment
+	}
+	return model.NetworkOnPrem
+}
+
+// genericTypeOf returns the generic type of the stencil, also for models lacking it (by following the parents of its type)
+func (what *importer) genericTypeOf(stencil stencil) string {
+	if len(stencil.GenericTypeId) > 0 {
+		return stencil.GenericTypeId
+	}
+	typeId := stencil.TypeId
+	for i := 0; i < 10 && len(typeId) > 0 && !strings.HasPrefix(typeId, "GE."); i++ {
+		typeId = what.parentOf[typeId]
+	}
+	return typeId
+}
+
+// nameOfType returns the name of the stencil type (like "Web Application") as given by the knowledge base
+func (what *importer) nameOfType(stencil stencil) string {
+	if name, ok := what.stencilName[stencil.TypeId]; ok && len(name) > 0 {
+		return name
+	}
+	if header := stencil.header(); len(header) > 0 {
+		return header
+	}
+	return stencil.TypeId
+}
+
+func (what *importer) addUnmapped(stencil stencil, kind string, elementName string) {
+	key := kind + "/" + stencil.TypeId
+	unmapped, exists := what.unmapped[key]
+	if !exists {
+		unmapped = &UnmappedStencil{TypeId: stencil.TypeId, Name: what.nameOfType(stencil), Kind: kindTitle(kind)}
+		what.unmapped[key] = unmapped
+	}
+	elementName += " (" + what.modelFile + ")"
+	if !model.Contains(unmapped.Elements, elementName) {
+		unmapped.Elements = append(unmapped.Elements, elementName)
+	}
+}
+
+// name returns the name of the element as given by its "Name" property
+func (what stencil) name() string {
+	return what.value("Name")
+}
+
+// header returns the name of the stencil type as shown in the properties of the element
+func (what stencil) header() string {
+	for _, property := range what.Properties {
+		if strings.HasSuffix(property.Type, "HeaderDisplayAttribute") {
+			return strings.TrimSpace(property.DisplayName)
+		}
+	}
+	return ""
+}
+
+// value returns the (selected) value of the property given by its display name
+func (what stencil) value(displayName string) string {
+	for _, property := range what.Properties {
+		if !strings.EqualFold(strings.TrimSpace(property.DisplayName), displayName) {
+			continue
+		}
+		if len(property.Value.Strings) > 0 {
+			if property.SelectedIndex >= 0 && property.SelectedIndex < len(property.Value.Strings) {
+				return strings.TrimSpace(property.Value.Strings[property.SelectedIndex])
+			}
+			return ""
+		}
+		return strings.TrimSpace(property.Value.Text)
+	}
+	return ""
+}
+
+func (what stencil) contains(x, y float64) bool {
+	return x >= what.Left && x <= what.Left+what.Width && y >= what.Top && y <= what.Top+what.Height
+}
+
+// isResponse tells whether the flow is the response to another flow in the opposite direction (as modeled by the
+// Threat Modeling Tool), as the communication link of the request already covers it
+func isResponse(flow stencil, flows []stencil) bool {
+	name := strings.ToLower(flow.name())
+	if !strings.Contains(name, "response") && !strings.Contains(name, "reply") && !strings.Contains(name, "result") {
+		return false
+	}
+	for _, other := range flows {
+		if other.SourceGuid == flow.TargetGuid && other.TargetGuid == flow.SourceGuid {
+			return true
+		}
+	}
+	return false
+}
+
+// intersects tells whether the line segments (x1,y1)-(x2,y2) and (x3,y3)-(x4,y4) cross each other
+func intersects(x1, y1, x2, y2, x3, y3, x4, y4 float64) bool {
+	orientation := func(ax, ay, bx, by, cx, cy float64) float64 {
+		return (bx-ax)*(cy-ay) - (by-ay)*(cx-ax)
+	}
+	d1, d2 := orientation(x3, y3, x4, y4, x1, y1), orientation(x3, y3, x4, y4, x2, y2)
+	d3, d4 := orientation(x1, y1, x2, y2, x3, y3), orientation(x1, y1, x2, y2, x4, y4)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func kindTitle(kind string) string {
+	switch kind {
+	case genericProcess:
+		return "process"
+	case genericDataStore:
+		return "data store"
+	case genericExternalInteractor:
+		return "external interactor"
+	case genericDataFlow:
+		return "data flow"
+	case genericLineBoundary:
+		return "line boundary"
+	}
+	return kind
+}
+
+// toUTF8 converts models saved as UTF-16 (having a byte order mark) and drops the byte order mark of UTF-8
+func toUTF8(content []byte) []byte {
+	if len(content) >= 2 && ((content[0] == 0xff && content[1] == 0xfe) || (content[0] == 0xfe && content[1] == 0xff)) {
+		littleEndian := content[0] == 0xff
+		units := make([]uint16, 0, len(content)/2)
+		for i := 2; i+1 < len(content); i += 2 {
+			if littleEndian {
+				units = append(units, uint16(content[i])|uint16(content[i+1])<<8)
+			} else {
+				units = append(units, uint16(content[i])<<8|uint16(content[i+1]))
+			}
+		}
+		return []byte(string(utf16.Decode(units)))
+	}
+	return bytes.TrimPrefix(content, []byte{0xef, 0xbb, 0xbf})
+}
+
+func titlesOf(elementsByTitle interface{}) map[string]bool {
+	result := make(map[string]bool)
+	switch elements := elementsByTitle.(type) {
+	case map[string]*element:
+		for title := range elements {
+			result[title] = true
+		}
+	case map[string]model.InputCommunicationLink:
+		for title := range elements {
+			result[title] = true
+		}
+	}
+	return result
+}
+
+func boundaryTitlesOf(trustBoundaries map[string]model.InputTrustBoundary) map[string]bool {
+	result := make(map[string]bool)
+	for title := range trustBoundaries {
+		result[title] = true
+	}
+	return result
+}
+
+func sortUnmappedStencils(unmapped []UnmappedStencil) {
+	sort.Slice(unmapped, func(i, j int) bool {
+		if unmapped[i].Kind != unmapped[j].Kind {
+			return unmapped[i].Kind < unmapped[j].Kind
+		}
+		return unmapped[i].TypeId < unmapped[j].TypeId
+	})
+}
+
+// WriteUnmappedStencilsReport writes the unmapped stencils (of one or more imports, merged by their type) as Markdown
+// table, to be set by hand in the imported models or to extend the mapping
+func WriteUnmappedStencilsReport(filename string, unmapped []UnmappedStencil) error {
+	merged := make([]UnmappedStencil, 0)
+	indexByKey := make(map[string]int)
+	for _, stencil := range unmapped {
+		key := stencil.Kind + "/" + stencil.TypeId
+		if index, exists := indexByKey[key]; exists {
+			for _, element := range stencil.Elements {
+				if !model.Contains(merged[index].Elements, element) {
+					merged[index].Elements = append(merged[index].Elements, element)
+				}
+			}
+			sort.Strings(merged[index].Elements)
+			continue
+		}
+		indexByKey[key] = len(merged)
+		merged = append(merged, UnmappedStencil{TypeId: stencil.TypeId, Name: stencil.Name, Kind: stencil.Kind,
+			Elements: append([]string{}, stencil.Elements...)})
+	}
+	sortUnmappedStencils(merged)
+
+	var report strings.Builder
+	report.WriteString("# Unmapped Stencils\n\n")
+	if len(merged) == 0 {
+		report.WriteString("All stencils were mapped.\n")
+	} else {
+		report.WriteString("The following stencils were imported as `" + model.UnknownTechnology.String() + "` (elements) or `" +
+			model.UnknownProtocol.String() + "` (data flows), to be set by hand. Line boundaries were skipped, as they do " +
+			"not enclose any elements (only the ones separating the internet mark the external interactors connected across " +
+			"them as accessed from the internet).\n\n")
+		report.WriteString("| Kind | Stencil | Type ID | Count | Elements |\n")
+		report.WriteString("|------|---------|---------|------:|----------|\n")
+		for _, stencil := range merged {
+			report.WriteString("| " + stencil.Kind + " | " + escapeTableCell(stencil.Name) + " | `" + stencil.TypeId + "` | " +
+				strconv.Itoa(len(stencil.Elements)) + " | " + escapeTableCell(strings.Join(stencil.Elements, ", ")) + " |\n")
+		}
+	}
+	return ioutil.WriteFile(filename, []byte(report.String()), 0644)
+}
+
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", "\\|"), "\n", " ")
+}
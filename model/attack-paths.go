@@ -0,0 +1,141 @@
+package model
+
+import (
+	"math"
+	"sort"
+)
+
+// AttackPath is the most plausible route (along the direction of communication links) from an entry technical asset
+// reachable by attackers (internet-exposed or client assets) to a technical asset storing highly sensitive data assets.
+// The lower the score, the more plausible the path.
+type AttackPath struct {
+	EntryTechnicalAssetId  string   `json:"entry_technical_asset"`
+	TargetTechnicalAssetId string   `json:"target_technical_asset"`
+	TechnicalAssetIds      []string `json:"technical_assets"`    // from entry to target (both included)
+	CommunicationLinkIds   []string `json:"communication_links"` // one per hop
+	TargetDataAssetIds     []string `json:"target_data_assets"`  // the highly sensitive data assets stored at the target
+	Hops                   int      `json:"hops"`
+	TrustBoundaryCrossings int      `json:"trust_boundary_crossings"`
+	AuthenticationStrength float64  `json:"authentication_strength"` // summed up over all hops
+	Score                  float64  `json:"score"`
+}
+
+// Strength weights how much an authentication hinders an attacker from traversing a communication link
+func (what Authentication) Strength() float64 {
+	return [...]float64{0, 1, 1, 1.5, 2, 2.5, 1.5}[what]
+}
+
+// AttackPathCost is the score contribution of traversing the communication link as part of an attack path
+func (what CommunicationLink) AttackPathCost() float64 {
+	cost := 1 + what.Authentication.Strength()
+	if what.IsAcrossTrustBoundaryNetworkOnly() {
+		cost += 1
+	}
+	return cost
+}
+
+func (what TechnicalAsset) IsAttackPathEntry() bool {
+	return what.Internet || what.Technology.IsClient() || what.UsedAsClientByHuman
+}
+
+// AttackPathTargetDataAssetIds returns the sorted IDs of the strictly-confidential or mission-critical data assets
+// stored by the technical asset (or nothing when it is out of scope)
+func (what TechnicalAsset) AttackPathTargetDataAssetIds() []string {
+	result := make([]string, 0)
+	if what.OutOfScope {
+		return result
+	}
+	for _, dataId := range what.DataAssetsStored {
+		dataAsset := ParsedModelRoot.DataAssets[dataId]
+		if dataAsset.Confidentiality == StrictlyConfidential || dataAsset.Integrity == MissionCritical || dataAsset.Availability == MissionCritical {
+			result = append(result, dataId)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AttackPaths returns the most plausible attack path of each pair of entry and target technical asset (if any) sorted
+// by plausibility (lowest score first), followed by the RAA of the target (highest first)
+func AttackPaths() []AttackPath {
+	result := make([]AttackPath, 0)
+	for _, entryId := range SortedTechnicalAssetIDs() {
+		entry := ParsedModelRoot.TechnicalAssets[entryId]
+		if !entry.IsAttackPathEntry() {
+			continue
+		}
+		costs, previousLinks := cheapestAttackPathsFrom(entryId)
+		for _, targetId := range SortedTechnicalAssetIDs() {
+			if targetId == entryId {
+				continue
+			}
+			if _, reachable := costs[targetId]; !reachable {
+				continue
+			}
+			targetDataAssetIds := ParsedModelRoot.TechnicalAssets[targetId].AttackPathTargetDataAssetIds()
+			if len(targetDataAssetIds) == 0 {
+				continue
+			}
+			path := AttackPath{
+				EntryTechnicalAssetId:  entryId,
+				TargetTechnicalAssetId: targetId,
+				TechnicalAssetIds:      []string{targetId},
+				CommunicationLinkIds:   make([]string, 0),
+				TargetDataAssetIds:     targetDataAssetIds,
+				Score:                  costs[targetId],
+			}
+			for current := targetId; current != entryId; {
+				link := previousLinks[current]
+				path.TechnicalAssetIds = append([]string{link.SourceId}, path.TechnicalAssetIds...)
+				path.CommunicationLinkIds = append([]string{link.Id}, path.CommunicationLinkIds...)
+				path.Hops++
+				path.AuthenticationStrength += link.Authentication.Strength()
+				if link.IsAcrossTrustBoundaryNetworkOnly() {
+					path.TrustBoundaryCrossings++
+				}
+				current = link.SourceId
+			}
+			result = append(result, path)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score < result[j].Score
+		}
+		raaI := ParsedModelRoot.TechnicalAssets[result[i].TargetTechnicalAssetId].RAA
+		raaJ := ParsedModelRoot.TechnicalAssets[result[j].TargetTechnicalAssetId].RAA
+		return raaI > raaJ
+	})
+	return result
+}
+
+// cheapestAttackPathsFrom runs Dijkstra along the communication links starting at the entry technical asset and
+// returns the lowest cost to reach each technical asset along with the last link of the respective cheapest path
+func cheapestAttackPathsFrom(entryId string) (map[string]float64, map[string]CommunicationLink) {
+	costs := map[string]float64{entryId: 0}
+	previousLinks := make(map[string]CommunicationLink)
+	visited := make(map[string]bool)
+	for {
+		current, currentCost := "", math.Inf(1)
+		for _, id := range SortedTechnicalAssetIDs() { // sorted for reproducible paths with equal costs
+			if cost, reached := costs[id]; reached && !visited[id] && cost < currentCost {
+				current, currentCost = id, cost
+			}
+		}
+		if len(current) == 0 {
+			return costs, previousLinks
+		}
+		visited[current] = true
+		links := append([]CommunicationLink{}, ParsedModelRoot.TechnicalAssets[current].CommunicationLinks...)
+		sort.Slice(links, func(i, j int) bool {
+			return links[i].Id < links[j].Id
+		})
+		for _, link := range links {
+			cost := currentCost + link.AttackPathCost()
+			if knownCost, reached := costs[link.TargetId]; !reached || cost < knownCost {
+				costs[link.TargetId] = cost
+				previousLinks[link.TargetId] = link
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// BaselineRisk is a risk as written into the risks.json of a previous run (see WriteRisksJSON), just the parts needed
+// to compare against
+type BaselineRisk struct {
+	CategoryId  string `json:"category"`
+	Severity    string `json:"severity"`
+	Title       string `json:"title"`
+	SyntheticId string `json:"synthetic_id"`
+}
+
+type RiskDeltaStatus int
+
+const (
+	NewRisk RiskDeltaStatus = iota
+	SeverityChangedRisk
+	UnchangedRisk
+	ResolvedRisk
+)
+
+func RiskDeltaStatusValues() []TypeEnum {
+	return []TypeEnum{
+		NewRisk,
+		SeverityChangedRisk,
+		UnchangedRisk,
+		ResolvedRisk,
+	}
+}
+
+func (what RiskDeltaStatus) String() string {
+	return [...]string{"new", "severity-changed", "unchanged", "resolved"}[what]
+}
+
+func (what RiskDeltaStatus) Title() string {
+	return [...]string{"New", "Severity Changed", "Unchanged", "Resolved"}[what]
+}
+
+func (what RiskDeltaStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(what.String())
+}
+
+// RiskDelta classifies a risk (by its synthetic id) compared to the baseline
+type RiskDelta struct {
+	Status           RiskDeltaStatus `json:"delta"`
+	SyntheticId      string          `json:"synthetic_id"`
+	CategoryId       string          `json:"category"`
+	Title            string          `json:"title"`
+	Severity         string          `json:"severity,omitempty"`          // empty when resolved
+	BaselineSeverity string          `json:"baseline_severity,omitempty"` // empty when new
+}
+
+func HasBaseline() bool {
+	return ParsedModelRoot.BaselineRisks != nil
+}
+
+// RiskDeltas compares all risks to the baseline risks (if any), sorted by status and then by severity (highest first)
+func RiskDeltas() []RiskDelta {
+	if !HasBaseline() {
+		return make([]RiskDelta, 0)
+	}
+	return CompareRisks(ParsedModelRoot.BaselineRisks, AllRisks())
+}
+
+// CompareRisks classifies the risks compared to the baseline risks, sorted by status and then by severity (highest first)
+func CompareRisks(baselineRisks []BaselineRisk, risks []Risk) []RiskDelta {
+	result := make([]RiskDelta, 0)
+	baselineRisksBySyntheticId := BaselineRisksBySyntheticId(baselineRisks)
+	severities := make(map[string]int) // by synthetic id, to sort resolved risks by their baseline severity as well
+	for _, risk := range risks {
+		severities[risk.SyntheticId] = int(risk.Severity)
+		delta := RiskDelta{
+			Status:      NewRisk,
+			SyntheticId: risk.SyntheticId,
+			CategoryId:  risk.CategoryId,
+			Title:       risk.Title,
+			Severity:    risk.Severity.String(),
+		}
+		if baselineRisk, exists := baselineRisksBySyntheticId[strings.ToLower(risk.SyntheticId)]; exists {
+			delta.BaselineSeverity = baselineRisk.Severity
+			delta.Status = UnchangedRisk
+			if baselineRisk.Severity != delta.Severity {
+				delta.Status = SeverityChangedRisk
+			}
+		}
+		result = append(result, delta)
+	}
+	currentRisksBySyntheticId := make(map[string]bool) // by lower-cased synthetic id
+	for _, risk := range risks {
+		currentRisksBySyntheticId[strings.ToLower(risk.SyntheticId)] = true
+	}
+	for _, baselineRisk := range baselineRisks {
+		if currentRisksBySyntheticId[strings.ToLower(baselineRisk.SyntheticId)] {
+			continue
+		}
+		severity, _ := ParseRiskSeverity(baselineRisk.Severity)
+		severities[baselineRisk.SyntheticId] = int(severity)
+		result = append(result, RiskDelta{
+			Status:           ResolvedRisk,
+			SyntheticId:      baselineRisk.SyntheticId,
+			CategoryId:       baselineRisk.CategoryId,
+			Title:            baselineRisk.Title,
+			BaselineSeverity: baselineRisk.Severity,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Status != result[j].Status {
+			return result[i].Status < result[j].Status
+		}
+		if severities[result[i].SyntheticId] != severities[result[j].SyntheticId] {
+			return severities[result[i].SyntheticId] > severities[result[j].SyntheticId]
+		}
+		return result[i].SyntheticId < result[j].SyntheticId
+	})
+	return result
+}
+
+// AsBaselineRisk reduces the risk to what is compared against
+func (what Risk) AsBaselineRisk() BaselineRisk {
+	return BaselineRisk{
+		CategoryId:  what.CategoryId,
+		Severity:    what.Severity.String(),
+		Title:       what.Title,
+		SyntheticId: what.SyntheticId,
+	}
+}
+
+// IsNewSinceBaseline tells whether the risk is missing in the baseline (always true without one), so that legacy risks
+// don't need attention, even when their severity changed
+func (what Risk) IsNewSinceBaseline() bool {
+	if !HasBaseline() {
+		return true
+	}
+	_, exists := ParsedModelRoot.BaselineRisksBySyntheticId[strings.ToLower(what.SyntheticId)]
+	return !exists
+}
+
+// BaselineRisksBySyntheticId maps the baseline risks by their lower-cased synthetic id (like GeneratedRisksBySyntheticId)
+func BaselineRisksBySyntheticId(baselineRisks []BaselineRisk) map[string]BaselineRisk {
+	result := make(map[string]BaselineRisk, len(baselineRisks))
+	for _, baselineRisk := range baselineRisks {
+		result[strings.ToLower(baselineRisk.SyntheticId)] = baselineRisk
+	}
+	return result
+}
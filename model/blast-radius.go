@@ -0,0 +1,138 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// BlastRadius lists everything exposed once a technical asset is compromised, i.e. all technical assets reachable via
+// outgoing communication links, shared runtimes and trust boundaries (transitively), along with the data assets they
+// process or store grouped by their CIA rating.
+type BlastRadius struct {
+	CompromisedTechnicalAssetId   string                           `json:"compromised_technical_asset"`
+	TechnicalAssets               []BlastRadiusTechnicalAsset      `json:"technical_assets"`    // in order of reaching them
+	CommunicationLinkIds          []string                         `json:"communication_links"` // the ones traversed
+	DataAssetIdsByConfidentiality map[string][]string              `json:"data_assets_by_confidentiality"`
+	DataAssetIdsByIntegrity       map[string][]string              `json:"data_assets_by_integrity"`
+	DataAssetIdsByAvailability    map[string][]string              `json:"data_assets_by_availability"`
+	technicalAssetIds             map[string]BlastRadiusReachedVia // for quick lookup
+}
+
+type BlastRadiusReachedVia int
+
+const (
+	CompromisedDirectly BlastRadiusReachedVia = iota
+	ReachedViaCommunicationLink
+	ReachedViaSharedRuntime
+	ReachedViaTrustBoundary
+)
+
+func BlastRadiusReachedViaValues() []TypeEnum {
+	return []TypeEnum{
+		CompromisedDirectly,
+		ReachedViaCommunicationLink,
+		ReachedViaSharedRuntime,
+		ReachedViaTrustBoundary,
+	}
+}
+
+func (what BlastRadiusReachedVia) String() string {
+	return [...]string{"compromised", "communication-link", "shared-runtime", "trust-boundary"}[what]
+}
+
+func (what BlastRadiusReachedVia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(what.String())
+}
+
+type BlastRadiusTechnicalAsset struct {
+	Id                  string                `json:"id"`
+	Hops                int                   `json:"hops"`
+	ReachedVia          BlastRadiusReachedVia `json:"reached_via"`
+	ReachedFrom         string                `json:"reached_from,omitempty"` // the technical asset one hop closer to the compromised one
+	DataAssetsProcessed []string              `json:"data_assets_processed"`
+	DataAssetsStored    []string              `json:"data_assets_stored"`
+}
+
+// Contains tells whether the technical asset is within the blast radius (including the compromised one)
+func (what BlastRadius) Contains(technicalAssetId string) bool {
+	_, contains := what.technicalAssetIds[technicalAssetId]
+	return contains
+}
+
+// BlastRadiusOf walks breadth-first from the compromised technical asset, so each reachable technical asset is listed
+// with the least number of hops
+func BlastRadiusOf(compromisedTechnicalAssetId string) BlastRadius {
+	if _, exists := ParsedModelRoot.TechnicalAssets[compromisedTechnicalAssetId]; !exists {
+		panic(errors.New("unknown technical asset to compute the blast radius of: " + compromisedTechnicalAssetId))
+	}
+	result := BlastRadius{
+		CompromisedTechnicalAssetId:   compromisedTechnicalAssetId,
+		TechnicalAssets:               make([]BlastRadiusTechnicalAsset, 0),
+		CommunicationLinkIds:          make([]string, 0),
+		DataAssetIdsByConfidentiality: make(map[string][]string),
+		DataAssetIdsByIntegrity:       make(map[string][]string),
+		DataAssetIdsByAvailability:    make(map[string][]string),
+		technicalAssetIds:             make(map[string]BlastRadiusReachedVia),
+	}
+	reach := func(id string, hops int, via BlastRadiusReachedVia, from string) {
+		if result.Contains(id) {
+			return
+		}
+		technicalAsset := ParsedModelRoot.TechnicalAssets[id]
+		result.technicalAssetIds[id] = via
+		result.TechnicalAssets = append(result.TechnicalAssets, BlastRadiusTechnicalAsset{
+			Id:                  id,
+			Hops:                hops,
+			ReachedVia:          via,
+			ReachedFrom:         from,
+			DataAssetsProcessed: sortedCopy(technicalAsset.DataAssetsProcessed),
+			DataAssetsStored:    sortedCopy(technicalAsset.DataAssetsStored),
+		})
+	}
+	reach(compromisedTechnicalAssetId, 0, CompromisedDirectly, "")
+	for i := 0; i < len(result.TechnicalAssets); i++ { // grows while walking
+		current := result.TechnicalAssets[i]
+		links := append([]CommunicationLink{}, ParsedModelRoot.TechnicalAssets[current.Id].CommunicationLinks...)
+		sort.Slice(links, func(i, j int) bool {
+			return links[i].Id < links[j].Id
+		})
+		for _, link := range links {
+			if !result.Contains(link.TargetId) {
+				result.CommunicationLinkIds = append(result.CommunicationLinkIds, link.Id)
+			}
+			reach(link.TargetId, current.Hops+1, ReachedViaCommunicationLink, current.Id)
+		}
+		if sharedRuntime, exists := DirectContainingSharedRuntimeMappedByTechnicalAssetId[current.Id]; exists {
+			for _, id := range sortedCopy(sharedRuntime.TechnicalAssetsRunning) {
+				reach(id, current.Hops+1, ReachedViaSharedRuntime, current.Id)
+			}
+		}
+		if trustBoundary, exists := DirectContainingTrustBoundaryMappedByTechnicalAssetId[current.Id]; exists {
+			for _, id := range sortedCopy(trustBoundary.TechnicalAssetsInside) {
+				reach(id, current.Hops+1, ReachedViaTrustBoundary, current.Id)
+			}
+		}
+	}
+	dataAssetIds := make(map[string]bool)
+	for _, technicalAsset := range result.TechnicalAssets {
+		for _, id := range append(technicalAsset.DataAssetsProcessed, technicalAsset.DataAssetsStored...) {
+			dataAssetIds[id] = true
+		}
+	}
+	for _, dataAsset := range SortedDataAssetsByTitle() {
+		if !dataAssetIds[dataAsset.Id] {
+			continue
+		}
+		result.DataAssetIdsByConfidentiality[dataAsset.Confidentiality.String()] = append(result.DataAssetIdsByConfidentiality[dataAsset.Confidentiality.String()], dataAsset.Id)
+		result.DataAssetIdsByIntegrity[dataAsset.Integrity.String()] = append(result.DataAssetIdsByIntegrity[dataAsset.Integrity.String()], dataAsset.Id)
+		result.DataAssetIdsByAvailability[dataAsset.Availability.String()] = append(result.DataAssetIdsByAvailability[dataAsset.Availability.String()], dataAsset.Id)
+	}
+	return result
+}
+
+func sortedCopy(values []string) []string {
+	result := append(make([]string, 0, len(values)), values...)
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,185 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// the compliance frameworks known out of the box (models may map risk categories to controls of further frameworks)
+const ASVSFramework, NIST80053Framework, ISO27001Framework, PCIDSSFramework = "asvs", "nist-800-53", "iso-27001", "pci-dss"
+
+var complianceFrameworkTitles = map[string]string{
+	ASVSFramework:      "OWASP ASVS 4.0",
+	NIST80053Framework: "NIST SP 800-53 Rev. 5",
+	ISO27001Framework:  "ISO/IEC 27001:2022 Annex A",
+	PCIDSSFramework:    "PCI DSS 4.0",
+}
+
+// ComplianceMappedRiskRule is optionally implemented by custom risk rules to map their risk category to the controls
+// (by framework) it relates to
+type ComplianceMappedRiskRule interface {
+	ComplianceControls() map[string][]string
+}
+
+func ComplianceFrameworkTitle(framework string) string {
+	if title, known := complianceFrameworkTitles[framework]; known {
+		return title
+	}
+	return framework
+}
+
+// MergeComplianceControls returns the controls of all mappings (by framework) sorted and without duplicates
+func MergeComplianceControls(mappings ...map[string][]string) map[string][]string {
+	result := make(map[string][]string)
+	for _, mapping := range mappings {
+		for framework, controls := range mapping {
+			for _, control := range controls {
+				if !Contains(result[framework], control) {
+					result[framework] = append(result[framework], control)
+				}
+			}
+		}
+	}
+	for framework := range result {
+		sortControls(result[framework])
+	}
+	return result
+}
+
+// sortControls sorts control ids with their numbers compared by value (so that "AC-3" comes before "AC-12")
+func sortControls(controls []string) {
+	sort.Slice(controls, func(i, j int) bool {
+		left, right := controls[i], controls[j]
+		for len(left) > 0 && len(right) > 0 {
+			leftChunk, rightChunk := leadingChunk(left), leadingChunk(right)
+			if leftChunk != rightChunk {
+				leftNumber, leftErr := strconv.Atoi(leftChunk)
+				rightNumber, rightErr := strconv.Atoi(rightChunk)
+				if leftErr == nil && rightErr == nil && leftNumber != rightNumber {
+					return leftNumber < rightNumber
+				}
+				return leftChunk < rightChunk
+			}
+			left, right = left[len(leftChunk):], right[len(rightChunk):]
+		}
+		return len(left) < len(right)
+	})
+}
+
+// leadingChunk returns the leading digits or non-digits
+func leadingChunk(value string) string {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	end := 1
+	for end < len(value) && isDigit(value[end]) == isDigit(value[0]) {
+		end++
+	}
+	return value[:end]
+}
+
+// SortedComplianceFrameworks returns the frameworks mapped by any risk category checked: the ones known out of the box
+// first, followed by the further ones sorted by name
+func SortedComplianceFrameworks() []string {
+	frameworks := make(map[string]bool)
+	for _, mapping := range ParsedModelRoot.ComplianceControls {
+		for framework := range mapping {
+			frameworks[framework] = true
+		}
+	}
+	result := make([]string, 0)
+	for _, framework := range []string{ASVSFramework, NIST80053Framework, ISO27001Framework, PCIDSSFramework} {
+		if frameworks[framework] {
+			result = append(result, framework)
+			delete(frameworks, framework)
+		}
+	}
+	further := make([]string, 0)
+	for framework := range frameworks {
+		further = append(further, framework)
+	}
+	sort.Strings(further)
+	return append(result, further...)
+}
+
+type ComplianceStatus int
+
+const (
+	ComplianceOpenRisks ComplianceStatus = iota
+	ComplianceMitigatedRisks
+	ComplianceNoRisks
+)
+
+func ComplianceStatusValues() []TypeEnum {
+	return []TypeEnum{
+		ComplianceOpenRisks,
+		ComplianceMitigatedRisks,
+		ComplianceNoRisks,
+	}
+}
+
+func (what ComplianceStatus) String() string {
+	return [...]string{"open-risks", "mitigated-risks", "no-risks"}[what]
+}
+
+func (what ComplianceStatus) Title() string {
+	return [...]string{"Open Risks", "Mitigated Risks", "No Risks"}[what]
+}
+
+func (what ComplianceStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(what.String())
+}
+
+// ComplianceControlCoverage tells whether the risk categories mapped to a control have open, only mitigated, or no risks
+type ComplianceControlCoverage struct {
+	Framework       string           `json:"framework"`
+	Control         string           `json:"control"`
+	Status          ComplianceStatus `json:"status"`
+	OpenRisks       int              `json:"open_risks"`
+	MitigatedRisks  int              `json:"mitigated_risks"`
+	RiskCategoryIds []string         `json:"risk_categories"`
+}
+
+// ComplianceCoverage returns the coverage of each control mapped by any risk category checked, sorted by framework
+// (see SortedComplianceFrameworks) and control
+func ComplianceCoverage() []ComplianceControlCoverage {
+	risksByCategoryId := make(map[string][]Risk)
+	for category, risks := range GeneratedRisksByCategory {
+		risksByCategoryId[category.Id] = risks
+	}
+	categoryIds := make([]string, 0)
+	for categoryId := range ParsedModelRoot.ComplianceControls {
+		categoryIds = append(categoryIds, categoryId)
+	}
+	sort.Strings(categoryIds)
+	result := make([]ComplianceControlCoverage, 0)
+	for _, framework := range SortedComplianceFrameworks() {
+		coverageByControl := make(map[string]*ComplianceControlCoverage)
+		controls := make([]string, 0)
+		for _, categoryId := range categoryIds {
+			for _, control := range ParsedModelRoot.ComplianceControls[categoryId][framework] {
+				coverage, exists := coverageByControl[control]
+				if !exists {
+					coverage = &ComplianceControlCoverage{Framework: framework, Control: control, RiskCategoryIds: make([]string, 0)}
+					coverageByControl[control] = coverage
+					controls = append(controls, control)
+				}
+				coverage.RiskCategoryIds = append(coverage.RiskCategoryIds, categoryId)
+				openRisks := len(ReduceToOnlyStillAtRisk(risksByCategoryId[categoryId]))
+				coverage.OpenRisks += openRisks
+				coverage.MitigatedRisks += len(risksByCategoryId[categoryId]) - openRisks
+			}
+		}
+		sortControls(controls)
+		for _, control := range controls {
+			coverage := coverageByControl[control]
+			coverage.Status = ComplianceNoRisks
+			if coverage.OpenRisks > 0 {
+				coverage.Status = ComplianceOpenRisks
+			} else if coverage.MitigatedRisks > 0 {
+				coverage.Status = ComplianceMitigatedRisks
+			}
+			result = append(result, *coverage)
+		}
+	}
+	return result
+}
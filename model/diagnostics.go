@@ -0,0 +1,116 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type DiagnosticSeverity int
+
+const (
+	ErrorDiagnostic DiagnosticSeverity = iota
+	WarningDiagnostic
+)
+
+func DiagnosticSeverityValues() []TypeEnum {
+	return []TypeEnum{
+		ErrorDiagnostic,
+		WarningDiagnostic,
+	}
+}
+
+func (what DiagnosticSeverity) String() string {
+	return [...]string{"error", "warning"}[what]
+}
+
+func (what DiagnosticSeverity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(what.String())
+}
+
+func (what *DiagnosticSeverity) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*what = ErrorDiagnostic
+	if value == WarningDiagnostic.String() {
+		*what = WarningDiagnostic
+	}
+	return nil
+}
+
+// machine-readable codes of model diagnostics
+const (
+	DiagnosticYamlSyntax               = "yaml-syntax"
+	DiagnosticUnreadableFile           = "unreadable-file"
+	DiagnosticInvalidInclude           = "invalid-include"
+	DiagnosticUnknownValue             = "unknown-value"
+	DiagnosticInvalidDate              = "invalid-date"
+	DiagnosticInvalidIdSyntax          = "invalid-id-syntax"
+	DiagnosticDuplicateId              = "duplicate-id"
+	DiagnosticMissingReference         = "missing-reference"
+	DiagnosticMissingTag               = "missing-tag"
+	DiagnosticMultipleTrustBoundaries  = "multiple-trust-boundaries"
+	DiagnosticInvalidDiagramTweak      = "invalid-diagram-tweak"
+	DiagnosticOrphanedRiskTracking     = "orphaned-risk-tracking"
+	DiagnosticInvalidVulnerabilityScan = "invalid-vulnerability-scan"
+	DiagnosticInvalidHost              = "invalid-host"
+)
+
+type ModelDiagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     string             `json:"code"`
+	Message  string             `json:"message"`
+	Path     string             `json:"path,omitempty"`
+	File     string             `json:"file,omitempty"`
+	Line     int                `json:"line,omitempty"`
+	Column   int                `json:"column,omitempty"`
+}
+
+// String renders the diagnostic in the well-known "file:line:column: severity: message" style of compilers and linters
+func (what ModelDiagnostic) String() string {
+	var result strings.Builder
+	if len(what.File) > 0 {
+		result.WriteString(what.File)
+		if what.Line > 0 {
+			result.WriteString(":" + strconv.Itoa(what.Line))
+			if what.Column > 0 {
+				result.WriteString(":" + strconv.Itoa(what.Column))
+			}
+		}
+		result.WriteString(": ")
+	}
+	result.WriteString(what.Severity.String() + ": " + what.Message + " [" + what.Code + "]")
+	return result.String()
+}
+
+func (what ModelDiagnostic) IsError() bool {
+	return what.Severity == ErrorDiagnostic
+}
+
+// ModelValidationError bundles all diagnostics found while validating a model (containing at least one error)
+type ModelValidationError struct {
+	Diagnostics []ModelDiagnostic
+}
+
+func (what ModelValidationError) Error() string {
+	errorCount := 0
+	lines := make([]string, 0, len(what.Diagnostics))
+	for _, diagnostic := range what.Diagnostics {
+		if diagnostic.IsError() {
+			errorCount++
+		}
+		lines = append(lines, diagnostic.String())
+	}
+	return "model validation failed with " + strconv.Itoa(errorCount) + " error(s):\n" + strings.Join(lines, "\n")
+}
+
+func ContainsErrorDiagnostics(diagnostics []ModelDiagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.IsError() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ModelDiff lists the semantic changes between two versions of a model (by the IDs of their elements) along with the
+// resulting changes of the risks
+type ModelDiff struct {
+	OldTitle           string        `json:"old_title"`
+	NewTitle           string        `json:"new_title"`
+	TechnicalAssets    []ElementDiff `json:"technical_assets"`
+	CommunicationLinks []ElementDiff `json:"communication_links"`
+	DataAssets         []ElementDiff `json:"data_assets"`
+	TrustBoundaries    []ElementDiff `json:"trust_boundaries"`
+	SharedRuntimes     []ElementDiff `json:"shared_runtimes"`
+	Risks              []RiskDelta   `json:"risks"` // without the unchanged ones
+}
+
+type ElementChange int
+
+const (
+	ElementAdded ElementChange = iota
+	ElementRemoved
+	ElementChanged
+)
+
+func ElementChangeValues() []TypeEnum {
+	return []TypeEnum{
+		ElementAdded,
+		ElementRemoved,
+		ElementChanged,
+	}
+}
+
+func (what ElementChange) String() string {
+	return [...]string{"added", "removed", "changed"}[what]
+}
+
+func (what ElementChange) Title() string {
+	return [...]string{"Added", "Removed", "Changed"}[what]
+}
+
+func (what ElementChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(what.String())
+}
+
+type ElementDiff struct {
+	Id     string        `json:"id"`
+	Title  string        `json:"title"` // the new one, unless removed
+	Change ElementChange `json:"change"`
+	Fields []FieldChange `json:"fields,omitempty"` // when changed
+}
+
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// IsEmpty tells whether both model versions are semantically the same
+func (what ModelDiff) IsEmpty() bool {
+	return len(what.TechnicalAssets) == 0 && len(what.CommunicationLinks) == 0 && len(what.DataAssets) == 0 &&
+		len(what.TrustBoundaries) == 0 && len(what.SharedRuntimes) == 0 && len(what.Risks) == 0
+}
+
+// the compared fields of an element in order, with their values rendered as text
+type diffField struct {
+	name, value string
+}
+
+type diffElement struct {
+	title  string
+	fields []diffField
+}
+
+// DiffModels compares the states of two analyzed models, which don't need to be activated via WithModelState
+func DiffModels(oldState *ModelState, newState *ModelState) ModelDiff {
+	result := ModelDiff{
+		OldTitle: oldState.ParsedModel.Title,
+		NewTitle: newState.ParsedModel.Title,
+		Risks:    make([]RiskDelta, 0),
+	}
+	result.TechnicalAssets = diffElements(technicalAssetsToDiff(oldState), technicalAssetsToDiff(newState))
+	result.CommunicationLinks = diffElements(communicationLinksToDiff(oldState), communicationLinksToDiff(newState))
+	result.DataAssets = diffElements(dataAssetsToDiff(oldState), dataAssetsToDiff(newState))
+	result.TrustBoundaries = diffElements(trustBoundariesToDiff(oldState), trustBoundariesToDiff(newState))
+	result.SharedRuntimes = diffElements(sharedRuntimesToDiff(oldState), sharedRuntimesToDiff(newState))
+	oldRisks := make([]BaselineRisk, 0)
+	for _, risks := range oldState.GeneratedRisksByCategory {
+		for _, risk := range risks {
+			oldRisks = append(oldRisks, risk.AsBaselineRisk())
+		}
+	}
+	newRisks := make([]Risk, 0)
+	for _, risks := range newState.GeneratedRisksByCategory {
+		newRisks = append(newRisks, risks...)
+	}
+	for _, delta := range CompareRisks(oldRisks, newRisks) {
+		if delta.Status != UnchangedRisk {
+			result.Risks = append(result.Risks, delta)
+		}
+	}
+	return result
+}
+
+func diffElements(oldElements map[string]diffElement, newElements map[string]diffElement) []ElementDiff {
+	result := make([]ElementDiff, 0)
+	for id, newElement := range newElements {
+		oldElement, existed := oldElements[id]
+		if !existed {
+			result = append(result, ElementDiff{Id: id, Title: newElement.title, Change: ElementAdded})
+			continue
+		}
+		changes := make([]FieldChange, 0)
+		for i, field := range newElement.fields { // same fields in same order for the same kind of element
+			if oldElement.fields[i].value != field.value {
+				changes = append(changes, FieldChange{Field: field.name, OldValue: oldElement.fields[i].value, NewValue: field.value})
+			}
+		}
+		if len(changes) > 0 {
+			result = append(result, ElementDiff{Id: id, Title: newElement.title, Change: ElementChanged, Fields: changes})
+		}
+	}
+	for id, oldElement := range oldElements {
+		if _, exists := newElements[id]; !exists {
+			result = append(result, ElementDiff{Id: id, Title: oldElement.title, Change: ElementRemoved})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Change != result[j].Change {
+			return result[i].Change < result[j].Change
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func technicalAssetsToDiff(state *ModelState) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, asset := range state.ParsedModel.TechnicalAssets {
+		dataFormats := make([]string, 0)
+		for _, dataFormat := range asset.DataFormatsAccepted {
+			dataFormats = append(dataFormats, dataFormat.String())
+		}
+		result[id] = diffElement{title: asset.Title, fields: []diffField{
+			{"title", asset.Title},
+			{"type", asset.Type.String()},
+			{"usage", asset.Usage.String()},
+			{"size", asset.Size.String()},
+			{"technology", asset.Technology.String()},
+			{"machine", asset.Machine.String()},
+			{"internet", strconv.FormatBool(asset.Internet)},
+			{"multi_tenant", strconv.FormatBool(asset.MultiTenant)},
+			{"redundant", strconv.FormatBool(asset.Redundant)},
+			{"custom_developed_parts", strconv.FormatBool(asset.CustomDevelopedParts)},
+			{"out_of_scope", strconv.FormatBool(asset.OutOfScope)},
+			{"used_as_client_by_human", strconv.FormatBool(asset.UsedAsClientByHuman)},
+			{"encryption", asset.Encryption.String()},
+			{"owner", asset.Owner},
+			{"confidentiality", asset.Confidentiality.String()},
+			{"integrity", asset.Integrity.String()},
+			{"availability", asset.Availability.String()},
+			{"data_assets_processed", joinSorted(asset.DataAssetsProcessed)},
+			{"data_assets_stored", joinSorted(asset.DataAssetsStored)},
+			{"data_formats_accepted", joinSorted(dataFormats)},
+			{"tags", joinSorted(asset.Tags)},
+			{"trust_boundary", state.DirectContainingTrustBoundaryMappedByTechnicalAssetId[id].Id},
+			{"shared_runtime", state.DirectContainingSharedRuntimeMappedByTechnicalAssetId[id].Id},
+		}}
+	}
+	return result
+}
+
+func communicationLinksToDiff(state *ModelState) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, link := range state.CommunicationLinks {
+		result[id] = diffElement{title: link.Title, fields: []diffField{
+			{"source", link.SourceId},
+			{"target", link.TargetId},
+			{"protocol", link.Protocol.String()},
+			{"authentication", link.Authentication.String()},
+			{"authorization", link.Authorization.String()},
+			{"usage", link.Usage.String()},
+			{"vpn", strconv.FormatBool(link.VPN)},
+			{"ip_filtered", strconv.FormatBool(link.IpFiltered)},
+			{"readonly", strconv.FormatBool(link.Readonly)},
+			{"data_assets_sent", joinSorted(link.DataAssetsSent)},
+			{"data_assets_received", joinSorted(link.DataAssetsReceived)},
+			{"tags", joinSorted(link.Tags)},
+		}}
+	}
+	return result
+}
+
+func dataAssetsToDiff(state *ModelState) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, dataAsset := range state.ParsedModel.DataAssets {
+		result[id] = diffElement{title: dataAsset.Title, fields: []diffField{
+			{"title", dataAsset.Title},
+			{"usage", dataAsset.Usage.String()},
+			{"quantity", dataAsset.Quantity.String()},
+			{"origin", dataAsset.Origin},
+			{"owner", dataAsset.Owner},
+			{"confidentiality", dataAsset.Confidentiality.String()},
+			{"integrity", dataAsset.Integrity.String()},
+			{"availability", dataAsset.Availability.String()},
+			{"tags", joinSorted(dataAsset.Tags)},
+		}}
+	}
+	return result
+}
+
+func trustBoundariesToDiff(state *ModelState) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, trustBoundary := range state.ParsedModel.TrustBoundaries {
+		result[id] = diffElement{title: trustBoundary.Title, fields: []diffField{
+			{"title", trustBoundary.Title},
+			{"type", trustBoundary.Type.String()},
+			{"technical_assets_inside", joinSorted(trustBoundary.TechnicalAssetsInside)},
+			{"trust_boundaries_nested", joinSorted(trustBoundary.TrustBoundariesNested)},
+			{"tags", joinSorted(trustBoundary.Tags)},
+		}}
+	}
+	return result
+}
+
+func sharedRuntimesToDiff(state *ModelState) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, sharedRuntime := range state.ParsedModel.SharedRuntimes {
+		result[id] = diffElement{title: sharedRuntime.Title, fields: []diffField{
+			{"title", sharedRuntime.Title},
+			{"technical_assets_running", joinSorted(sharedRuntime.TechnicalAssetsRunning)},
+			{"tags", joinSorted(sharedRuntime.Tags)},
+		}}
+	}
+	return result
+}
+
+func joinSorted(values []string) string {
+	return strings.Join(sortedCopy(values), ", ")
+}
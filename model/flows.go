@@ -0,0 +1,329 @@
+package model
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FlowLogRecord is a single connection (or aggregation window of it) of a flow log
+type FlowLogRecord struct {
+	Source, Target         string // host names or IP addresses
+	SourcePort, TargetPort int    // zero when not logged
+	Protocol               string
+}
+
+// ephemeralPortStart is where the dynamic port range of the common operating systems (Linux: 32768, Windows and
+// IANA: 49152) starts, used to tell responses from requests
+const ephemeralPortStart = 32768
+
+// Normalized turns a logged response (from a service port to an ephemeral port) into the direction of its request,
+// as flow logs (like VPC flow logs) record both directions of a connection
+func (what FlowLogRecord) Normalized() FlowLogRecord {
+	if what.SourcePort > 0 && what.SourcePort < ephemeralPortStart && what.TargetPort >= ephemeralPortStart {
+		return what.Reversed()
+	}
+	return what
+}
+
+// Reversed returns the record of the other direction of the connection
+func (what FlowLogRecord) Reversed() FlowLogRecord {
+	return FlowLogRecord{
+		Source:     what.Target,
+		Target:     what.Source,
+		SourcePort: what.TargetPort,
+		TargetPort: what.SourcePort,
+		Protocol:   what.Protocol,
+	}
+}
+
+// NormalizedFlowLogRecords turns the logged responses into the direction of their requests: When both directions of a
+// connection are logged, the request is the one to the endpoint (host and port) seen in more connections, as services
+// are connected by many clients (on changing ports), or else the one to the lower port. This way services on ports of
+// the ephemeral range (which differs between operating systems anyway) are found as well. Records without the other
+// direction logged are normalized by their ports only.
+func NormalizedFlowLogRecords(records []FlowLogRecord) []FlowLogRecord {
+	logged := make(map[FlowLogRecord]bool, len(records))
+	connectionsByEndpoint := make(map[string]int)
+	for _, record := range records {
+		if logged[record] || logged[record.Reversed()] {
+			logged[record] = true
+			continue
+		}
+		logged[record] = true
+		connectionsByEndpoint[record.sourceEndpoint()]++
+		connectionsByEndpoint[record.targetEndpoint()]++
+	}
+	result := make([]FlowLogRecord, 0, len(records))
+	for _, record := range records {
+		if record.SourcePort > 0 && record.TargetPort > 0 && logged[record.Reversed()] {
+			sourceConnections := connectionsByEndpoint[record.sourceEndpoint()]
+			targetConnections := connectionsByEndpoint[record.targetEndpoint()]
+			if sourceConnections > targetConnections ||
+				(sourceConnections == targetConnections && (record.SourcePort < record.TargetPort ||
+					(record.SourcePort == record.TargetPort && record.Source > record.Target))) {
+				record = record.Reversed()
+			}
+		} else {
+			record = record.Normalized()
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func (what FlowLogRecord) sourceEndpoint() string {
+	return what.Source + "|" + strconv.Itoa(what.SourcePort) + "|" + what.Protocol
+}
+
+func (what FlowLogRecord) targetEndpoint() string {
+	return what.Target + "|" + strconv.Itoa(what.TargetPort) + "|" + what.Protocol
+}
+
+var flowLogColumns = map[string][]string{ // by field, the column names of the known flow log formats
+	"source":      {"srcaddr", "src_addr", "src", "source", "src_ip", "source_ip", "source_address", "src_host", "source_host"},
+	"target":      {"dstaddr", "dst_addr", "dst", "destination", "dst_ip", "destination_ip", "destination_address", "dst_host", "destination_host"},
+	"source-port": {"srcport", "src_port", "source_port"},
+	"target-port": {"dstport", "dst_port", "destination_port", "port"},
+	"protocol":    {"protocol", "proto"},
+	"action":      {"action"},
+	"log-status":  {"log_status", "logstatus"},
+}
+
+// the fields of the default format of AWS VPC flow logs (version 2) when exported without header line
+var vpcFlowLogDefaultColumns = []string{"version", "account_id", "interface_id", "srcaddr", "dstaddr", "srcport", "dstport",
+	"protocol", "packets", "bytes", "start", "end", "action", "log_status"}
+
+// ParseFlowLog reads the accepted connections of a flow log export: AWS VPC flow logs (space-separated as delivered or
+// as CSV, with or without header line) or a simple CSV of source, destination and (optionally) port. Rejected
+// connections and records without data are skipped.
+func ParseFlowLog(content []byte) ([]FlowLogRecord, error) {
+	rows, err := flowLogRows(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty flow log")
+	}
+	columns := make(map[string]int)
+	header := rows[0]
+	for field, names := range flowLogColumns {
+		for i, column := range header {
+			column = strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(strings.TrimSpace(column)))
+			if Contains(names, column) {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	_, hasSource := columns["source"]
+	_, hasTarget := columns["target"]
+	switch {
+	case hasSource && hasTarget:
+		rows = rows[1:]
+	case len(header) == len(vpcFlowLogDefaultColumns) && header[0] == "2": // VPC flow logs without header
+		columns = map[string]int{"source": 3, "target": 4, "source-port": 5, "target-port": 6, "protocol": 7, "action": 12, "log-status": 13}
+	case len(header) >= 2: // simple CSV without header
+		columns = map[string]int{"source": 0, "target": 1, "target-port": 2, "protocol": 3}
+	default:
+		return nil, errors.New("unable to find the source and destination columns")
+	}
+	result := make([]FlowLogRecord, 0)
+	for _, row := range rows {
+		value := func(field string) string {
+			if i, exists := columns[field]; exists && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if strings.EqualFold(value("action"), "REJECT") || strings.EqualFold(value("log-status"), "NODATA") || strings.EqualFold(value("log-status"), "SKIPDATA") {
+			continue
+		}
+		record := FlowLogRecord{
+			Source:   value("source"),
+			Target:   value("target"),
+			Protocol: protocolOfFlowLog(value("protocol")),
+		}
+		if len(record.Source) == 0 || len(record.Target) == 0 || record.Source == "-" || record.Target == "-" {
+			continue
+		}
+		record.SourcePort, _ = strconv.Atoi(value("source-port"))
+		record.TargetPort, _ = strconv.Atoi(value("target-port"))
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+func flowLogRows(content []byte) ([][]string, error) {
+	firstLine := strings.SplitN(strings.TrimSpace(string(content)), "\n", 2)[0]
+	if strings.Contains(firstLine, ",") {
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.Comment = '#'
+		rows := make([][]string, 0)
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				return rows, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+	rows := make([][]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			rows = append(rows, fields)
+		}
+	}
+	return rows, nil
+}
+
+func protocolOfFlowLog(protocol string) string {
+	switch protocol { // IANA protocol numbers as logged by VPC flow logs
+	case "1":
+		return "icmp"
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	}
+	return strings.ToLower(protocol)
+}
+
+// ObservedFlow aggregates the flow log records between two hosts on a target port
+type ObservedFlow struct {
+	SourceHost             string `json:"source_host"`
+	TargetHost             string `json:"target_host"`
+	Port                   int    `json:"port,omitempty"` // zero when not logged
+	Protocol               string `json:"protocol,omitempty"`
+	Records                int    `json:"records"`
+	SourceTechnicalAssetId string `json:"source_technical_asset,omitempty"` // empty when the host is not mapped via "hosts"
+	TargetTechnicalAssetId string `json:"target_technical_asset,omitempty"` // empty when the host is not mapped via "hosts"
+}
+
+func (what ObservedFlow) PortTitle() string {
+	result := "?"
+	if what.Port > 0 {
+		result = strconv.Itoa(what.Port)
+	}
+	if len(what.Protocol) > 0 {
+		result += "/" + what.Protocol
+	}
+	return result
+}
+
+func (what ObservedFlow) IsMapped() bool {
+	return len(what.SourceTechnicalAssetId) > 0 && len(what.TargetTechnicalAssetId) > 0
+}
+
+// IsDeclared tells whether a communication link is declared between the source and the target technical asset (in any
+// direction, as telling requests from responses via the ports of the flow log is a heuristic only)
+func (what ObservedFlow) IsDeclared() bool {
+	return hasCommunicationLink(what.SourceTechnicalAssetId, what.TargetTechnicalAssetId) ||
+		hasCommunicationLink(what.TargetTechnicalAssetId, what.SourceTechnicalAssetId)
+}
+
+func hasCommunicationLink(sourceId, targetId string) bool {
+	for _, link := range ParsedModelRoot.TechnicalAssets[sourceId].CommunicationLinks {
+		if link.TargetId == targetId {
+			return true
+		}
+	}
+	return false
+}
+
+func HasObservedFlows() bool {
+	return ParsedModelRoot.ObservedFlows != nil
+}
+
+// FlowVerification compares the observed flows with the declared communication links
+type FlowVerification struct {
+	ObservedFlows                  int            `json:"observed_flows"`
+	DeclaredFlows                  int            `json:"declared_flows"`
+	UndeclaredFlows                []ObservedFlow `json:"undeclared_flows"`
+	UnobservedCommunicationLinks   []string       `json:"unobserved_communication_links"`   // between technical assets with hosts, but never observed
+	UnverifiableCommunicationLinks []string       `json:"unverifiable_communication_links"` // from or to technical assets without hosts
+	UnmappedHosts                  []string       `json:"unmapped_hosts"`                   // observed, but not mapped to any technical asset
+}
+
+// VerifyFlows compares the observed flows with the declared communication links
+func VerifyFlows() FlowVerification {
+	result := FlowVerification{
+		UndeclaredFlows:                make([]ObservedFlow, 0),
+		UnobservedCommunicationLinks:   make([]string, 0),
+		UnverifiableCommunicationLinks: make([]string, 0),
+		UnmappedHosts:                  make([]string, 0),
+	}
+	observed := make(map[string]bool) // keyed by "source>target" as well as "target<source" (to look up both directions)
+	unmappedHosts := make(map[string]bool)
+	for _, flow := range ParsedModelRoot.ObservedFlows {
+		result.ObservedFlows++
+		if len(flow.SourceTechnicalAssetId) == 0 {
+			unmappedHosts[flow.SourceHost] = true
+		}
+		if len(flow.TargetTechnicalAssetId) == 0 {
+			unmappedHosts[flow.TargetHost] = true
+		}
+		if !flow.IsMapped() || flow.SourceTechnicalAssetId == flow.TargetTechnicalAssetId {
+			continue
+		}
+		observed[flow.SourceTechnicalAssetId+">"+flow.TargetTechnicalAssetId] = true
+		observed[flow.TargetTechnicalAssetId+"<"+flow.SourceTechnicalAssetId] = true
+		if flow.IsDeclared() {
+			result.DeclaredFlows++
+		} else {
+			result.UndeclaredFlows = append(result.UndeclaredFlows, flow)
+		}
+	}
+	for _, id := range SortedTechnicalAssetIDs() {
+		technicalAsset := ParsedModelRoot.TechnicalAssets[id]
+		for _, link := range technicalAsset.CommunicationLinks {
+			if len(technicalAsset.Hosts) == 0 || len(ParsedModelRoot.TechnicalAssets[link.TargetId].Hosts) == 0 {
+				result.UnverifiableCommunicationLinks = append(result.UnverifiableCommunicationLinks, link.Id)
+			} else if !observed[link.SourceId+">"+link.TargetId] && !observed[link.SourceId+"<"+link.TargetId] {
+				result.UnobservedCommunicationLinks = append(result.UnobservedCommunicationLinks, link.Id)
+			}
+		}
+	}
+	for host := range unmappedHosts {
+		result.UnmappedHosts = append(result.UnmappedHosts, host)
+	}
+	sort.Strings(result.UnmappedHosts)
+	return result
+}
+
+// TechnicalAssetIdOfHost finds the technical asset the host name or IP address belongs to via "hosts": exact matches
+// (case-insensitive) take precedence over the most specific matching CIDR range
+func TechnicalAssetIdOfHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	ip := net.ParseIP(host)
+	bestMatch, bestPrefixLength := "", -1
+	for _, id := range SortedTechnicalAssetIDs() {
+		for _, assetHost := range ParsedModelRoot.TechnicalAssets[id].Hosts {
+			assetHost = strings.ToLower(assetHost)
+			if assetHost == host {
+				return id
+			}
+			if ip == nil {
+				continue
+			}
+			if assetIP := net.ParseIP(assetHost); assetIP != nil && assetIP.Equal(ip) {
+				return id
+			}
+			if _, network, err := net.ParseCIDR(assetHost); err == nil && network.Contains(ip) {
+				if prefixLength, _ := network.Mask.Size(); prefixLength > bestPrefixLength {
+					bestMatch, bestPrefixLength = id, prefixLength
+				}
+			}
+		}
+	}
+	return bestMatch
+}
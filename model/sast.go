@@ -0,0 +1,322 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SASTFinding is a result of a SAST tool for a source code repository as read from a SARIF file
+type SASTFinding struct {
+	Repository string `json:"repository"`
+	Tool       string `json:"tool"`
+	RuleId     string `json:"rule_id"`
+	CWEs       []int  `json:"cwes"`
+	Level      string `json:"level"` // error, warning, note or none
+	Message    string `json:"message"`
+	Location   string `json:"location,omitempty"` // file and line
+}
+
+func (what SASTFinding) CWETitles() string {
+	titles := make([]string, 0, len(what.CWEs))
+	for _, cwe := range what.CWEs {
+		titles = append(titles, "CWE-"+strconv.Itoa(cwe))
+	}
+	return strings.Join(titles, ", ")
+}
+
+// relatedCWEs maps CWEs commonly reported by SAST tools to the (broader or sibling) CWE of the built-in risk category
+// they confirm, as the risk categories just name one CWE each
+var relatedCWEs = map[int]int{
+	564:  89, // Hibernate injection
+	943:  89, // NoSQL (improper neutralization of special elements in data query logic)
+	80:   79,
+	83:   79,
+	87:   79,
+	23:   22, // relative path traversal
+	36:   22, // absolute path traversal
+	73:   22, // external control of file name or path
+	776:  611,
+	827:  611,
+	259:  200, // hard-coded password
+	321:  200, // hard-coded cryptographic key
+	798:  200, // hard-coded credentials
+	287:  306,
+	1236: 74, // CSV injection
+	643:  74, // XPath injection
+	917:  74, // expression language injection
+}
+
+// IsConfirmedBySAST tells whether SAST findings of the source code repositories of the technical assets at risk
+// report a weakness of the risk category
+func (what Risk) IsConfirmedBySAST() bool {
+	return len(what.SASTFindings) > 0
+}
+
+// MatchesCWE tells whether the finding reports the CWE of the risk category (or a CWE related to it)
+func (what SASTFinding) MatchesCWE(categoryCWE int) bool {
+	if categoryCWE <= 0 {
+		return false
+	}
+	for _, cwe := range what.CWEs {
+		if cwe == categoryCWE || relatedCWEs[cwe] == categoryCWE {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmedRisks returns all risks confirmed by SAST findings sorted by severity (highest first)
+func ConfirmedRisks() []Risk {
+	result := make([]Risk, 0)
+	for _, risk := range AllRisks() {
+		if risk.IsConfirmedBySAST() {
+			result = append(result, risk)
+		}
+	}
+	sort.Sort(ByRiskSeveritySort(result))
+	return result
+}
+
+// UnconfirmingSASTFindings returns the SAST findings not confirming any risk, either as no modelled risk covers their
+// weakness or as their repository is not referenced by any technical asset
+func UnconfirmingSASTFindings() []SASTFinding {
+	confirming := make(map[string]bool)
+	for _, risk := range AllRisks() {
+		for _, finding := range risk.SASTFindings {
+			confirming[finding.key()] = true
+		}
+	}
+	result := make([]SASTFinding, 0)
+	for _, finding := range ParsedModelRoot.SASTFindings {
+		if !confirming[finding.key()] {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+func (what SASTFinding) key() string {
+	return what.Repository + "|" + what.Tool + "|" + what.RuleId + "|" + what.Location + "|" + what.Message
+}
+
+func HasSASTFindings() bool {
+	return ParsedModelRoot.SASTFindings != nil
+}
+
+var repositoryScheme = regexp.MustCompile(`^[a-z+]+://`)
+var repositoryUser = regexp.MustCompile(`^[^/@]+@`)
+var cweReference = regexp.MustCompile(`(?i)\bcwe[-_:/ ]?0*([0-9]+)\b`)
+
+// NormalizeRepository reduces the various notations of a repository (like "https://github.com/acme/shop.git" or
+// "git@github.com:acme/shop") to a comparable form (like "github.com/acme/shop")
+func NormalizeRepository(repository string) string {
+	result := strings.ToLower(strings.TrimSpace(repository))
+	result = repositoryScheme.ReplaceAllString(result, "")
+	if repositoryUser.MatchString(result) {
+		result = repositoryUser.ReplaceAllString(result, "")
+		result = strings.Replace(result, ":", "/", 1) // the scp-like syntax of ssh
+	}
+	result = strings.TrimSuffix(strings.TrimRight(result, "/"), ".git")
+	return strings.TrimRight(result, "/")
+}
+
+// RepositoryMatches compares two repositories, where a shorter one (like "acme/shop" or "shop") matches the trailing
+// path segments of a longer one (like "github.com/acme/shop")
+func RepositoryMatches(repository, otherRepository string) bool {
+	a, b := NormalizeRepository(repository), NormalizeRepository(otherRepository)
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	return a == b || strings.HasSuffix(a, "/"+b)
+}
+
+// ParseSARIF reads the results of all runs of a SARIF 2.1.0 file. The repository of each run is taken from its
+// version control provenance or else the given default repository.
+func ParseSARIF(content []byte, defaultRepository string) ([]SASTFinding, error) {
+	var log struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Name  string      `json:"name"`
+					Rules []sarifRule `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			VersionControlProvenance []struct {
+				RepositoryUri string `json:"repositoryUri"`
+			} `json:"versionControlProvenance"`
+			Results []struct {
+				RuleId    string `json:"ruleId"`
+				RuleIndex *int   `json:"ruleIndex"`
+				Rule      struct {
+					Id    string `json:"id"`
+					Index *int   `json:"index"`
+				} `json:"rule"`
+				Kind    string `json:"kind"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							Uri string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Taxa         []sarifReference `json:"taxa"`
+				Suppressions []struct {
+					Status string `json:"status"`
+				} `json:"suppressions"`
+				Properties struct {
+					Tags []string `json:"tags"`
+				} `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(content, &log); err != nil {
+		return nil, err
+	}
+	if log.Runs == nil {
+		return nil, errors.New("no SARIF runs found")
+	}
+	result := make([]SASTFinding, 0)
+	for _, run := range log.Runs {
+		repository := defaultRepository
+		if len(run.VersionControlProvenance) > 0 && len(run.VersionControlProvenance[0].RepositoryUri) > 0 {
+			repository = run.VersionControlProvenance[0].RepositoryUri
+		}
+		rulesById := make(map[string]sarifRule)
+		for _, rule := range run.Tool.Driver.Rules {
+			rulesById[rule.Id] = rule
+		}
+		for _, sarifResult := range run.Results {
+			if (len(sarifResult.Kind) > 0 && sarifResult.Kind != "fail") || isSuppressed(sarifResult.Suppressions) {
+				continue
+			}
+			ruleId := sarifResult.RuleId
+			if len(ruleId) == 0 {
+				ruleId = sarifResult.Rule.Id
+			}
+			ruleIndex := sarifResult.RuleIndex
+			if ruleIndex == nil {
+				ruleIndex = sarifResult.Rule.Index
+			}
+			rule, found := rulesById[ruleId]
+			if !found && ruleIndex != nil && *ruleIndex >= 0 && *ruleIndex < len(run.Tool.Driver.Rules) {
+				rule = run.Tool.Driver.Rules[*ruleIndex]
+				if len(ruleId) == 0 {
+					ruleId = rule.Id
+				}
+			}
+			cwes := make(map[int]bool)
+			collectCWEs(cwes, rule.Properties.Tags, rule.relationshipTargets())
+			collectCWEs(cwes, sarifResult.Properties.Tags, sarifResult.Taxa)
+			level := sarifResult.Level
+			if len(level) == 0 {
+				level = rule.DefaultConfiguration.Level
+			}
+			if len(level) == 0 {
+				level = "warning"
+			}
+			location := ""
+			if len(sarifResult.Locations) > 0 {
+				physicalLocation := sarifResult.Locations[0].PhysicalLocation
+				location = physicalLocation.ArtifactLocation.Uri
+				if physicalLocation.Region.StartLine > 0 {
+					location += ":" + strconv.Itoa(physicalLocation.Region.StartLine)
+				}
+			}
+			result = append(result, SASTFinding{
+				Repository: repository,
+				Tool:       run.Tool.Driver.Name,
+				RuleId:     ruleId,
+				CWEs:       sortedCWEs(cwes),
+				Level:      level,
+				Message:    firstLine(sarifResult.Message.Text),
+				Location:   location,
+			})
+		}
+	}
+	return result, nil
+}
+
+type sarifRule struct {
+	Id                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	Properties struct {
+		Tags []string `json:"tags"`
+	} `json:"properties"`
+	Relationships []struct {
+		Target sarifReference `json:"target"`
+	} `json:"relationships"`
+}
+
+func (what sarifRule) relationshipTargets() []sarifReference {
+	result := make([]sarifReference, 0, len(what.Relationships))
+	for _, relationship := range what.Relationships {
+		result = append(result, relationship.Target)
+	}
+	return result
+}
+
+// sarifReference refers to a taxon (like a CWE) of a taxonomy
+type sarifReference struct {
+	Id            string `json:"id"`
+	ToolComponent struct {
+		Name string `json:"name"`
+	} `json:"toolComponent"`
+}
+
+func collectCWEs(cwes map[int]bool, tags []string, taxa []sarifReference) {
+	// tags like "external/cwe/cwe-89" (CodeQL) or "CWE-89: Improper Neutralization..." (Semgrep)
+	for _, tag := range tags {
+		for _, match := range cweReference.FindAllStringSubmatch(tag, -1) {
+			if cwe, err := strconv.Atoi(match[1]); err == nil {
+				cwes[cwe] = true
+			}
+		}
+	}
+	for _, taxon := range taxa {
+		if strings.EqualFold(taxon.ToolComponent.Name, "CWE") {
+			if cwe, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(taxon.Id), "CWE-")); err == nil {
+				cwes[cwe] = true
+			}
+		} else if match := cweReference.FindStringSubmatch(taxon.Id); match != nil {
+			if cwe, err := strconv.Atoi(match[1]); err == nil {
+				cwes[cwe] = true
+			}
+		}
+	}
+}
+
+func isSuppressed(suppressions []struct {
+	Status string `json:"status"`
+}) bool {
+	for _, suppression := range suppressions {
+		if len(suppression.Status) == 0 || suppression.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedCWEs(cwes map[int]bool) []int {
+	result := make([]int, 0, len(cwes))
+	for cwe := range cwes {
+		result = append(result, cwe)
+	}
+	sort.Ints(result)
+	return result
+}
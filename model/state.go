@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ModelState holds everything the package-level model variables (ParsedModelRoot, CommunicationLinks,
+// GeneratedRisksByCategory, ...) refer to for one model. The risk rules, RAA plugins, macros and the report generation
+// still read these variables, so a state has to be activated via WithModelState while such code runs on its behalf.
+type ModelState struct {
+	ParsedModel                                           ParsedModel
+	CommunicationLinks                                    map[string]CommunicationLink
+	IncomingTechnicalCommunicationLinksMappedByTargetId   map[string][]CommunicationLink
+	DirectContainingTrustBoundaryMappedByTechnicalAssetId map[string]TrustBoundary
+	DirectContainingSharedRuntimeMappedByTechnicalAssetId map[string]SharedRuntime
+	GeneratedRisksByCategory                              map[RiskCategory][]Risk
+	GeneratedRisksBySyntheticId                           map[string]Risk
+	AllSupportedTags                                      map[string]bool
+}
+
+func NewModelState() *ModelState {
+	return &ModelState{
+		CommunicationLinks: make(map[string]CommunicationLink, 0),
+		IncomingTechnicalCommunicationLinksMappedByTargetId:   make(map[string][]CommunicationLink, 0),
+		DirectContainingTrustBoundaryMappedByTechnicalAssetId: make(map[string]TrustBoundary, 0),
+		DirectContainingSharedRuntimeMappedByTechnicalAssetId: make(map[string]SharedRuntime, 0),
+		GeneratedRisksByCategory:                              make(map[RiskCategory][]Risk, 0),
+		GeneratedRisksBySyntheticId:                           make(map[string]Risk, 0),
+		AllSupportedTags:                                      make(map[string]bool, 0),
+	}
+}
+
+var modelStateLock sync.Mutex // held by the outermost activation of a model state
+var activeModelState *ModelState
+var activeModelStateOwner uint64         // the goroutine of the outermost activation
+var activeModelStateOwnerLock sync.Mutex // guards activeModelState and activeModelStateOwner
+
+// WithModelState points the package-level model variables to the given state while executing the action and stores
+// their (possibly reassigned) values back into the state afterwards. As there is only one set of package-level
+// variables, activations from other goroutines wait until the action is done. Nested calls (from within the action)
+// for the same state just execute the action, as the state is active already, while nested calls for another state
+// panic (instead of waiting forever).
+func WithModelState(state *ModelState, action func()) {
+	goroutine := currentGoroutineId()
+	activeModelStateOwnerLock.Lock()
+	nestedState, nested := activeModelState, activeModelState != nil && activeModelStateOwner == goroutine
+	activeModelStateOwnerLock.Unlock()
+	if nested {
+		if nestedState != state {
+			panic(errors.New("another model state is already active: models must be processed one after the other"))
+		}
+		action()
+		return
+	}
+	modelStateLock.Lock()
+	defer modelStateLock.Unlock()
+	activeModelStateOwnerLock.Lock()
+	activeModelState, activeModelStateOwner = state, goroutine
+	activeModelStateOwnerLock.Unlock()
+	defer func() {
+		activeModelStateOwnerLock.Lock()
+		activeModelState, activeModelStateOwner = nil, 0
+		activeModelStateOwnerLock.Unlock()
+	}()
+	ParsedModelRoot = state.ParsedModel
+	CommunicationLinks = state.CommunicationLinks
+	IncomingTechnicalCommunicationLinksMappedByTargetId = state.IncomingTechnicalCommunicationLinksMappedByTargetId
+	DirectContainingTrustBoundaryMappedByTechnicalAssetId = state.DirectContainingTrustBoundaryMappedByTechnicalAssetId
+	DirectContainingSharedRuntimeMappedByTechnicalAssetId = state.DirectContainingSharedRuntimeMappedByTechnicalAssetId
+	GeneratedRisksByCategory = state.GeneratedRisksByCategory
+	GeneratedRisksBySyntheticId = state.GeneratedRisksBySyntheticId
+	AllSupportedTags = state.AllSupportedTags
+	defer func() { // also when the action panics, as the state is still needed to report about it
+		state.ParsedModel = ParsedModelRoot
+		state.CommunicationLinks = CommunicationLinks
+		state.IncomingTechnicalCommunicationLinksMappedByTargetId = IncomingTechnicalCommunicationLinksMappedByTargetId
+		state.DirectContainingTrustBoundaryMappedByTechnicalAssetId = DirectContainingTrustBoundaryMappedByTechnicalAssetId
+		state.DirectContainingSharedRuntimeMappedByTechnicalAssetId = DirectContainingSharedRuntimeMappedByTechnicalAssetId
+		state.GeneratedRisksByCategory = GeneratedRisksByCategory
+		state.GeneratedRisksBySyntheticId = GeneratedRisksBySyntheticId
+		state.AllSupportedTags = AllSupportedTags
+	}()
+	action()
+}
+
+// the id of the current goroutine, parsed from the first line of its stack trace (like "goroutine 42 [running]:"), as
+// Go offers no other way to tell whether a nested call comes from the goroutine owning the active model state
+func currentGoroutineId() uint64 {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+	fields := strings.Fields(string(buffer))
+	if len(fields) < 2 {
+		panic(errors.New("unable to determine the current goroutine"))
+	}
+	id, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		panic(errors.New("unable to determine the current goroutine: " + err.Error()))
+	}
+	return id
+}
@@ -74,6 +74,7 @@ func MakeID(val string) string {
 
 type ModelInput struct { // TODO:Eventualmente, remova isso e use diretamente o ParseedModelroot?Mas então as mensagens de erro para erros de modelo não são mais bem .....
 	Threagile_version                                  string
+	Includes                                           []string
 	Title                                              string
 	Author                                             Author
 	Date                                               string
@@ -91,6 +92,7 @@ type ModelInput struct { // TODO:Eventualmente, remova isso e use diretamente o
 	Shared_runtimes                                    map[string]InputSharedRuntime
 	Individual_risk_categories                         map[string]InputIndividualRiskCategory
 	Risk_tracking                                      map[string]InputRiskTracking
+	Compliance_mappings                                map[string]map[string][]string
 	Diagram_tweak_nodesep, Diagram_tweak_ranksep       int
 	Diagram_tweak_edge_layout                          string
 	Diagram_tweak_suppress_edge_labels                 bool
@@ -140,6 +142,9 @@ type InputTechnicalAsset struct {
 	Data_formats_accepted      []string                          `json:"data_formats_accepted"`
 	Diagram_tweak_order        int                               `json:"diagram_tweak_order"`
 	Communication_links        map[string]InputCommunicationLink `json:"communication_links"`
+	Vulnerability_scans        []string                          `json:"vulnerability_scans"`
+	Sourcecode_repositories    []string                          `json:"sourcecode_repositories"`
+	Hosts                      []string                          `json:"hosts"`
 }
 
 type InputCommunicationLink struct {
@@ -191,6 +196,7 @@ type InputIndividualRiskCategory struct {
 	False_positives               string                         `json:"false_positives"`
 	Model_failure_possible_reason bool                           `json:"model_failure_possible_reason"`
 	CWE                           int                            `json:"cwe"`
+	Compliance                    map[string][]string            `json:"compliance"`
 	Risks_identified              map[string]InputRiskIdentified `json:"risks_identified"`
 }
 
@@ -1316,6 +1322,9 @@ type TechnicalAsset struct {
 	DataFormatsAccepted                                                                     []DataFormat
 	CommunicationLinks                                                                      []CommunicationLink
 	DiagramTweakOrder                                                                       int
+	KnownVulnerabilities                                                                    []KnownVulnerability
+	SourcecodeRepositories                                                                  []string
+	Hosts                                                                                   []string // host names, IP addresses or CIDR ranges
 	// will be set by separate calculation step:
 	RAA float64
 }
@@ -1942,6 +1951,12 @@ type ParsedModel struct {
 	SharedRuntimes                                map[string]SharedRuntime
 	IndividualRiskCategories                      map[string]RiskCategory
 	RiskTracking                                  map[string]RiskTracking
+	ComplianceMappings                            map[string]map[string][]string // as given by the model (by risk category id and framework)
+	ComplianceControls                            map[string]map[string][]string // of all risk categories checked (by risk category id and framework)
+	BaselineRisks                                 []BaselineRisk                 // of the previous run to compare against (nil when none given)
+	BaselineRisksBySyntheticId                    map[string]BaselineRisk        // the baseline risks by their lower-cased synthetic id
+	SASTFindings                                  []SASTFinding                  // read from the SARIF files given (nil when none given)
+	ObservedFlows                                 []ObservedFlow                 // read from the flow log given (nil when none given)
 	DiagramTweakNodesep, DiagramTweakRanksep      int
 	DiagramTweakEdgeLayout                        string
 	DiagramTweakSuppressEdgeLabels                bool
@@ -2679,6 +2694,16 @@ func RiskSeverityValues() []TypeEnum {
 	}
 }
 
+func ParseRiskSeverity(value string) (severity RiskSeverity, err error) {
+	value = strings.TrimSpace(value)
+	for _, candidate := range RiskSeverityValues() {
+		if candidate.String() == value {
+			return candidate.(RiskSeverity), err
+		}
+	}
+	return severity, errors.New("Unable to parse into type: " + value)
+}
+
 func (what RiskSeverity) String() string {
 	// NOTE: maintain list also in schema.json for validation in IDEs
 	return [...]string{"low", "medium", "elevated", "high", "critical"}[what]
@@ -2946,6 +2971,7 @@ type Risk struct {
 	MostRelevantCommunicationLinkId string                     `json:"most_relevant_communication_link"`
 	DataBreachProbability           DataBreachProbability      `json:"data_breach_probability"`
 	DataBreachTechnicalAssetIDs     []string                   `json:"data_breach_technical_assets"`
+	SASTFindings                    []SASTFinding              `json:"sast_findings,omitempty"` // confirming the risk, assigned when SARIF files are given
 	// TODO: refactor all "Id" here to "ID"?
 }
 
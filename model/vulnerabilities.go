@@ -0,0 +1,353 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// KnownVulnerability is a vulnerable component of a technical asset as reported by an SBOM or vulnerability scan file
+// referenced via "vulnerability_scans" of the technical asset
+type KnownVulnerability struct {
+	Id               string  `json:"id"` // like CVE-2021-44228 or GHSA-jfh8-c2jp-5v3q
+	Component        string  `json:"component"`
+	Version          string  `json:"version,omitempty"`
+	FixedVersion     string  `json:"fixed_version,omitempty"`
+	CVSS             float64 `json:"cvss,omitempty"`     // the highest CVSS base score reported (zero when unknown)
+	ReportedSeverity string  `json:"severity,omitempty"` // as reported by the scanner (used when no CVSS score is known)
+	Title            string  `json:"title,omitempty"`
+	URL              string  `json:"url,omitempty"`
+	Source           string  `json:"source"` // the scan file reporting it
+}
+
+// Score returns the CVSS base score or else an approximation of it derived from the reported severity
+func (what KnownVulnerability) Score() float64 {
+	if what.CVSS > 0 {
+		return what.CVSS
+	}
+	switch strings.ToLower(what.ReportedSeverity) {
+	case "critical":
+		return 9.0
+	case "high":
+		return 7.0
+	case "medium", "moderate":
+		return 4.0
+	case "low":
+		return 0.1
+	}
+	return 0
+}
+
+// ComponentTitle returns the component name along with its version (when known)
+func (what KnownVulnerability) ComponentTitle() string {
+	if len(what.Version) > 0 {
+		return what.Component + " " + what.Version
+	}
+	return what.Component
+}
+
+var nonComponentIdCharacters = regexp.MustCompile("[^a-z0-9._/-]+")
+
+// ComponentId returns the component name as used within synthetic risk IDs: lower-cased (as scanners differ in the
+// case of names), with characters other than letters, digits, dots, dashes, underscores and slashes replaced by dashes
+func (what KnownVulnerability) ComponentId() string {
+	return strings.Trim(nonComponentIdCharacters.ReplaceAllString(strings.ToLower(what.Component), "-"), "-")
+}
+
+// SortedKnownVulnerabilitiesByComponent groups the known vulnerabilities of the technical asset by their component
+// (sorted by component id, so that the names reported differently by several scanners end up in the same group), each
+// sorted descending by score
+func (what TechnicalAsset) SortedKnownVulnerabilitiesByComponent() [][]KnownVulnerability {
+	byComponent := make(map[string][]KnownVulnerability)
+	components := make([]string, 0)
+	for _, vulnerability := range what.KnownVulnerabilities {
+		key := vulnerability.ComponentId()
+		if _, exists := byComponent[key]; !exists {
+			components = append(components, key)
+		}
+		byComponent[key] = append(byComponent[key], vulnerability)
+	}
+	sort.Strings(components)
+	result := make([][]KnownVulnerability, 0, len(components))
+	for _, component := range components {
+		vulnerabilities := byComponent[component]
+		sort.SliceStable(vulnerabilities, func(i, j int) bool {
+			if vulnerabilities[i].Score() != vulnerabilities[j].Score() {
+				return vulnerabilities[i].Score() > vulnerabilities[j].Score()
+			}
+			return vulnerabilities[i].Id < vulnerabilities[j].Id
+		})
+		result = append(result, vulnerabilities)
+	}
+	return result
+}
+
+// ParseVulnerabilityScan reads the vulnerabilities of a CycloneDX (SBOM with vulnerabilities), Trivy or Grype JSON file
+func ParseVulnerabilityScan(content []byte, source string) ([]KnownVulnerability, error) {
+	var probe struct {
+		BomFormat string          `json:"bomFormat"`
+		Results   json.RawMessage `json:"Results"`
+		Matches   json.RawMessage `json:"matches"`
+	}
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return nil, err
+	}
+	var result []KnownVulnerability
+	var err error
+	switch {
+	case probe.BomFormat == "CycloneDX":
+		result, err = parseCycloneDXVulnerabilities(content, source)
+	case probe.Results != nil:
+		result, err = parseTrivyVulnerabilities(content, source)
+	case probe.Matches != nil:
+		result, err = parseGrypeVulnerabilities(content, source)
+	default:
+		return nil, errors.New("unsupported format (expected CycloneDX, Trivy or Grype JSON)")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return UniqueKnownVulnerabilities(result), nil
+}
+
+type cycloneDXComponent struct {
+	BomRef     string               `json:"bom-ref"`
+	Name       string               `json:"name"`
+	Group      string               `json:"group"`
+	Version    string               `json:"version"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDXVulnerabilities(content []byte, source string) ([]KnownVulnerability, error) {
+	var bom struct {
+		Metadata struct {
+			Component *cycloneDXComponent `json:"component"`
+		} `json:"metadata"`
+		Components      []cycloneDXComponent `json:"components"`
+		Vulnerabilities []struct {
+			Id          string `json:"id"`
+			Description string `json:"description"`
+			Source      struct {
+				URL string `json:"url"`
+			} `json:"source"`
+			Ratings []struct {
+				Score    float64 `json:"score"`
+				Severity string  `json:"severity"`
+				Method   string  `json:"method"`
+			} `json:"ratings"`
+			Affects []struct {
+				Ref string `json:"ref"`
+			} `json:"affects"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(content, &bom); err != nil {
+		return nil, err
+	}
+	componentsByRef := make(map[string]cycloneDXComponent)
+	var collect func(components []cycloneDXComponent)
+	collect = func(components []cycloneDXComponent) {
+		for _, component := range components {
+			if len(component.BomRef) > 0 {
+				componentsByRef[component.BomRef] = component
+			}
+			collect(component.Components)
+		}
+	}
+	if bom.Metadata.Component != nil {
+		collect([]cycloneDXComponent{*bom.Metadata.Component})
+	}
+	collect(bom.Components)
+	result := make([]KnownVulnerability, 0)
+	for _, vulnerability := range bom.Vulnerabilities {
+		var cvss float64
+		severity := ""
+		for _, rating := range vulnerability.Ratings {
+			if strings.HasPrefix(strings.ToUpper(rating.Method), "CVSS") && rating.Score > cvss {
+				cvss = rating.Score
+			}
+			if len(severity) == 0 || severityRank(rating.Severity) > severityRank(severity) {
+				severity = rating.Severity
+			}
+		}
+		for _, affected := range vulnerability.Affects {
+			name, version := affected.Ref, ""
+			if component, found := componentsByRef[affected.Ref]; found {
+				name, version = component.Name, component.Version
+				if len(component.Group) > 0 {
+					name = component.Group + "/" + component.Name
+				}
+			}
+			result = append(result, KnownVulnerability{
+				Id:               vulnerability.Id,
+				Component:        name,
+				Version:          version,
+				CVSS:             cvss,
+				ReportedSeverity: severity,
+				Title:            firstLine(vulnerability.Description),
+				URL:              vulnerability.Source.URL,
+				Source:           source,
+			})
+		}
+	}
+	return result, nil
+}
+
+func parseTrivyVulnerabilities(content []byte, source string) ([]KnownVulnerability, error) {
+	var report struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+				Title            string `json:"Title"`
+				PrimaryURL       string `json:"PrimaryURL"`
+				CVSS             map[string]struct {
+					V2Score float64 `json:"V2Score"`
+					V3Score float64 `json:"V3Score"`
+				} `json:"CVSS"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, err
+	}
+	result := make([]KnownVulnerability, 0)
+	for _, target := range report.Results {
+		for _, vulnerability := range target.Vulnerabilities {
+			var cvss, cvssV2 float64
+			for _, score := range vulnerability.CVSS {
+				if score.V3Score > cvss {
+					cvss = score.V3Score
+				}
+				if score.V2Score > cvssV2 {
+					cvssV2 = score.V2Score
+				}
+			}
+			if cvss == 0 {
+				cvss = cvssV2
+			}
+			result = append(result, KnownVulnerability{
+				Id:               vulnerability.VulnerabilityID,
+				Component:        vulnerability.PkgName,
+				Version:          vulnerability.InstalledVersion,
+				FixedVersion:     vulnerability.FixedVersion,
+				CVSS:             cvss,
+				ReportedSeverity: vulnerability.Severity,
+				Title:            vulnerability.Title,
+				URL:              vulnerability.PrimaryURL,
+				Source:           source,
+			})
+		}
+	}
+	return result, nil
+}
+
+type grypeVulnerability struct {
+	Id         string `json:"id"`
+	DataSource string `json:"dataSource"`
+	Severity   string `json:"severity"`
+	CVSS       []struct {
+		Version string `json:"version"`
+		Metrics struct {
+			BaseScore float64 `json:"baseScore"`
+		} `json:"metrics"`
+	} `json:"cvss"`
+	Description string `json:"description"`
+}
+
+func (what grypeVulnerability) highestCVSS() float64 {
+	var result float64
+	for _, cvss := range what.CVSS {
+		if cvss.Metrics.BaseScore > result {
+			result = cvss.Metrics.BaseScore
+		}
+	}
+	return result
+}
+
+func parseGrypeVulnerabilities(content []byte, source string) ([]KnownVulnerability, error) {
+	var report struct {
+		Matches []struct {
+			Vulnerability struct {
+				grypeVulnerability
+				Fix struct {
+					Versions []string `json:"versions"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			RelatedVulnerabilities []grypeVulnerability `json:"relatedVulnerabilities"`
+			Artifact               struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"artifact"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		return nil, err
+	}
+	result := make([]KnownVulnerability, 0)
+	for _, match := range report.Matches {
+		vulnerability := match.Vulnerability
+		cvss := vulnerability.highestCVSS()
+		title := firstLine(vulnerability.Description)
+		for _, related := range match.RelatedVulnerabilities { // GHSA matches often carry the CVSS score in the related NVD entry only
+			if score := related.highestCVSS(); score > cvss {
+				cvss = score
+			}
+			if len(title) == 0 {
+				title = firstLine(related.Description)
+			}
+		}
+		result = append(result, KnownVulnerability{
+			Id:               vulnerability.Id,
+			Component:        match.Artifact.Name,
+			Version:          match.Artifact.Version,
+			FixedVersion:     strings.Join(vulnerability.Fix.Versions, ", "),
+			CVSS:             cvss,
+			ReportedSeverity: vulnerability.Severity,
+			Title:            title,
+			URL:              vulnerability.DataSource,
+			Source:           source,
+		})
+	}
+	return result, nil
+}
+
+// UniqueKnownVulnerabilities removes duplicates, as scanners report the same vulnerability multiple times when a
+// component is found at several places (like in different image layers) and scan files might overlap
+func UniqueKnownVulnerabilities(vulnerabilities []KnownVulnerability) []KnownVulnerability {
+	result := make([]KnownVulnerability, 0, len(vulnerabilities))
+	seen := make(map[string]bool)
+	for _, vulnerability := range vulnerabilities {
+		if len(vulnerability.Id) == 0 || len(vulnerability.Component) == 0 {
+			continue
+		}
+		key := vulnerability.Id + "@" + vulnerability.ComponentTitle()
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, vulnerability)
+		}
+	}
+	return result
+}
+
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "medium", "moderate":
+		return 2
+	case "low":
+		return 1
+	}
+	return 0
+}
+
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
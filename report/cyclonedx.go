@@ -0,0 +1,432 @@
+package report
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/threagile/threagile/model"
+)
+
+const cycloneDXSpecVersion = "1.5"
+
+type cdxBom struct {
+	BomFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	SerialNumber    string             `json:"serialNumber"`
+	Version         int                `json:"version"`
+	Metadata        cdxMetadata        `json:"metadata"`
+	Components      []cdxComponent     `json:"components"`
+	Services        []cdxService       `json:"services"`
+	Dependencies    []cdxDependency    `json:"dependencies"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxMetadata struct {
+	Timestamp string            `json:"timestamp"`
+	Tools     cdxTools          `json:"tools"`
+	Authors   []cdxContact      `json:"authors,omitempty"`
+	Component cdxComponent      `json:"component"`
+	Lifecycle []cdxLifecycle    `json:"lifecycles"`
+	Property  []cdxNameAndValue `json:"properties,omitempty"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxContact struct {
+	Name string `json:"name"`
+}
+
+type cdxLifecycle struct {
+	Phase string `json:"phase"`
+}
+
+type cdxComponent struct {
+	BomRef      string             `json:"bom-ref,omitempty"`
+	Type        string             `json:"type"`
+	Name        string             `json:"name"`
+	Version     string             `json:"version,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Data        []cdxComponentData `json:"data,omitempty"`
+	Properties  []cdxNameAndValue  `json:"properties,omitempty"`
+}
+
+type cdxComponentData struct {
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+	Description    string `json:"description,omitempty"`
+	Classification string `json:"classification"`
+}
+
+type cdxService struct {
+	BomRef          string            `json:"bom-ref"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	Authenticated   *bool             `json:"authenticated,omitempty"`
+	CrossesBoundary *bool             `json:"x-trust-boundary,omitempty"`
+	TrustZone       string            `json:"trustZone,omitempty"`
+	Data            []cdxServiceData  `json:"data,omitempty"`
+	Properties      []cdxNameAndValue `json:"properties,omitempty"`
+}
+
+type cdxServiceData struct {
+	Flow           string   `json:"flow"` // inbound, outbound, bi-directional, or unknown
+	Classification string   `json:"classification"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	Source         []string `json:"source"`
+	Destination    []string `json:"destination"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cdxVulnerability struct {
+	BomRef         string            `json:"bom-ref"`
+	Id             string            `json:"id"`
+	Source         cdxSource         `json:"source"`
+	Ratings        []cdxRating       `json:"ratings"`
+	Cwes           []int             `json:"cwes,omitempty"`
+	Description    string            `json:"description"`
+	Detail         string            `json:"detail"`
+	Recommendation string            `json:"recommendation"`
+	Advisories     []cdxAdvisory     `json:"advisories,omitempty"`
+	Analysis       cdxAnalysis       `json:"analysis"`
+	Affects        []cdxAffect       `json:"affects"`
+	Properties     []cdxNameAndValue `json:"properties"`
+}
+
+type cdxSource struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type cdxRating struct {
+	Source        cdxSource `json:"source"`
+	Severity      string    `json:"severity"`
+	Method        string    `json:"method"`
+	Justification string    `json:"justification,omitempty"`
+}
+
+type cdxAdvisory struct {
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+type cdxAnalysis struct {
+	State    string   `json:"state"`
+	Response []string `json:"response,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type cdxAffect struct {
+	Ref string `json:"ref"`
+}
+
+type cdxNameAndValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var cdxThreagile = cdxSource{Name: "Threagile", Url: "https://threagile.io"}
+
+// WriteCycloneDX writes the model as CycloneDX 1.5 BOM: the server-side technical assets become services (having their
+// communication links as data flows classified by the confidentiality of the data assets transferred, and their trust
+// boundary as trust zone), all other technical assets become components, and the data assets become data components,
+// while the risks become vulnerabilities (with their CWE) affecting their most relevant technical assets
+func WriteCycloneDX(filename string) {
+	components, services, dependencies := make([]cdxComponent, 0), make([]cdxService, 0), make([]cdxDependency, 0)
+	for _, asset := range model.SortedTechnicalAssetsByTitle() {
+		if isCycloneDXService(asset) {
+			services = append(services, cycloneDXService(asset))
+		} else {
+			components = append(components, cycloneDXComponent(asset))
+		}
+		dependsOn := make([]string, 0)
+		for _, link := range asset.CommunicationLinks {
+			if ref := cycloneDXRef("technical-asset", link.TargetId); !model.Contains(dependsOn, ref) {
+				dependsOn = append(dependsOn, ref)
+			}
+		}
+		sort.Strings(dependsOn)
+		dependencies = append(dependencies, cdxDependency{Ref: cycloneDXRef("technical-asset", asset.Id), DependsOn: dependsOn})
+	}
+	for _, dataAsset := range model.SortedDataAssetsByTitle() {
+		components = append(components, cdxComponent{
+			BomRef:      cycloneDXRef("data-asset", dataAsset.Id),
+			Type:        "data",
+			Name:        dataAsset.Title,
+			Description: removeFormattingTags(dataAsset.Description),
+			Data: []cdxComponentData{{
+				Type:           "dataset",
+				Name:           dataAsset.Title,
+				Description:    removeFormattingTags(dataAsset.Description),
+				Classification: dataAsset.Confidentiality.String(),
+			}},
+			Properties: []cdxNameAndValue{
+				{Name: "threagile:usage", Value: dataAsset.Usage.String()},
+				{Name: "threagile:quantity", Value: dataAsset.Quantity.String()},
+				{Name: "threagile:confidentiality", Value: dataAsset.Confidentiality.String()},
+				{Name: "threagile:integrity", Value: dataAsset.Integrity.String()},
+				{Name: "threagile:availability", Value: dataAsset.Availability.String()},
+			},
+		})
+	}
+	authors := make([]cdxContact, 0)
+	if len(model.ParsedModelRoot.Author.Name) > 0 {
+		authors = append(authors, cdxContact{Name: model.ParsedModelRoot.Author.Name})
+	}
+	timestamp := model.ParsedModelRoot.Date
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	jsonBytes, err := json.MarshalIndent(cdxBom{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + uuid.New().String(), // a new BOM with each run, as the model might have changed
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools:     cdxTools{Components: []cdxComponent{{Type: "application", Name: "Threagile", Version: model.ThreagileVersion}}},
+			Authors:   authors,
+			Component: cdxComponent{
+				BomRef:      cycloneDXRef("model", model.MakeID(model.ParsedModelRoot.Title)),
+				Type:        "application",
+				Name:        model.ParsedModelRoot.Title,
+				Description: removeFormattingTags(model.ParsedModelRoot.BusinessOverview.Description),
+			},
+			Lifecycle: []cdxLifecycle{{Phase: "design"}},
+			Property:  []cdxNameAndValue{{Name: "threagile:business-criticality", Value: model.ParsedModelRoot.BusinessCriticality.String()}},
+		},
+		Components:      components,
+		Services:        services,
+		Dependencies:    dependencies,
+		Vulnerabilities: cycloneDXVulnerabilities(),
+	}, "", "  ")
+	checkErr(err)
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	checkErr(err)
+}
+
+// isCycloneDXService tells whether the technical asset is offering a service (being a server-side process)
+func isCycloneDXService(asset model.TechnicalAsset) bool {
+	return asset.Type == model.Process && !asset.Technology.IsClient() && asset.Technology != model.Library
+}
+
+func cycloneDXService(asset model.TechnicalAsset) cdxService {
+	service := cdxService{
+		BomRef:      cycloneDXRef("technical-asset", asset.Id),
+		Name:        asset.Title,
+		Description: removeFormattingTags(asset.Description),
+		Data:        make([]cdxServiceData, 0),
+		Properties:  cycloneDXProperties(asset),
+	}
+	if trustBoundaryId := asset.GetTrustBoundaryId(); len(trustBoundaryId) > 0 {
+		service.TrustZone = model.ParsedModelRoot.TrustBoundaries[trustBoundaryId].Title
+	}
+	incomingLinks := model.IncomingTechnicalCommunicationLinksMappedByTargetId[asset.Id]
+	if len(incomingLinks) > 0 {
+		authenticated, crossesBoundary := true, false
+		for _, link := range incomingLinks {
+			authenticated = authenticated && link.Authentication != model.NoneAuthentication
+			crossesBoundary = crossesBoundary || link.IsAcrossTrustBoundary()
+		}
+		service.Authenticated, service.CrossesBoundary = &authenticated, &crossesBoundary
+	}
+	// the data flows as seen by the service: data sent via outgoing links and received via incoming links is outbound
+	for _, link := range asset.CommunicationLinks {
+		service.Data = append(service.Data, cycloneDXDataFlows(link, "outbound", "inbound")...)
+	}
+	for _, link := range incomingLinks {
+		service.Data = append(service.Data, cycloneDXDataFlows(link, "inbound", "outbound")...)
+	}
+	return service
+}
+
+// cycloneDXDataFlows returns the data flows of the data assets sent and received via the link (or a single one of
+// unknown classification when none are given), using the flow directions given for each of them
+func cycloneDXDataFlows(link model.CommunicationLink, flowOfSent, flowOfReceived string) []cdxServiceData {
+	source, target := cycloneDXRef("technical-asset", link.SourceId), cycloneDXRef("technical-asset", link.TargetId)
+	sourceTitle := model.ParsedModelRoot.TechnicalAssets[link.SourceId].Title
+	result := make([]cdxServiceData, 0)
+	for _, dataAsset := range link.DataAssetsSentSorted() {
+		result = append(result, cdxServiceData{
+			Flow:           flowOfSent,
+			Classification: dataAsset.Confidentiality.String(),
+			Name:           sourceTitle + " / " + link.Title + ": " + dataAsset.Title,
+			Description:    removeFormattingTags(link.Description) + " (" + link.Protocol.String() + ")",
+			Source:         []string{source},
+			Destination:    []string{target},
+		})
+	}
+	for _, dataAsset := range link.DataAssetsReceivedSorted() {
+		result = append(result, cdxServiceData{
+			Flow:           flowOfReceived,
+			Classification: dataAsset.Confidentiality.String(),
+			Name:           sourceTitle + " / " + link.Title + ": " + dataAsset.Title,
+			Description:    removeFormattingTags(link.Description) + " (" + link.Protocol.String() + ")",
+			Source:         []string{target},
+			Destination:    []string{source},
+		})
+	}
+	if len(result) == 0 {
+		result = append(result, cdxServiceData{
+			Flow:           "unknown",
+			Classification: "unknown",
+			Name:           sourceTitle + " / " + link.Title,
+			Description:    removeFormattingTags(link.Description) + " (" + link.Protocol.String() + ")",
+			Source:         []string{source},
+			Destination:    []string{target},
+		})
+	}
+	return result
+}
+
+func cycloneDXComponent(asset model.TechnicalAsset) cdxComponent {
+	componentType := "application"
+	switch asset.Technology {
+	case model.Library:
+		componentType = "library"
+	case model.IoTDevice, model.HSM, model.Mainframe:
+		componentType = "device"
+	case model.ContainerPlatform, model.BigDataPlatform:
+		componentType = "platform"
+	case model.AI:
+		componentType = "machine-learning-model"
+	}
+	return cdxComponent{
+		BomRef:      cycloneDXRef("technical-asset", asset.Id),
+		Type:        componentType,
+		Name:        asset.Title,
+		Description: removeFormattingTags(asset.Description),
+		Properties:  cycloneDXProperties(asset),
+	}
+}
+
+func cycloneDXProperties(asset model.TechnicalAsset) []cdxNameAndValue {
+	result := []cdxNameAndValue{
+		{Name: "threagile:type", Value: asset.Type.String()},
+		{Name: "threagile:technology", Value: asset.Technology.String()},
+		{Name: "threagile:machine", Value: asset.Machine.String()},
+		{Name: "threagile:internet", Value: strconv.FormatBool(asset.Internet)},
+		{Name: "threagile:out-of-scope", Value: strconv.FormatBool(asset.OutOfScope)},
+		{Name: "threagile:confidentiality", Value: asset.Confidentiality.String()},
+		{Name: "threagile:integrity", Value: asset.Integrity.String()},
+		{Name: "threagile:availability", Value: asset.Availability.String()},
+		{Name: "threagile:raa", Value: strconv.FormatFloat(asset.RAA, 'f', 0, 64)},
+	}
+	if len(asset.Owner) > 0 {
+		result = append(result, cdxNameAndValue{Name: "threagile:owner", Value: asset.Owner})
+	}
+	for _, tag := range asset.Tags {
+		result = append(result, cdxNameAndValue{Name: "threagile:tag", Value: tag})
+	}
+	return result
+}
+
+func cycloneDXVulnerabilities() []cdxVulnerability {
+	result := make([]cdxVulnerability, 0)
+	for _, category := range model.SortedRiskCategories() {
+		for _, risk := range model.SortedRisksOfCategory(category) {
+			riskTracking := risk.GetRiskTracking()
+			vulnerability := cdxVulnerability{
+				BomRef:         cycloneDXRef("risk", risk.SyntheticId),
+				Id:             risk.SyntheticId,
+				Source:         cdxThreagile,
+				Ratings:        []cdxRating{{Source: cdxThreagile, Severity: cycloneDXSeverity(risk.Severity), Method: "other"}},
+				Description:    removeFormattingTags(category.Title + ": " + category.Description),
+				Detail:         removeFormattingTags(risk.Title),
+				Recommendation: removeFormattingTags(category.Mitigation),
+				Analysis:       cycloneDXAnalysis(risk.GetRiskTrackingStatusDefaultingUnchecked(), riskTracking.Justification),
+				Affects:        make([]cdxAffect, 0),
+				Properties: []cdxNameAndValue{
+					{Name: "threagile:category", Value: category.Id},
+					{Name: "threagile:stride", Value: category.STRIDE.String()},
+					{Name: "threagile:severity", Value: risk.Severity.String()},
+					{Name: "threagile:exploitation-likelihood", Value: risk.ExploitationLikelihood.String()},
+					{Name: "threagile:exploitation-impact", Value: risk.ExploitationImpact.String()},
+					{Name: "threagile:data-breach-probability", Value: risk.DataBreachProbability.String()},
+					{Name: "threagile:risk-status", Value: risk.GetRiskTrackingStatusDefaultingUnchecked().String()},
+				},
+			}
+			if category.CWE > 0 {
+				vulnerability.Cwes = []int{category.CWE}
+			}
+			if len(category.CheatSheet) > 0 {
+				vulnerability.Advisories = []cdxAdvisory{{Title: category.Title, Url: category.CheatSheet}}
+			}
+			if len(riskTracking.Ticket) > 0 {
+				vulnerability.Properties = append(vulnerability.Properties, cdxNameAndValue{Name: "threagile:ticket", Value: riskTracking.Ticket})
+			}
+			for _, ref := range cycloneDXAffectedRefs(risk) {
+				vulnerability.Affects = append(vulnerability.Affects, cdxAffect{Ref: ref})
+			}
+			result = append(result, vulnerability)
+		}
+	}
+	return result
+}
+
+// cycloneDXAffectedRefs returns the technical assets affected by the risk: the most relevant one (or the source of the
+// most relevant communication link), or else the ones inside the most relevant trust boundary or shared runtime, or
+// else the most relevant data asset
+func cycloneDXAffectedRefs(risk model.Risk) []string {
+	assetIds := make([]string, 0)
+	if _, exists := model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; exists {
+		assetIds = append(assetIds, risk.MostRelevantTechnicalAssetId)
+	} else if link, exists := model.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; exists {
+		assetIds = append(assetIds, link.SourceId)
+	} else if trustBoundary, exists := model.ParsedModelRoot.TrustBoundaries[risk.MostRelevantTrustBoundaryId]; exists {
+		assetIds = append(assetIds, trustBoundary.RecursivelyAllTechnicalAssetIDsInside()...)
+	} else if sharedRuntime, exists := model.ParsedModelRoot.SharedRuntimes[risk.MostRelevantSharedRuntimeId]; exists {
+		assetIds = append(assetIds, sharedRuntime.TechnicalAssetsRunning...)
+	} else if _, exists := model.ParsedModelRoot.DataAssets[risk.MostRelevantDataAssetId]; exists {
+		return []string{cycloneDXRef("data-asset", risk.MostRelevantDataAssetId)}
+	}
+	sort.Strings(assetIds)
+	result := make([]string, 0)
+	for _, assetId := range assetIds {
+		result = append(result, cycloneDXRef("technical-asset", assetId))
+	}
+	return result
+}
+
+func cycloneDXAnalysis(status model.RiskStatus, justification string) cdxAnalysis {
+	analysis := cdxAnalysis{Detail: justification}
+	switch status {
+	case model.Mitigated:
+		analysis.State = "resolved"
+	case model.FalsePositive:
+		analysis.State = "false_positive"
+	case model.Accepted:
+		analysis.State, analysis.Response = "exploitable", []string{"will_not_fix"}
+	case model.InProgress:
+		analysis.State, analysis.Response = "exploitable", []string{"update"}
+	default: // unchecked or in discussion
+		analysis.State = "in_triage"
+	}
+	return analysis
+}
+
+func cycloneDXSeverity(severity model.RiskSeverity) string {
+	switch severity {
+	case model.CriticalSeverity:
+		return "critical"
+	case model.HighSeverity, model.ElevatedSeverity:
+		return "high"
+	case model.MediumSeverity:
+		return "medium"
+	}
+	return "low"
+}
+
+func cycloneDXRef(kind, id string) string {
+	return kind + ":" + id
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+func WriteModelDiffJSON(filename string, diff model.ModelDiff) {
+	jsonBytes, err := json.Marshal(diff)
+	checkErr(err)
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	checkErr(err)
+}
+
+// WriteModelDiffMarkdown renders the diff as Markdown tables (one per kind of model element and one for the risks)
+func WriteModelDiffMarkdown(filename string, diff model.ModelDiff) {
+	var markdown strings.Builder
+	markdown.WriteString("# Model Diff\n\n")
+	if diff.OldTitle != diff.NewTitle {
+		markdown.WriteString("Comparing **" + escapeMarkdown(diff.OldTitle) + "** with **" + escapeMarkdown(diff.NewTitle) + "**.\n\n")
+	} else {
+		markdown.WriteString("Comparing two versions of **" + escapeMarkdown(diff.NewTitle) + "**.\n\n")
+	}
+	if diff.IsEmpty() {
+		markdown.WriteString("No semantic changes.\n")
+	}
+	writeElementDiffsMarkdown(&markdown, "Technical Assets", diff.TechnicalAssets)
+	writeElementDiffsMarkdown(&markdown, "Communication Links", diff.CommunicationLinks)
+	writeElementDiffsMarkdown(&markdown, "Data Assets", diff.DataAssets)
+	writeElementDiffsMarkdown(&markdown, "Trust Boundaries", diff.TrustBoundaries)
+	writeElementDiffsMarkdown(&markdown, "Shared Runtimes", diff.SharedRuntimes)
+	if len(diff.Risks) > 0 {
+		markdown.WriteString("## Risks (" + strconv.Itoa(len(diff.Risks)) + ")\n\n")
+		markdown.WriteString("| Change | Severity | Old Severity | Risk | ID |\n")
+		markdown.WriteString("|---|---|---|---|---|\n")
+		for _, delta := range diff.Risks {
+			markdown.WriteString("| " + delta.Status.Title() + " | " + delta.Severity + " | " + delta.BaselineSeverity + " | " +
+				escapeMarkdown(removeFormattingTags(delta.Title)) + " | `" + delta.SyntheticId + "` |\n")
+		}
+		markdown.WriteString("\n")
+	}
+	err := ioutil.WriteFile(filename, []byte(markdown.String()), 0644)
+	checkErr(err)
+}
+
+func writeElementDiffsMarkdown(markdown *strings.Builder, kind string, diffs []model.ElementDiff) {
+	if len(diffs) == 0 {
+		return
+	}
+	markdown.WriteString("## " + kind + " (" + strconv.Itoa(len(diffs)) + ")\n\n")
+	markdown.WriteString("| Change | ID | Title | Field | Old Value | New Value |\n")
+	markdown.WriteString("|---|---|---|---|---|---|\n")
+	for _, diff := range diffs {
+		element := "| " + diff.Change.Title() + " | `" + diff.Id + "` | " + escapeMarkdown(diff.Title) + " | "
+		if len(diff.Fields) == 0 {
+			markdown.WriteString(element + " |  |  |\n")
+		}
+		for _, field := range diff.Fields {
+			markdown.WriteString(element + field.Field + " | " + escapeMarkdown(field.OldValue) + " | " + escapeMarkdown(field.NewValue) + " |\n")
+		}
+	}
+	markdown.WriteString("\n")
+}
+
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ", "*", "\\*", "_", "\\_").Replace(text)
+}
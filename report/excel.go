@@ -211,11 +211,75 @@ func WriteRisksExcelToFile(filename string) {
 	err = excel.SetCellStyle(sheetName, "A1", "T1", styleHeadCenter)
 	checkErr(err)
 
+	if model.HasBaseline() {
+		writeRiskDeltaSheet(excel)
+	}
+
 	excel.SetActiveSheet(sheetIndex)
 	err = excel.SaveAs(filename)
 	checkErr(err)
 }
 
+// writeRiskDeltaSheet adds a sheet classifying the risks compared to the baseline
+func writeRiskDeltaSheet(excel *excelize.File) {
+	sheetName := "Delta since Baseline"
+	excel.NewSheet(sheetName)
+	err := excel.SetPageLayout(sheetName,
+		excelize.PageLayoutOrientation(excelize.OrientationLandscape),
+		excelize.PageLayoutPaperSize(9)) // A4
+	checkErr(err)
+
+	err = excel.SetCellValue(sheetName, "A1", "Delta")
+	err = excel.SetCellValue(sheetName, "B1", "Severity")
+	err = excel.SetCellValue(sheetName, "C1", "Baseline Severity")
+	err = excel.SetCellValue(sheetName, "D1", "Risk Category")
+	err = excel.SetCellValue(sheetName, "E1", "Risk Title")
+	err = excel.SetCellValue(sheetName, "F1", "Risk ID")
+
+	err = excel.SetColWidth(sheetName, "A", "A", 18)
+	err = excel.SetColWidth(sheetName, "B", "C", 20)
+	err = excel.SetColWidth(sheetName, "D", "D", 35)
+	err = excel.SetColWidth(sheetName, "E", "E", 80)
+	err = excel.SetColWidth(sheetName, "F", "F", 55)
+	checkErr(err)
+
+	styleRedCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"` + colors.RgbHexColorRiskStatusUnchecked() + `","size":12,"bold":true}}`)
+	styleOrangeCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#` + colors.RgbHexColorRiskStatusInDiscussion() + `","size":12,"bold":true}}`)
+	styleGreenCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"` + colors.RgbHexColorRiskStatusMitigated() + `","size":12,"bold":true}}`)
+	styleBlackCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#000000","size":12}}`)
+	styleBlackLeft, err := excel.NewStyle(`{"alignment":{"horizontal":"left","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#000000","size":12}}`)
+	styleGraySmall, err := excel.NewStyle(`{"font":{"color":"` + colors.RgbHexColorOutOfScope() + `","size":10}}`)
+
+	row := 1 // as we have a header line
+	for _, delta := range model.RiskDeltas() {
+		row++
+		err := excel.SetCellValue(sheetName, "A"+strconv.Itoa(row), delta.Status.Title())
+		err = excel.SetCellValue(sheetName, "B"+strconv.Itoa(row), delta.Severity)
+		err = excel.SetCellValue(sheetName, "C"+strconv.Itoa(row), delta.BaselineSeverity)
+		err = excel.SetCellValue(sheetName, "D"+strconv.Itoa(row), delta.CategoryId)
+		err = excel.SetCellValue(sheetName, "E"+strconv.Itoa(row), removeFormattingTags(delta.Title))
+		err = excel.SetCellValue(sheetName, "F"+strconv.Itoa(row), delta.SyntheticId)
+		styleFromStatus := styleBlackCenter
+		switch delta.Status {
+		case model.NewRisk:
+			styleFromStatus = styleRedCenter
+		case model.SeverityChangedRisk:
+			styleFromStatus = styleOrangeCenter
+		case model.ResolvedRisk:
+			styleFromStatus = styleGreenCenter
+		}
+		err = excel.SetCellStyle(sheetName, "A"+strconv.Itoa(row), "A"+strconv.Itoa(row), styleFromStatus)
+		err = excel.SetCellStyle(sheetName, "B"+strconv.Itoa(row), "C"+strconv.Itoa(row), styleBlackCenter)
+		err = excel.SetCellStyle(sheetName, "D"+strconv.Itoa(row), "E"+strconv.Itoa(row), styleBlackLeft)
+		err = excel.SetCellStyle(sheetName, "F"+strconv.Itoa(row), "F"+strconv.Itoa(row), styleGraySmall)
+		checkErr(err)
+	}
+
+	styleHeadCenter, err := excel.NewStyle(`{"font":{"bold":true,"italic":false,"size":14,"color":"#000000"},"fill":{"type":"pattern","color":["#eeeeee"],"pattern":1},"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false}}`)
+	err = excel.SetCellStyle(sheetName, "A1", "F1", styleHeadCenter)
+	checkErr(err)
+}
+
 func WriteTagsExcelToFile(filename string) { // TODO: eventually when len(sortedTagsAvailable) == 0 is: write a hint in the execel that no tags are used
 	excelRow = 0
 	excel := excelize.NewFile()
@@ -304,6 +368,99 @@ func WriteTagsExcelToFile(filename string) { // TODO: eventually when len(sorted
 	checkErr(err)
 }
 
+func WriteComplianceExcelToFile(filename string) {
+	excelRow = 0
+	excel := excelize.NewFile()
+	sheetName := model.ParsedModelRoot.Title
+	err := excel.SetDocProps(&excelize.DocProperties{
+		Category:       "Compliance Matrix",
+		ContentStatus:  "Final",
+		Creator:        model.ParsedModelRoot.Author.Name,
+		Description:    sheetName + " via Threagile",
+		Identifier:     "xlsx",
+		Keywords:       "Compliance Matrix",
+		LastModifiedBy: model.ParsedModelRoot.Author.Name,
+		Revision:       "0",
+		Subject:        sheetName,
+		Title:          sheetName,
+		Language:       "en-US",
+		Version:        "1.0.0",
+	})
+	checkErr(err)
+
+	sheetIndex := excel.NewSheet(sheetName)
+	excel.DeleteSheet("Sheet1")
+	err = excel.SetPageLayout(sheetName,
+		excelize.PageLayoutOrientation(excelize.OrientationLandscape),
+		excelize.PageLayoutPaperSize(9)) // A4
+	checkErr(err)
+
+	err = excel.SetHeaderFooter(sheetName, &excelize.FormatHeaderFooter{
+		DifferentFirst:   false,
+		DifferentOddEven: false,
+		OddHeader:        "&R&P",
+		OddFooter:        "&C&F",
+		EvenHeader:       "&L&P",
+		EvenFooter:       "&L&D&R&T",
+		FirstHeader:      `&Compliance Matrix &"-,` + model.ParsedModelRoot.Title + `"Bold&"-,Regular"Summary+000A&D`,
+	})
+	checkErr(err)
+
+	err = excel.SetCellValue(sheetName, "A1", "Framework")
+	err = excel.SetCellValue(sheetName, "B1", "Control")
+	err = excel.SetCellValue(sheetName, "C1", "Status")
+	err = excel.SetCellValue(sheetName, "D1", "Open Risks")
+	err = excel.SetCellValue(sheetName, "E1", "Mitigated Risks")
+	err = excel.SetCellValue(sheetName, "F1", "Risk Categories")
+
+	err = excel.SetColWidth(sheetName, "A", "A", 30)
+	err = excel.SetColWidth(sheetName, "B", "B", 15)
+	err = excel.SetColWidth(sheetName, "C", "C", 20)
+	err = excel.SetColWidth(sheetName, "D", "D", 15)
+	err = excel.SetColWidth(sheetName, "E", "E", 18)
+	err = excel.SetColWidth(sheetName, "F", "F", 100)
+	checkErr(err)
+
+	styleRedCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"` + colors.RgbHexColorRiskStatusUnchecked() + `","size":12}}`)
+	styleGreenCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"` + colors.RgbHexColorRiskStatusMitigated() + `","size":12}}`)
+	styleBlackLeft, err := excel.NewStyle(`{"alignment":{"horizontal":"left","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#000000","size":12}}`)
+	styleBlackCenter, err := excel.NewStyle(`{"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#000000","size":12}}`)
+	styleBlackBold, err := excel.NewStyle(`{"alignment":{"horizontal":"left","shrink_to_fit":true,"wrap_text":false},"font":{"color":"#000000","size":12,"bold":true}}`)
+	styleBlackSmall, err := excel.NewStyle(`{"font":{"color":"#000000","size":10}}`)
+
+	excelRow++ // as we have a header line
+	for _, coverage := range model.ComplianceCoverage() {
+		excelRow++
+		err := excel.SetCellValue(sheetName, "A"+strconv.Itoa(excelRow), model.ComplianceFrameworkTitle(coverage.Framework))
+		err = excel.SetCellValue(sheetName, "B"+strconv.Itoa(excelRow), coverage.Control)
+		err = excel.SetCellValue(sheetName, "C"+strconv.Itoa(excelRow), coverage.Status.Title())
+		err = excel.SetCellValue(sheetName, "D"+strconv.Itoa(excelRow), coverage.OpenRisks)
+		err = excel.SetCellValue(sheetName, "E"+strconv.Itoa(excelRow), coverage.MitigatedRisks)
+		err = excel.SetCellValue(sheetName, "F"+strconv.Itoa(excelRow), strings.Join(coverage.RiskCategoryIds, ", "))
+		styleFromStatus := styleBlackCenter
+		switch coverage.Status {
+		case model.ComplianceOpenRisks:
+			styleFromStatus = styleRedCenter
+		case model.ComplianceMitigatedRisks:
+			styleFromStatus = styleGreenCenter
+		}
+		err = excel.SetCellStyle(sheetName, "A"+strconv.Itoa(excelRow), "A"+strconv.Itoa(excelRow), styleBlackLeft)
+		err = excel.SetCellStyle(sheetName, "B"+strconv.Itoa(excelRow), "B"+strconv.Itoa(excelRow), styleBlackBold)
+		err = excel.SetCellStyle(sheetName, "C"+strconv.Itoa(excelRow), "C"+strconv.Itoa(excelRow), styleFromStatus)
+		err = excel.SetCellStyle(sheetName, "D"+strconv.Itoa(excelRow), "E"+strconv.Itoa(excelRow), styleBlackCenter)
+		err = excel.SetCellStyle(sheetName, "F"+strconv.Itoa(excelRow), "F"+strconv.Itoa(excelRow), styleBlackSmall)
+		checkErr(err)
+	}
+
+	styleHeadCenter, err := excel.NewStyle(`{"font":{"bold":true,"italic":false,"size":14,"color":"#000000"},"fill":{"type":"pattern","color":["#eeeeee"],"pattern":1},"alignment":{"horizontal":"center","shrink_to_fit":true,"wrap_text":false}}`)
+	err = excel.SetCellStyle(sheetName, "A1", "F1", styleHeadCenter)
+	checkErr(err)
+
+	excel.SetActiveSheet(sheetIndex)
+	err = excel.SaveAs(filename)
+	checkErr(err)
+}
+
 func writeRow(excel *excelize.File, sheetName string, axis string, styleBlackLeftBold int, styleBlackCenter int,
 	sortedTags []string, assetTitle string, tagsUsed []string) {
 	excelRow++
@@ -329,7 +486,7 @@ func determineColumnLetter(i int) string {
 	return alphabet[(i/26)-1] + alphabet[i%26]
 }
 
-func removeFormattingTags(content string) interface{} {
+func removeFormattingTags(content string) string {
 	result := strings.ReplaceAll(strings.ReplaceAll(content, "<b>", ""), "</b>", "")
 	result = strings.ReplaceAll(strings.ReplaceAll(result, "<i>", ""), "</i>", "")
 	result = strings.ReplaceAll(strings.ReplaceAll(result, "<u>", ""), "</u>", "")
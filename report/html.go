@@ -0,0 +1,628 @@
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/colors"
+	"github.com/threagile/threagile/model"
+)
+
+// the formatting tags used in risk titles and model texts, which are kept when escaping them
+var htmlFormattingTags = strings.NewReplacer(
+	"&lt;b&gt;", "<b>", "&lt;/b&gt;", "</b>",
+	"&lt;i&gt;", "<i>", "&lt;/i&gt;", "</i>",
+	"&lt;u&gt;", "<u>", "&lt;/u&gt;", "</u>",
+	"&lt;br&gt;", "<br>", "&lt;p&gt;", "<p>")
+
+// the links (to http or https URLs only) in model texts, which are kept when escaping them (as matched after escaping)
+var htmlLinks = regexp.MustCompile(`&lt;a href=&#34;(https?://[^&\s]+)&#34;&gt;(.*?)&lt;/a&gt;`)
+
+const htmlReportStyle = `
+body { font-family: Helvetica, Arial, sans-serif; margin: 0; color: #000000; }
+header, main { max-width: 1100px; margin: 0 auto; padding: 0 20px; }
+nav { max-width: 1100px; margin: 0 auto; padding: 0 20px; }
+h1 { margin-top: 30px; }
+h2 { border-bottom: 1px solid #999999; padding-top: 20px; }
+table { border-collapse: collapse; margin: 10px 0; }
+th, td { border: 1px solid #D2D2D2; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background-color: #F6F6F6; }
+td.number { text-align: right; }
+.muted { color: #666666; font-size: smaller; }
+.not-at-risk { opacity: 0.6; }
+.filters { position: sticky; top: 0; background-color: #FFFFFF; border-bottom: 1px solid #D2D2D2; padding: 8px 20px; z-index: 1; }
+.filters label { margin-right: 15px; }
+.diagram { max-width: 100%; border: 1px solid #E5E5E5; }
+:target { background-color: #FFFC97; }
+`
+
+const htmlReportScript = `
+function filterRisks() {
+  var severity = document.getElementById('filter-severity').value;
+  var status = document.getElementById('filter-status').value;
+  var owner = document.getElementById('filter-owner').value;
+  var shown = 0, total = 0;
+  document.querySelectorAll('.risk').forEach(function (element) {
+    var visible = (severity === '' || element.dataset.severity === severity) &&
+      (status === '' || element.dataset.status === status) &&
+      (owner === '' || element.dataset.owner === owner);
+    element.style.display = visible ? '' : 'none';
+    if (element.tagName === 'TR') {
+      total++;
+      if (visible) {
+        shown++;
+      }
+    }
+  });
+  document.querySelectorAll('.risk-category').forEach(function (section) {
+    var anyVisible = Array.prototype.some.call(section.querySelectorAll('tr.risk'), function (row) {
+      return row.style.display !== 'none';
+    });
+    section.style.display = anyVisible ? '' : 'none';
+  });
+  document.getElementById('filter-count').textContent = shown + ' of ' + total + ' risks shown';
+}
+`
+
+// WriteReportHTML writes a self-contained HTML report (with the diagrams embedded) having the same chapters as the PDF
+// report, but searchable, filterable by severity, status, and owner, and with an anchor per risk (its synthetic ID)
+func WriteReportHTML(reportFilename string,
+	dataFlowDiagramFilenamePNG string,
+	dataAssetDiagramFilenamePNG string,
+	attackPathsDiagramFilenamePNG string,
+	introTextRAA string) {
+	var page strings.Builder
+	title := html.EscapeString(model.ParsedModelRoot.Title)
+	page.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	page.WriteString("<title>Threat Model Report: " + title + "</title>\n")
+	page.WriteString("<style>" + htmlReportStyle + "</style>\n<script>" + htmlReportScript + "</script>\n</head>\n<body>\n")
+	page.WriteString("<header>\n<h1>Threat Model Report: " + title + "</h1>\n<p class=\"muted\">")
+	if len(model.ParsedModelRoot.Author.Name) > 0 {
+		page.WriteString("by " + html.EscapeString(model.ParsedModelRoot.Author.Name) + ", ")
+	}
+	page.WriteString(model.ParsedModelRoot.Date.Format("2006-01-02") + "</p>\n")
+	writeTableOfContentsHTML(&page)
+	page.WriteString("</header>\n")
+	writeRiskFiltersHTML(&page)
+	page.WriteString("<main>\n")
+	writeManagementSummaryHTML(&page)
+	if model.HasBaseline() {
+		writeRiskDeltaHTML(&page)
+	}
+	if model.HasSASTFindings() {
+		writeSASTConfirmationHTML(&page)
+	}
+	writeDiagramHTML(&page, "data-flow-diagram", "Data-Flow Diagram", dataFlowDiagramFilenamePNG)
+	writeDiagramHTML(&page, "attack-paths", "Attack Paths", attackPathsDiagramFilenamePNG)
+	writeDiagramHTML(&page, "data-risk-mapping", "Data Mapping", dataAssetDiagramFilenamePNG)
+	writeSTRIDEHTML(&page)
+	writeRAAHTML(&page, introTextRAA)
+	writeRiskCategoriesHTML(&page)
+	writeTechnicalAssetsHTML(&page)
+	writeDataAssetsHTML(&page)
+	writeTrustBoundariesHTML(&page)
+	writeSharedRuntimesHTML(&page)
+	page.WriteString("</main>\n<script>filterRisks();</script>\n</body>\n</html>\n")
+	err := ioutil.WriteFile(reportFilename, []byte(page.String()), 0644)
+	checkErr(err)
+}
+
+func writeTableOfContentsHTML(page *strings.Builder) {
+	page.WriteString("<nav>\n<ul>\n")
+	page.WriteString("<li><a href=\"#management-summary\">Management Summary</a></li>\n")
+	if model.HasBaseline() {
+		page.WriteString("<li><a href=\"#risk-delta\">Delta since Baseline</a></li>\n")
+	}
+	if model.HasSASTFindings() {
+		page.WriteString("<li><a href=\"#sast-confirmation\">Risks Confirmed by SAST Findings</a></li>\n")
+	}
+	page.WriteString("<li><a href=\"#data-flow-diagram\">Data-Flow Diagram</a></li>\n")
+	page.WriteString("<li><a href=\"#attack-paths\">Attack Paths</a></li>\n")
+	page.WriteString("<li><a href=\"#data-risk-mapping\">Data Mapping</a></li>\n")
+	page.WriteString("<li><a href=\"#stride\">STRIDE Classification of Identified Risks</a></li>\n")
+	page.WriteString("<li><a href=\"#raa-analysis\">RAA Analysis</a></li>\n")
+	page.WriteString("<li><a href=\"#risks-by-vulnerability-category\">Risks by Vulnerability Category</a></li>\n")
+	page.WriteString("<li><a href=\"#technical-assets\">Technical Assets</a></li>\n")
+	page.WriteString("<li><a href=\"#data-assets\">Data Assets</a></li>\n")
+	page.WriteString("<li><a href=\"#trust-boundaries\">Trust Boundaries</a></li>\n")
+	page.WriteString("<li><a href=\"#shared-runtimes\">Shared Runtimes</a></li>\n")
+	page.WriteString("</ul>\n</nav>\n")
+}
+
+func writeRiskFiltersHTML(page *strings.Builder) {
+	page.WriteString("<div class=\"filters\">\n")
+	page.WriteString("<label>Severity <select id=\"filter-severity\" onchange=\"filterRisks()\"><option value=\"\">all</option>")
+	for i := len(model.RiskSeverityValues()) - 1; i >= 0; i-- { // highest first
+		severity := model.RiskSeverityValues()[i].(model.RiskSeverity)
+		page.WriteString("<option value=\"" + severity.String() + "\">" + severity.Title() + "</option>")
+	}
+	page.WriteString("</select></label>\n")
+	page.WriteString("<label>Status <select id=\"filter-status\" onchange=\"filterRisks()\"><option value=\"\">all</option>")
+	for _, value := range model.RiskStatusValues() {
+		status := value.(model.RiskStatus)
+		page.WriteString("<option value=\"" + status.String() + "\">" + status.Title() + "</option>")
+	}
+	page.WriteString("</select></label>\n")
+	page.WriteString("<label>Owner <select id=\"filter-owner\" onchange=\"filterRisks()\"><option value=\"\">all</option>")
+	owners := make([]string, 0)
+	for _, risk := range model.AllRisks() {
+		if owner := riskOwner(risk); len(owner) > 0 && !model.Contains(owners, owner) {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		page.WriteString("<option value=\"" + html.EscapeString(owner) + "\">" + html.EscapeString(owner) + "</option>")
+	}
+	page.WriteString("</select></label>\n")
+	page.WriteString("<span id=\"filter-count\" class=\"muted\"></span>\n</div>\n")
+}
+
+func writeManagementSummaryHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"management-summary\">\n<h2>Management Summary</h2>\n")
+	page.WriteString("<p>Threagile toolkit was used to model the architecture of \"" + html.EscapeString(model.ParsedModelRoot.Title) + "\" " +
+		"and derive risks by analyzing the components and data flows. In total <b>" + strconv.Itoa(model.TotalRiskCount()) +
+		" initial risks</b> in <b>" + strconv.Itoa(len(model.GeneratedRisksByCategory)) + " categories</b> have been identified " +
+		"during the threat modeling process. They are potential risks, which have to be reviewed individually.</p>\n")
+	page.WriteString("<table>\n<tr><th>Severity</th><th>Risks</th><th>Still at Risk</th></tr>\n")
+	for i := len(model.RiskSeverityValues()) - 1; i >= 0; i-- {
+		severity := model.RiskSeverityValues()[i].(model.RiskSeverity)
+		risks := risksOfSeverity(model.AllRisks(), severity)
+		page.WriteString("<tr><td style=\"color: " + severityColorHTML(severity) + "\">" + severity.Title() + "</td>" +
+			"<td class=\"number\">" + strconv.Itoa(len(risks)) + "</td>" +
+			"<td class=\"number\">" + strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(risks))) + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n")
+	page.WriteString("<table>\n<tr><th>Risk Status</th><th>Risks</th></tr>\n")
+	for _, value := range model.RiskStatusValues() {
+		status := value.(model.RiskStatus)
+		count := 0
+		for _, risk := range model.AllRisks() {
+			if risk.GetRiskTrackingStatusDefaultingUnchecked() == status {
+				count++
+			}
+		}
+		page.WriteString("<tr><td style=\"color: " + statusColorHTML(status) + "\">" + status.Title() + "</td>" +
+			"<td class=\"number\">" + strconv.Itoa(count) + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n")
+	if len(model.ParsedModelRoot.ManagementSummaryComment) > 0 {
+		page.WriteString("<p>" + formattedHTML(model.ParsedModelRoot.ManagementSummaryComment) + "</p>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeRiskDeltaHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"risk-delta\">\n<h2>Delta since Baseline</h2>\n")
+	page.WriteString("<table>\n<tr><th>Delta</th><th>Severity</th><th>Baseline Severity</th><th>Risk</th></tr>\n")
+	for _, delta := range model.RiskDeltas() {
+		if delta.Status == model.UnchangedRisk {
+			continue
+		}
+		riskTitle := formattedHTML(delta.Title)
+		if delta.Status != model.ResolvedRisk {
+			riskTitle = "<a href=\"#" + html.EscapeString(delta.SyntheticId) + "\">" + riskTitle + "</a>"
+		}
+		page.WriteString("<tr><td>" + delta.Status.Title() + "</td><td>" + delta.Severity + "</td><td>" + delta.BaselineSeverity +
+			"</td><td>" + riskTitle + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n</section>\n")
+}
+
+func writeSASTConfirmationHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"sast-confirmation\">\n<h2>Risks Confirmed by SAST Findings</h2>\n")
+	page.WriteString("<table>\n<tr><th>Severity</th><th>Likelihood</th><th>Risk</th><th>SAST Findings</th></tr>\n")
+	for _, risk := range model.ConfirmedRisks() {
+		findings := make([]string, 0, len(risk.SASTFindings))
+		for _, finding := range risk.SASTFindings {
+			findings = append(findings, html.EscapeString(finding.Tool+" "+finding.RuleId+" ("+finding.CWETitles()+") at "+finding.Repository+" "+finding.Location))
+		}
+		page.WriteString("<tr><td>" + risk.Severity.String() + "</td><td>" + risk.ExploitationLikelihood.String() +
+			"</td><td><a href=\"#" + html.EscapeString(risk.SyntheticId) + "\">" + formattedHTML(risk.Title) + "</a></td><td>" +
+			strings.Join(findings, "<br>") + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n")
+	unconfirmingFindings := model.UnconfirmingSASTFindings()
+	if len(unconfirmingFindings) > 0 {
+		page.WriteString("<h3>SAST Findings without Modelled Risk</h3>\n")
+		page.WriteString("<table>\n<tr><th>Repository</th><th>Finding</th><th>CWE</th><th>Location</th></tr>\n")
+		for _, finding := range unconfirmingFindings {
+			page.WriteString("<tr><td>" + html.EscapeString(finding.Repository) + "</td><td>" + html.EscapeString(finding.Tool+" "+finding.RuleId+": "+finding.Message) +
+				"</td><td>" + finding.CWETitles() + "</td><td>" + html.EscapeString(finding.Location) + "</td></tr>\n")
+		}
+		page.WriteString("</table>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeDiagramHTML(page *strings.Builder, anchor string, title string, diagramFilenamePNG string) {
+	page.WriteString("<section id=\"" + anchor + "\">\n<h2>" + title + "</h2>\n")
+	if !fileExists(diagramFilenamePNG) {
+		page.WriteString("<p class=\"muted\">n/a</p>\n</section>\n")
+		return
+	}
+	imageBytes, err := ioutil.ReadFile(diagramFilenamePNG)
+	checkErr(err)
+	page.WriteString("<img class=\"diagram\" alt=\"" + title + "\" src=\"data:image/png;base64," +
+		base64.StdEncoding.EncodeToString(imageBytes) + "\">\n</section>\n")
+}
+
+func writeSTRIDEHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"stride\">\n<h2>STRIDE Classification of Identified Risks</h2>\n")
+	for _, value := range model.STRIDEValues() {
+		stride := value.(model.STRIDE)
+		page.WriteString("<h3>" + stride.Title() + "</h3>\n")
+		categories := make([]model.RiskCategory, 0)
+		for _, category := range model.SortedRiskCategories() {
+			if category.STRIDE == stride {
+				categories = append(categories, category)
+			}
+		}
+		if len(categories) == 0 {
+			page.WriteString("<p class=\"muted\">n/a</p>\n")
+			continue
+		}
+		page.WriteString("<ul>\n")
+		for _, category := range categories {
+			risks := model.SortedRisksOfCategory(category)
+			page.WriteString("<li><a href=\"#" + riskCategoryAnchor(category) + "\" style=\"color: " +
+				severityColorHTML(model.HighestSeverityStillAtRisk(risks)) + "\">" + html.EscapeString(category.Title) + "</a>: " +
+				strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(risks))) + " / " + strconv.Itoa(len(risks)) + " risks still at risk</li>\n")
+		}
+		page.WriteString("</ul>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeRAAHTML(page *strings.Builder, introTextRAA string) {
+	page.WriteString("<section id=\"raa-analysis\">\n<h2>RAA Analysis</h2>\n")
+	page.WriteString("<p>" + introTextRAA + "</p>\n")
+	page.WriteString("<table>\n<tr><th>Technical Asset</th><th>RAA</th><th>Risks Still at Risk</th></tr>\n")
+	for _, technicalAsset := range model.SortedTechnicalAssetsByRAAAndTitle() {
+		if technicalAsset.OutOfScope {
+			continue
+		}
+		risks := technicalAsset.GeneratedRisks()
+		page.WriteString("<tr><td><a href=\"#" + technicalAssetAnchor(technicalAsset.Id) + "\">" + html.EscapeString(technicalAsset.Title) + "</a></td>" +
+			"<td class=\"number\">" + fmt.Sprintf("%.0f", technicalAsset.RAA) + "%</td>" +
+			"<td class=\"number\">" + strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(risks))) + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n</section>\n")
+}
+
+func writeRiskCategoriesHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"risks-by-vulnerability-category\">\n<h2>Risks by Vulnerability Category</h2>\n")
+	page.WriteString("<p>In total <b>" + strconv.Itoa(model.TotalRiskCount()) + " potential risks</b> have been identified, " +
+		"distributed among <b>" + strconv.Itoa(len(model.GeneratedRisksByCategory)) + " vulnerability categories</b>.</p>\n")
+	for _, category := range model.SortedRiskCategories() {
+		risks := model.SortedRisksOfCategory(category)
+		page.WriteString("<section class=\"risk-category\" id=\"" + riskCategoryAnchor(category) + "\">\n")
+		page.WriteString("<h3 style=\"color: " + severityColorHTML(model.HighestSeverityStillAtRisk(risks)) + "\">" +
+			html.EscapeString(category.Title) + ": " + strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(risks))) + " / " +
+			strconv.Itoa(len(risks)) + " Risks</h3>\n")
+		cweLink := "n/a"
+		if category.CWE > 0 {
+			cweLink = "<a href=\"https://cwe.mitre.org/data/definitions/" + strconv.Itoa(category.CWE) + ".html\">CWE " +
+				strconv.Itoa(category.CWE) + "</a>"
+		}
+		page.WriteString("<p><b>Description</b> (" + category.STRIDE.Title() + "): " + cweLink + "</p>\n")
+		page.WriteString("<p>" + formattedHTML(category.Description) + "</p>\n")
+		page.WriteString("<p><b>Impact</b></p>\n<p>" + formattedHTML(category.Impact) + "</p>\n")
+		page.WriteString("<p><b>Detection Logic</b></p>\n<p>" + formattedHTML(category.DetectionLogic) + "</p>\n")
+		page.WriteString("<p><b>Risk Rating</b></p>\n<p>" + formattedHTML(category.RiskAssessment) + "</p>\n")
+		page.WriteString("<p style=\"color: " + colors.RgbHexColorRiskStatusFalsePositive() + "\"><b>False Positives</b><br>" +
+			formattedHTML(category.FalsePositives) + "</p>\n")
+		page.WriteString("<p style=\"color: " + colors.RgbHexColorRiskStatusMitigated() + "\"><b>Mitigation</b> (" +
+			category.Function.Title() + "): " + formattedHTML(category.Action) + "<br>" + formattedHTML(category.Mitigation) + "</p>\n")
+		asvsChapter, cheatSheetLink := "n/a", "n/a"
+		if len(category.ASVS) > 0 {
+			asvsChapter = "<a href=\"https://owasp.org/www-project-application-security-verification-standard/\">" + formattedHTML(category.ASVS) + "</a>"
+		}
+		if len(category.CheatSheet) > 0 {
+			cheatSheetLink = formattedHTML(category.CheatSheet)
+			if isWebURL(category.CheatSheet) { // no links to javascript: or data: URLs of uploaded models
+				cheatSheetLink = "<a href=\"" + html.EscapeString(category.CheatSheet) + "\">" + cheatSheetLink + "</a>"
+			}
+		}
+		page.WriteString("<p>ASVS Chapter: " + asvsChapter + "<br>Cheat Sheet: " + cheatSheetLink + "</p>\n")
+		page.WriteString("<p><b>Check</b></p>\n<p>" + formattedHTML(category.Check) + "</p>\n")
+		page.WriteString("<table>\n<tr><th>Severity</th><th>Risk</th><th>Likelihood</th><th>Impact</th><th>Owner</th><th>Status</th><th>Tracking</th></tr>\n")
+		for _, risk := range risks {
+			writeRiskRowHTML(page, risk)
+		}
+		page.WriteString("</table>\n</section>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeRiskRowHTML(page *strings.Builder, risk model.Risk) {
+	tracking := risk.GetRiskTracking()
+	status := risk.GetRiskTrackingStatusDefaultingUnchecked()
+	class := "risk"
+	if !status.IsStillAtRisk() {
+		class += " not-at-risk"
+	}
+	page.WriteString("<tr id=\"" + html.EscapeString(risk.SyntheticId) + "\" " + riskDataAttributesHTML(risk, class) + ">")
+	page.WriteString("<td style=\"color: " + severityColorHTML(risk.Severity) + "\">" + risk.Severity.Title() + "</td>")
+	page.WriteString("<td><a href=\"#" + html.EscapeString(risk.SyntheticId) + "\">" + formattedHTML(risk.Title) + "</a>" +
+		"<br><span class=\"muted\">" + html.EscapeString(risk.SyntheticId) + "</span>")
+	if len(risk.MostRelevantTechnicalAssetId) > 0 {
+		page.WriteString("<br><span class=\"muted\">Technical asset: <a href=\"#" + technicalAssetAnchor(risk.MostRelevantTechnicalAssetId) + "\">" +
+			html.EscapeString(model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId].Title) + "</a></span>")
+	}
+	page.WriteString("</td>")
+	page.WriteString("<td>" + risk.ExploitationLikelihood.Title() + "</td><td>" + risk.ExploitationImpact.Title() + "</td>")
+	page.WriteString("<td>" + html.EscapeString(riskOwner(risk)) + "</td>")
+	page.WriteString("<td style=\"color: " + statusColorHTML(status) + "\">" + status.Title() + "</td><td>")
+	if status != model.Unchecked {
+		if date := tracking.Date.Format("2006-01-02"); date != "0001-01-01" {
+			page.WriteString(date + " ")
+		}
+		page.WriteString(html.EscapeString(tracking.CheckedBy) + " " + html.EscapeString(tracking.Ticket))
+		if len(tracking.Justification) > 0 {
+			page.WriteString("<br>" + html.EscapeString(tracking.Justification))
+		}
+	}
+	page.WriteString("</td></tr>\n")
+}
+
+func writeTechnicalAssetsHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"technical-assets\">\n<h2>Technical Assets</h2>\n")
+	for _, technicalAsset := range model.SortedTechnicalAssetsByRiskSeverityAndTitle() {
+		risks := technicalAsset.GeneratedRisks()
+		page.WriteString("<section id=\"" + technicalAssetAnchor(technicalAsset.Id) + "\">\n")
+		page.WriteString("<h3 style=\"color: " + severityColorHTML(model.HighestSeverityStillAtRisk(risks)) + "\">" +
+			html.EscapeString(technicalAsset.Title) + "</h3>\n")
+		page.WriteString("<p>" + formattedHTML(technicalAsset.Description) + "</p>\n")
+		writeRiskListHTML(page, risks)
+		dataAssetsProcessed := make([]string, 0)
+		for _, dataAsset := range technicalAsset.DataAssetsProcessedSorted() {
+			dataAssetsProcessed = append(dataAssetsProcessed, "<a href=\"#"+dataAssetAnchor(dataAsset.Id)+"\">"+html.EscapeString(dataAsset.Title)+"</a>")
+		}
+		dataAssetsStored := make([]string, 0)
+		for _, dataAsset := range technicalAsset.DataAssetsStoredSorted() {
+			dataAssetsStored = append(dataAssetsStored, "<a href=\"#"+dataAssetAnchor(dataAsset.Id)+"\">"+html.EscapeString(dataAsset.Title)+"</a>")
+		}
+		outgoingLinks := make([]string, 0)
+		for _, link := range technicalAsset.CommunicationLinksSorted() {
+			outgoingLinks = append(outgoingLinks, html.EscapeString(link.Title)+" ("+link.Protocol.String()+") to <a href=\"#"+
+				technicalAssetAnchor(link.TargetId)+"\">"+html.EscapeString(model.ParsedModelRoot.TechnicalAssets[link.TargetId].Title)+"</a>")
+		}
+		writePropertiesHTML(page, [][2]string{
+			{"ID", html.EscapeString(technicalAsset.Id)},
+			{"Type", technicalAsset.Type.String()},
+			{"Usage", technicalAsset.Usage.String()},
+			{"RAA", fmt.Sprintf("%.0f", technicalAsset.RAA) + "%"},
+			{"Size", technicalAsset.Size.String()},
+			{"Technology", technicalAsset.Technology.String()},
+			{"Tags", html.EscapeString(strings.Join(technicalAsset.Tags, ", "))},
+			{"Internet", strconv.FormatBool(technicalAsset.Internet)},
+			{"Machine", technicalAsset.Machine.String()},
+			{"Encryption", technicalAsset.Encryption.String()},
+			{"Multi-Tenant", strconv.FormatBool(technicalAsset.MultiTenant)},
+			{"Redundant", strconv.FormatBool(technicalAsset.Redundant)},
+			{"Custom-Developed", strconv.FormatBool(technicalAsset.CustomDevelopedParts)},
+			{"Client by Human", strconv.FormatBool(technicalAsset.UsedAsClientByHuman)},
+			{"Out of Scope", strconv.FormatBool(technicalAsset.OutOfScope) + " " + html.EscapeString(technicalAsset.JustificationOutOfScope)},
+			{"Owner", html.EscapeString(technicalAsset.Owner)},
+			{"Confidentiality", technicalAsset.Confidentiality.String()},
+			{"Integrity", technicalAsset.Integrity.String()},
+			{"Availability", technicalAsset.Availability.String()},
+			{"CIA-Justification", html.EscapeString(technicalAsset.JustificationCiaRating)},
+			{"Data Processed", strings.Join(dataAssetsProcessed, ", ")},
+			{"Data Stored", strings.Join(dataAssetsStored, ", ")},
+			{"Outgoing Communication Links", strings.Join(outgoingLinks, "<br>")},
+		})
+		page.WriteString("</section>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeDataAssetsHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"data-assets\">\n<h2>Data Assets</h2>\n")
+	for _, dataAsset := range model.SortedDataAssetsByDataBreachProbabilityAndTitle() {
+		page.WriteString("<section id=\"" + dataAssetAnchor(dataAsset.Id) + "\">\n")
+		page.WriteString("<h3>" + html.EscapeString(dataAsset.Title) + "</h3>\n")
+		page.WriteString("<p>" + formattedHTML(dataAsset.Description) + "</p>\n")
+		processedBy := make([]string, 0)
+		for _, technicalAsset := range dataAsset.ProcessedByTechnicalAssetsSorted() {
+			processedBy = append(processedBy, "<a href=\"#"+technicalAssetAnchor(technicalAsset.Id)+"\">"+html.EscapeString(technicalAsset.Title)+"</a>")
+		}
+		storedBy := make([]string, 0)
+		for _, technicalAsset := range dataAsset.StoredByTechnicalAssetsSorted() {
+			storedBy = append(storedBy, "<a href=\"#"+technicalAssetAnchor(technicalAsset.Id)+"\">"+html.EscapeString(technicalAsset.Title)+"</a>")
+		}
+		writePropertiesHTML(page, [][2]string{
+			{"ID", html.EscapeString(dataAsset.Id)},
+			{"Usage", dataAsset.Usage.String()},
+			{"Quantity", dataAsset.Quantity.String()},
+			{"Tags", html.EscapeString(strings.Join(dataAsset.Tags, ", "))},
+			{"Origin", html.EscapeString(dataAsset.Origin)},
+			{"Owner", html.EscapeString(dataAsset.Owner)},
+			{"Confidentiality", dataAsset.Confidentiality.String()},
+			{"Integrity", dataAsset.Integrity.String()},
+			{"Availability", dataAsset.Availability.String()},
+			{"CIA-Justification", html.EscapeString(dataAsset.JustificationCiaRating)},
+			{"Processed by", strings.Join(processedBy, ", ")},
+			{"Stored by", strings.Join(storedBy, ", ")},
+			{"Data Breach", dataAsset.IdentifiedDataBreachProbabilityStillAtRisk().Title()},
+		})
+		page.WriteString("<p><b>Data Breach Risks</b></p>\n")
+		writeRiskListHTML(page, dataAsset.IdentifiedDataBreachProbabilityRisks())
+		page.WriteString("</section>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeTrustBoundariesHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"trust-boundaries\">\n<h2>Trust Boundaries</h2>\n")
+	for _, trustBoundary := range model.SortedTrustBoundariesByTitle() {
+		page.WriteString("<section id=\"" + trustBoundaryAnchor(trustBoundary.Id) + "\">\n")
+		page.WriteString("<h3>" + html.EscapeString(trustBoundary.Title) + "</h3>\n")
+		page.WriteString("<p>" + formattedHTML(trustBoundary.Description) + "</p>\n")
+		assetsInside := make([]string, 0)
+		for _, technicalAssetId := range trustBoundary.TechnicalAssetsInside {
+			assetsInside = append(assetsInside, "<a href=\"#"+technicalAssetAnchor(technicalAssetId)+"\">"+
+				html.EscapeString(model.ParsedModelRoot.TechnicalAssets[technicalAssetId].Title)+"</a>")
+		}
+		boundariesNested := make([]string, 0)
+		for _, trustBoundaryId := range trustBoundary.TrustBoundariesNested {
+			boundariesNested = append(boundariesNested, "<a href=\"#"+trustBoundaryAnchor(trustBoundaryId)+"\">"+
+				html.EscapeString(model.ParsedModelRoot.TrustBoundaries[trustBoundaryId].Title)+"</a>")
+		}
+		writePropertiesHTML(page, [][2]string{
+			{"ID", html.EscapeString(trustBoundary.Id)},
+			{"Type", trustBoundary.Type.String()},
+			{"Tags", html.EscapeString(strings.Join(trustBoundary.Tags, ", "))},
+			{"Assets inside", strings.Join(assetsInside, ", ")},
+			{"Boundaries nested", strings.Join(boundariesNested, ", ")},
+		})
+		page.WriteString("</section>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+func writeSharedRuntimesHTML(page *strings.Builder) {
+	page.WriteString("<section id=\"shared-runtimes\">\n<h2>Shared Runtimes</h2>\n")
+	for _, sharedRuntime := range model.SortedSharedRuntimesByTitle() {
+		page.WriteString("<section id=\"" + sharedRuntimeAnchor(sharedRuntime.Id) + "\">\n")
+		page.WriteString("<h3>" + html.EscapeString(sharedRuntime.Title) + "</h3>\n")
+		page.WriteString("<p>" + formattedHTML(sharedRuntime.Description) + "</p>\n")
+		assetsRunning := make([]string, 0)
+		for _, technicalAssetId := range sharedRuntime.TechnicalAssetsRunning {
+			assetsRunning = append(assetsRunning, "<a href=\"#"+technicalAssetAnchor(technicalAssetId)+"\">"+
+				html.EscapeString(model.ParsedModelRoot.TechnicalAssets[technicalAssetId].Title)+"</a>")
+		}
+		writePropertiesHTML(page, [][2]string{
+			{"ID", html.EscapeString(sharedRuntime.Id)},
+			{"Tags", html.EscapeString(strings.Join(sharedRuntime.Tags, ", "))},
+			{"Assets running", strings.Join(assetsRunning, ", ")},
+		})
+		page.WriteString("</section>\n")
+	}
+	page.WriteString("</section>\n")
+}
+
+// writeRiskListHTML lists the risks as links to their findings (filtered along with them)
+func writeRiskListHTML(page *strings.Builder, risks []model.Risk) {
+	if len(risks) == 0 {
+		page.WriteString("<p class=\"muted\">No risks identified.</p>\n")
+		return
+	}
+	sort.Sort(model.ByRiskSeveritySort(risks))
+	page.WriteString("<ul>\n")
+	for _, risk := range risks {
+		page.WriteString("<li " + riskDataAttributesHTML(risk, "risk") + "><a href=\"#" + html.EscapeString(risk.SyntheticId) +
+			"\" style=\"color: " + severityColorHTML(risk.Severity) + "\">" + formattedHTML(risk.Title) + "</a> " +
+			"<span class=\"muted\">" + risk.GetRiskTrackingStatusDefaultingUnchecked().Title() + "</span></li>\n")
+	}
+	page.WriteString("</ul>\n")
+}
+
+// writePropertiesHTML writes a two-column table of the (already escaped) properties
+func writePropertiesHTML(page *strings.Builder, properties [][2]string) {
+	page.WriteString("<table>\n")
+	for _, property := range properties {
+		page.WriteString("<tr><th>" + property[0] + "</th><td>" + property[1] + "</td></tr>\n")
+	}
+	page.WriteString("</table>\n")
+}
+
+func riskDataAttributesHTML(risk model.Risk, class string) string {
+	return "class=\"" + class + "\" data-severity=\"" + risk.Severity.String() + "\" data-status=\"" +
+		risk.GetRiskTrackingStatusDefaultingUnchecked().String() + "\" data-owner=\"" + html.EscapeString(riskOwner(risk)) + "\""
+}
+
+// riskOwner is the owner of the most relevant technical asset (or else data asset) of the risk
+func riskOwner(risk model.Risk) string {
+	if len(risk.MostRelevantTechnicalAssetId) > 0 {
+		return model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId].Owner
+	}
+	if len(risk.MostRelevantDataAssetId) > 0 {
+		return model.ParsedModelRoot.DataAssets[risk.MostRelevantDataAssetId].Owner
+	}
+	return ""
+}
+
+func risksOfSeverity(risks []model.Risk, severity model.RiskSeverity) []model.Risk {
+	result := make([]model.Risk, 0)
+	for _, risk := range risks {
+		if risk.Severity == severity {
+			result = append(result, risk)
+		}
+	}
+	return result
+}
+
+// formattedHTML escapes the text except for the simple formatting tags used by risk titles and model texts
+func formattedHTML(text string) string {
+	return htmlLinks.ReplaceAllString(htmlFormattingTags.Replace(html.EscapeString(text)), "<a href=\"$1\">$2</a>")
+}
+
+func isWebURL(url string) bool {
+	url = strings.ToLower(strings.TrimSpace(url))
+	return strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")
+}
+
+func severityColorHTML(severity model.RiskSeverity) string {
+	switch severity {
+	case model.CriticalSeverity:
+		return colors.RgbHexColorCriticalRisk()
+	case model.HighSeverity:
+		return colors.RgbHexColorHighRisk()
+	case model.ElevatedSeverity:
+		return colors.RgbHexColorElevatedRisk()
+	case model.MediumSeverity:
+		return colors.RgbHexColorMediumRisk()
+	case model.LowSeverity:
+		return colors.RgbHexColorLowRisk()
+	default:
+		return rgbHexColorBlack()
+	}
+}
+
+func statusColorHTML(status model.RiskStatus) string {
+	switch status {
+	case model.Unchecked:
+		return colors.RgbHexColorRiskStatusUnchecked()
+	case model.InDiscussion:
+		return colors.RgbHexColorRiskStatusInDiscussion()
+	case model.Accepted:
+		return colors.RgbHexColorRiskStatusAccepted()
+	case model.InProgress:
+		return colors.RgbHexColorRiskStatusInProgress()
+	case model.Mitigated:
+		return colors.RgbHexColorRiskStatusMitigated()
+	case model.FalsePositive:
+		return colors.RgbHexColorRiskStatusFalsePositive()
+	default:
+		return rgbHexColorBlack()
+	}
+}
+
+func riskCategoryAnchor(category model.RiskCategory) string {
+	return "risk-category-" + html.EscapeString(category.Id)
+}
+
+func technicalAssetAnchor(id string) string {
+	return "technical-asset-" + html.EscapeString(id)
+}
+
+func dataAssetAnchor(id string) string {
+	return "data-asset-" + html.EscapeString(id)
+}
+
+func trustBoundaryAnchor(id string) string {
+	return "trust-boundary-" + html.EscapeString(id)
+}
+
+func sharedRuntimeAnchor(id string) string {
+	return "shared-runtime-" + html.EscapeString(id)
+}
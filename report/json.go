@@ -26,6 +26,28 @@ func WriteRisksJSON(filename string) {
 	}
 }
 
+func WriteRiskDeltaJSON(filename string) {
+	jsonBytes, err := json.Marshal(model.RiskDeltas())
+	if err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func WriteFlowVerificationJSON(filename string) {
+	jsonBytes, err := json.Marshal(model.VerifyFlows())
+	if err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // TODO: also a "data assets" json?
 func WriteTechnicalAssetsJSON(filename string) {
 	jsonBytes, err := json.Marshal(model.ParsedModelRoot.TechnicalAssets)
@@ -48,3 +70,25 @@ func WriteStatsJSON(filename string) {
 		panic(err)
 	}
 }
+
+func WriteAttackPathsJSON(filename string) {
+	jsonBytes, err := json.Marshal(model.AttackPaths())
+	if err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func WriteBlastRadiusJSON(filename string, compromisedTechnicalAssetId string) {
+	jsonBytes, err := json.Marshal(model.BlastRadiusOf(compromisedTechnicalAssetId))
+	if err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,175 @@
+package report
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// keeps the compact Markdown report below the comment size limit of GitHub (65536 characters)
+const markdownCompactMaxLength = 65000
+
+var markdownLinkRegEx = regexp.MustCompile(`<a href="(.*?)">(.*?)</a>`)
+
+var markdownFormattingTags = strings.NewReplacer(
+	"<b>", "**", "</b>", "**",
+	"<i>", "*", "</i>", "*",
+	"<u>", "<ins>", "</u>", "</ins>", // as GitHub-flavoured Markdown has no underline
+	"<p>", "<br><br>") // line breaks are kept as HTML (<br>) to work within tables as well
+
+// WriteReportMarkdown writes the risks as GitHub-flavoured Markdown (for wikis and pull request comments), linking to
+// the given diagrams when they exist next to the report. The compact variant lists only the risks still at risk and
+// is cut off (lowest severities first) to fit into a pull request comment.
+func WriteReportMarkdown(reportFilename string,
+	dataFlowDiagramFilenamePNG string,
+	dataAssetDiagramFilenamePNG string,
+	attackPathsDiagramFilenamePNG string,
+	compact bool) {
+	var markdown strings.Builder
+	markdown.WriteString("# Threat Model Report: " + escapeMarkdown(model.ParsedModelRoot.Title) + "\n\n")
+	markdown.WriteString("In total **" + strconv.Itoa(model.TotalRiskCount()) + " risks** in **" +
+		strconv.Itoa(len(model.GeneratedRisksByCategory)) + " categories** have been identified, of which **" +
+		strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(model.AllRisks()))) + "** are still at risk.\n\n")
+	writeRiskSummaryMarkdown(&markdown)
+	diagramLinks := make([]string, 0)
+	for _, diagram := range [][2]string{
+		{"Data-Flow Diagram", dataFlowDiagramFilenamePNG},
+		{"Attack Paths", attackPathsDiagramFilenamePNG},
+		{"Data Mapping", dataAssetDiagramFilenamePNG},
+	} {
+		if fileExists(diagram[1]) {
+			diagramLinks = append(diagramLinks, "["+diagram[0]+"]("+filepath.Base(diagram[1])+")")
+		}
+	}
+	if len(diagramLinks) > 0 {
+		markdown.WriteString("Diagrams: " + strings.Join(diagramLinks, " · ") + "\n\n")
+	}
+	if compact {
+		writeRisksCompactMarkdown(&markdown)
+	} else {
+		writeRiskCategoriesMarkdown(&markdown)
+	}
+	err := ioutil.WriteFile(reportFilename, []byte(markdown.String()), 0644)
+	checkErr(err)
+}
+
+func writeRiskSummaryMarkdown(markdown *strings.Builder) {
+	markdown.WriteString("| Severity | Total |")
+	separator := "|---|---:|"
+	for _, value := range model.RiskStatusValues() {
+		markdown.WriteString(" " + value.(model.RiskStatus).Title() + " |")
+		separator += "---:|"
+	}
+	markdown.WriteString("\n" + separator + "\n")
+	for i := len(model.RiskSeverityValues()) - 1; i >= 0; i-- { // highest first
+		severity := model.RiskSeverityValues()[i].(model.RiskSeverity)
+		risks := risksOfSeverity(model.AllRisks(), severity)
+		markdown.WriteString("| " + severity.Title() + " | " + strconv.Itoa(len(risks)) + " |")
+		for _, value := range model.RiskStatusValues() {
+			count := 0
+			for _, risk := range risks {
+				if risk.GetRiskTrackingStatusDefaultingUnchecked() == value.(model.RiskStatus) {
+					count++
+				}
+			}
+			markdown.WriteString(" " + strconv.Itoa(count) + " |")
+		}
+		markdown.WriteString("\n")
+	}
+	markdown.WriteString("\n")
+}
+
+func writeRiskCategoriesMarkdown(markdown *strings.Builder) {
+	markdown.WriteString("## Risks by Vulnerability Category\n\n")
+	for _, category := range model.SortedRiskCategories() {
+		risks := model.SortedRisksOfCategory(category)
+		markdown.WriteString("### " + escapeMarkdown(category.Title) + ": " + strconv.Itoa(len(model.ReduceToOnlyStillAtRisk(risks))) +
+			" / " + strconv.Itoa(len(risks)) + " Risks\n\n")
+		cweLink := "n/a"
+		if category.CWE > 0 {
+			cweLink = "[CWE " + strconv.Itoa(category.CWE) + "](https://cwe.mitre.org/data/definitions/" + strconv.Itoa(category.CWE) + ".html)"
+		}
+		markdown.WriteString("**Description** (" + category.STRIDE.Title() + "): " + cweLink + "\n\n")
+		markdown.WriteString(markdownFromFormattedText(category.Description) + "\n\n")
+		markdown.WriteString("**Impact**\n\n" + markdownFromFormattedText(category.Impact) + "\n\n")
+		markdown.WriteString("**Mitigation** (" + category.Function.Title() + "): " + markdownFromFormattedText(category.Action) + "\n\n")
+		markdown.WriteString(markdownFromFormattedText(category.Mitigation) + "\n\n")
+		if len(category.ASVS) > 0 {
+			markdown.WriteString("ASVS Chapter: [" + category.ASVS + "](https://owasp.org/www-project-application-security-verification-standard/)  \n")
+		}
+		if len(category.CheatSheet) > 0 {
+			markdown.WriteString("Cheat Sheet: <" + category.CheatSheet + ">\n")
+		}
+		markdown.WriteString("\n| Severity | Risk | Likelihood | Impact | Status | Tracking |\n|---|---|---|---|---|---|\n")
+		for _, risk := range risks {
+			markdown.WriteString("| " + risk.Severity.Title() + " | " + riskTitleMarkdown(risk) + " | " + risk.ExploitationLikelihood.Title() +
+				" | " + risk.ExploitationImpact.Title() + " | " + risk.GetRiskTrackingStatusDefaultingUnchecked().Title() +
+				" | " + riskTrackingMarkdown(risk) + " |\n")
+		}
+		markdown.WriteString("\n")
+	}
+}
+
+func writeRisksCompactMarkdown(markdown *strings.Builder) {
+	risks := model.ReduceToOnlyStillAtRisk(model.AllRisks())
+	sort.Sort(model.ByRiskSeveritySort(risks))
+	if len(risks) == 0 {
+		markdown.WriteString("No risks still at risk.\n")
+		return
+	}
+	mitigations := make([]string, 0)
+	for _, category := range model.SortedRiskCategories() {
+		if len(model.ReduceToOnlyStillAtRisk(model.SortedRisksOfCategory(category))) > 0 {
+			mitigation := "- **" + escapeMarkdown(category.Title) + "**: " + markdownFromFormattedText(category.Action)
+			if len(category.CheatSheet) > 0 {
+				mitigation += " ([Cheat Sheet](" + category.CheatSheet + "))"
+			}
+			mitigations = append(mitigations, mitigation)
+		}
+	}
+	// the findings are cut off (being sorted highest severity first) when the mitigations wouldn't fit anymore
+	reservedLength := len(strings.Join(mitigations, "\n")) + 200
+	markdown.WriteString("## Risks Still at Risk\n\n| Severity | Risk | Category | Status |\n|---|---|---|---|\n")
+	for i, risk := range risks {
+		row := "| " + risk.Severity.Title() + " | " + riskTitleMarkdown(risk) + " | " + escapeMarkdown(risk.Category.Title) +
+			" | " + risk.GetRiskTrackingStatusDefaultingUnchecked().Title() + " |\n"
+		if markdown.Len()+len(row)+reservedLength > markdownCompactMaxLength {
+			markdown.WriteString("\n*" + strconv.Itoa(len(risks)-i) + " more risks omitted, see the full report.*\n")
+			break
+		}
+		markdown.WriteString(row)
+	}
+	markdown.WriteString("\n## Mitigations\n\n" + strings.Join(mitigations, "\n") + "\n")
+}
+
+func riskTitleMarkdown(risk model.Risk) string {
+	return markdownFromFormattedText(escapeMarkdown(risk.Title)) + "<br>`" + risk.SyntheticId + "`"
+}
+
+func riskTrackingMarkdown(risk model.Risk) string {
+	if risk.GetRiskTrackingStatusDefaultingUnchecked() == model.Unchecked {
+		return ""
+	}
+	tracking := risk.GetRiskTracking()
+	parts := make([]string, 0)
+	if date := tracking.Date.Format("2006-01-02"); date != "0001-01-01" {
+		parts = append(parts, date)
+	}
+	for _, part := range []string{tracking.CheckedBy, tracking.Ticket, tracking.Justification} {
+		if len(part) > 0 {
+			parts = append(parts, escapeMarkdown(part))
+		}
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// markdownFromFormattedText converts the HTML-like formatting tags of risk rule texts and titles into Markdown
+func markdownFromFormattedText(text string) string {
+	text = markdownLinkRegEx.ReplaceAllString(text, "[$2]($1)")
+	return strings.TrimSpace(markdownFormattingTags.Replace(strings.ReplaceAll(text, "\n", " ")))
+}
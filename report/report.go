@@ -19,48 +19,7 @@ import (
 	"github.com/jung-kurt/gofpdf/contrib/gofpdi"
 	"github.com/threagile/threagile/colors"
 	"github.com/threagile/threagile/model"
-	accidental_secret_leak "github.com/threagile/threagile/risks/built-in/accidental-secret-leak"
-	code_backdooring "github.com/threagile/threagile/risks/built-in/code-backdooring"
-	container_baseimage_backdooring "github.com/threagile/threagile/risks/built-in/container-baseimage-backdooring"
-	container_platform_escape "github.com/threagile/threagile/risks/built-in/container-platform-escape"
-	cross_site_request_forgery "github.com/threagile/threagile/risks/built-in/cross-site-request-forgery"
-	cross_site_scripting "github.com/threagile/threagile/risks/built-in/cross-site-scripting"
-	dos_risky_access_across_trust_boundary "github.com/threagile/threagile/risks/built-in/dos-risky-access-across-trust-boundary"
-	incomplete_model "github.com/threagile/threagile/risks/built-in/incomplete-model"
-	ldap_injection "github.com/threagile/threagile/risks/built-in/ldap-injection"
-	missing_authentication "github.com/threagile/threagile/risks/built-in/missing-authentication"
-	missing_authentication_second_factor "github.com/threagile/threagile/risks/built-in/missing-authentication-second-factor"
-	missing_build_infrastructure "github.com/threagile/threagile/risks/built-in/missing-build-infrastructure"
-	missing_cloud_hardening "github.com/threagile/threagile/risks/built-in/missing-cloud-hardening"
-	missing_file_validation "github.com/threagile/threagile/risks/built-in/missing-file-validation"
-	missing_hardening "github.com/threagile/threagile/risks/built-in/missing-hardening"
-	missing_identity_propagation "github.com/threagile/threagile/risks/built-in/missing-identity-propagation"
-	missing_identity_provider_isolation "github.com/threagile/threagile/risks/built-in/missing-identity-provider-isolation"
-	missing_identity_store "github.com/threagile/threagile/risks/built-in/missing-identity-store"
-	missing_network_segmentation "github.com/threagile/threagile/risks/built-in/missing-network-segmentation"
-	missing_vault "github.com/threagile/threagile/risks/built-in/missing-vault"
-	missing_vault_isolation "github.com/threagile/threagile/risks/built-in/missing-vault-isolation"
-	missing_waf "github.com/threagile/threagile/risks/built-in/missing-waf"
-	mixed_targets_on_shared_runtime "github.com/threagile/threagile/risks/built-in/mixed-targets-on-shared-runtime"
-	path_traversal "github.com/threagile/threagile/risks/built-in/path-traversal"
-	push_instead_of_pull_deployment "github.com/threagile/threagile/risks/built-in/push-instead-of-pull-deployment"
-	search_query_injection "github.com/threagile/threagile/risks/built-in/search-query-injection"
-	server_side_request_forgery "github.com/threagile/threagile/risks/built-in/server-side-request-forgery"
-	service_registry_poisoning "github.com/threagile/threagile/risks/built-in/service-registry-poisoning"
-	sql_nosql_injection "github.com/threagile/threagile/risks/built-in/sql-nosql-injection"
-	unchecked_deployment "github.com/threagile/threagile/risks/built-in/unchecked-deployment"
-	unencrypted_asset "github.com/threagile/threagile/risks/built-in/unencrypted-asset"
-	unencrypted_communication "github.com/threagile/threagile/risks/built-in/unencrypted-communication"
-	unguarded_access_from_internet "github.com/threagile/threagile/risks/built-in/unguarded-access-from-internet"
-	unguarded_direct_datastore_access "github.com/threagile/threagile/risks/built-in/unguarded-direct-datastore-access"
-	unnecessary_communication_link "github.com/threagile/threagile/risks/built-in/unnecessary-communication-link"
-	unnecessary_data_asset "github.com/threagile/threagile/risks/built-in/unnecessary-data-asset"
-	unnecessary_data_transfer "github.com/threagile/threagile/risks/built-in/unnecessary-data-transfer"
-	unnecessary_technical_asset "github.com/threagile/threagile/risks/built-in/unnecessary-technical-asset"
-	untrusted_deserialization "github.com/threagile/threagile/risks/built-in/untrusted-deserialization"
-	wrong_communication_link_content "github.com/threagile/threagile/risks/built-in/wrong-communication-link-content"
-	wrong_trust_boundary_content "github.com/threagile/threagile/risks/built-in/wrong-trust-boundary-content"
-	xml_external_entity "github.com/threagile/threagile/risks/built-in/xml-external-entity"
+	"github.com/threagile/threagile/risks"
 	"github.com/wcharczuk/go-chart"
 	"github.com/wcharczuk/go-chart/drawing"
 )
@@ -95,8 +54,10 @@ func WriteReportPDF(reportFilename string,
 	templateFilename string,
 	dataFlowDiagramFilenamePNG string,
 	dataAssetDiagramFilenamePNG string,
+	attackPathsDiagramFilenamePNG string,
 	modelFilename string,
 	skipRiskRules string,
+	enableRiskRules string,
 	buildTimestamp string,
 	modelHash string,
 	introTextRAA string, customRiskRules map[string]model.CustomRiskRule) {
@@ -109,8 +70,15 @@ func WriteReportPDF(reportFilename string,
 	createImpactInitialRisks()
 	createRiskMitigationStatus()
 	createImpactRemainingRisks()
+	if model.HasBaseline() {
+		createRiskDelta()
+	}
+	if model.HasSASTFindings() {
+		createSASTConfirmation()
+	}
 	createTargetDescription(filepath.Dir(modelFilename))
 	embedDataFlowDiagram(dataFlowDiagramFilenamePNG)
+	createAttackPaths(attackPathsDiagramFilenamePNG)
 	createSecurityRequirements()
 	createAbuseCases()
 	createTagListing()
@@ -118,6 +86,7 @@ func WriteReportPDF(reportFilename string,
 	createAssignmentByFunction()
 	createRAA(introTextRAA)
 	embedDataRiskMapping(dataAssetDiagramFilenamePNG)
+	createComplianceCoverage()
 	//createDataRiskQuickWins()
 	createOutOfScopeAssets()
 	createModelFailures()
@@ -127,7 +96,7 @@ func WriteReportPDF(reportFilename string,
 	createDataAssets()
 	createTrustBoundaries()
 	createSharedRuntimes()
-	createRiskRulesChecked(modelFilename, skipRiskRules, buildTimestamp, modelHash, customRiskRules)
+	createRiskRulesChecked(modelFilename, skipRiskRules, enableRiskRules, buildTimestamp, modelHash, customRiskRules)
 	createDisclaimer()
 	writeReportToFile(reportFilename)
 }
@@ -287,6 +256,22 @@ func createTableOfContents() {
 	pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
 	pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
 
+	if model.HasBaseline() {
+		y += 6
+		pdf.Text(11, y, "    "+"Risk Delta since Baseline")
+		pdf.Text(175, y, "{risk-delta}")
+		pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+		pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
+	}
+
+	if model.HasSASTFindings() {
+		y += 6
+		pdf.Text(11, y, "    "+"Risks Confirmed by SAST Findings")
+		pdf.Text(175, y, "{sast-confirmation}")
+		pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+		pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
+	}
+
 	y += 6
 	pdf.Text(11, y, "    "+"Application Overview")
 	pdf.Text(175, y, "{target-overview}")
@@ -299,6 +284,12 @@ func createTableOfContents() {
 	pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
 	pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
 
+	y += 6
+	pdf.Text(11, y, "    "+"Attack Paths: "+strconv.Itoa(len(model.AttackPaths())))
+	pdf.Text(175, y, "{attack-paths}")
+	pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+	pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
+
 	y += 6
 	pdf.Text(11, y, "    "+"Security Requirements")
 	pdf.Text(175, y, "{security-requirements}")
@@ -341,6 +332,12 @@ func createTableOfContents() {
 	pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
 	pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
 
+	y += 6
+	pdf.Text(11, y, "    "+"Compliance Coverage")
+	pdf.Text(175, y, "{compliance-coverage}")
+	pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+	pdf.Link(10, y-5, 172.5, 6.5, pdf.AddLink())
+
 	/*
 		y += 6
 		assets := "assets"
@@ -3931,7 +3928,7 @@ func createSharedRuntimes() {
 	}
 }
 
-func createRiskRulesChecked(modelFilename string, skipRiskRules string, buildTimestamp string, modelHash string, customRiskRules map[string]model.CustomRiskRule) {
+func createRiskRulesChecked(modelFilename string, skipRiskRules string, enableRiskRules string, buildTimestamp string, modelHash string, customRiskRules map[string]model.CustomRiskRule) {
 	uni := pdf.UnicodeTranslatorFromDescriptor("")
 	pdf.SetTextColor(0, 0, 0)
 	title := uni("Regras de risco verificadas por Threagile")
@@ -3960,48 +3957,11 @@ func createRiskRulesChecked(modelFilename string, skipRiskRules string, buildTim
 	html.Write(5, strBuilder.String())
 	strBuilder.Reset()
 
-	// TODO use the new plugin system to discover risk rules instead of hard-coding them here:
 	skippedRules := strings.Split(skipRiskRules, ",")
-	skipped := ""
 	pdf.Ln(-1)
 
-	for id, customRule := range customRiskRules {
-		pdf.Ln(-1)
-		pdf.SetFont("Helvetica", "B", fontSizeBody)
-		if model.Contains(skippedRules, id) {
-			skipped = "SKIPPED - "
-		} else {
-			skipped = ""
-		}
-		pdf.CellFormat(190, 3, uni(skipped+customRule.Category().Title), "0", 0, "", false, 0, "")
-		pdf.Ln(-1)
-		pdf.SetFont("Helvetica", "", fontSizeSmall)
-		pdf.CellFormat(190, 6, id, "0", 0, "", false, 0, "")
-		pdf.Ln(-1)
-		pdf.SetFont("Helvetica", "I", fontSizeBody)
-		pdf.CellFormat(190, 6, "Custom Risk Rule", "0", 0, "", false, 0, "")
-		pdf.Ln(-1)
-		pdf.SetFont("Helvetica", "", fontSizeBody)
-		pdfColorGray()
-		pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-		pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-		pdfColorBlack()
-		pdf.MultiCell(160, 6, uni(customRule.Category().STRIDE.Title()), "0", "0", false)
-		pdfColorGray()
-		pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-		pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-		pdfColorBlack()
-		pdf.MultiCell(160, 6, uni(firstParagraph(customRule.Category().Description)), "0", "0", false)
-		pdfColorGray()
-		pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-		pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-		pdfColorBlack()
-		pdf.MultiCell(160, 6, uni(customRule.Category().DetectionLogic), "0", "0", false)
-		pdfColorGray()
-		pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-		pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-		pdfColorBlack()
-		pdf.MultiCell(160, 6, uni(customRule.Category().RiskAssessment), "0", "0", false)
+	for _, customRule := range risks.CustomRiskRules(customRiskRules) {
+		addRiskRuleChecked(customRule, skippedRules, "Custom Risk Rule")
 	}
 
 	for _, key := range model.SortedKeysOfIndividualRiskCategories() {
@@ -4039,1487 +3999,106 @@ func createRiskRulesChecked(modelFilename string, skipRiskRules string, buildTim
 		pdf.MultiCell(160, 6, uni(indivRiskCat.RiskAssessment), "0", "0", false)
 	}
 
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, accidental_secret_leak.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
+	for _, builtInRule := range risks.BuiltInRiskRules() {
+		if builtInRule.IsEnabled(enableRiskRules) {
+			addRiskRuleChecked(builtInRule, skippedRules, "")
+		}
 	}
-	pdf.CellFormat(190, 3, uni(skipped+accidental_secret_leak.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(accidental_secret_leak.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(accidental_secret_leak.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(accidental_secret_leak.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(accidental_secret_leak.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(accidental_secret_leak.Category().RiskAssessment), "0", "0", false)
+}
 
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, code_backdooring.Category().Id) {
+func addRiskRuleChecked(rule risks.RegisteredRiskRule, skippedRules []string, kind string) {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	skipped := ""
+	if model.Contains(skippedRules, rule.ID) {
 		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
 	}
-	pdf.CellFormat(190, 3, uni(skipped+code_backdooring.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(code_backdooring.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(code_backdooring.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(code_backdooring.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(code_backdooring.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(code_backdooring.Category().RiskAssessment), "0", "0", false)
-
 	pdf.Ln(-1)
 	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, container_baseimage_backdooring.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+container_baseimage_backdooring.Category().Title), "0", 0, "", false, 0, "")
+	pdf.CellFormat(190, 3, uni(skipped+rule.Category().Title), "0", 0, "", false, 0, "")
 	pdf.Ln(-1)
 	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(container_baseimage_backdooring.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_baseimage_backdooring.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(container_baseimage_backdooring.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_baseimage_backdooring.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_baseimage_backdooring.Category().RiskAssessment), "0", "0", false)
-
+	pdf.CellFormat(190, 6, uni(rule.ID), "0", 0, "", false, 0, "")
 	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, container_platform_escape.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
+	if len(kind) > 0 {
+		pdf.SetFont("Helvetica", "I", fontSizeBody)
+		pdf.CellFormat(190, 6, kind, "0", 0, "", false, 0, "")
+		pdf.Ln(-1)
 	}
-	pdf.CellFormat(190, 3, uni(skipped+container_platform_escape.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(container_platform_escape.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
 	pdf.SetFont("Helvetica", "", fontSizeBody)
 	pdfColorGray()
 	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
 	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
 	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_platform_escape.Category().STRIDE.Title()), "0", "0", false)
+	pdf.MultiCell(160, 6, uni(rule.Category().STRIDE.Title()), "0", "0", false)
 	pdfColorGray()
 	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
 	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
 	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(container_platform_escape.Category().Description)), "0", "0", false)
+	pdf.MultiCell(160, 6, uni(firstParagraph(rule.Category().Description)), "0", "0", false)
 	pdfColorGray()
 	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
 	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
 	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_platform_escape.Category().DetectionLogic), "0", "0", false)
+	pdf.MultiCell(160, 6, uni(rule.Category().DetectionLogic), "0", "0", false)
 	pdfColorGray()
 	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
 	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
 	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(container_platform_escape.Category().RiskAssessment), "0", "0", false)
+	pdf.MultiCell(160, 6, uni(rule.Category().RiskAssessment), "0", "0", false)
+}
 
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, cross_site_request_forgery.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+cross_site_request_forgery.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(cross_site_request_forgery.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_request_forgery.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(cross_site_request_forgery.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_request_forgery.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_request_forgery.Category().RiskAssessment), "0", "0", false)
+func createTargetDescription(baseFolder string) {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTextColor(0, 0, 0)
+	title := uni("Application Overview")
+	addHeadline(title, false)
+	defineLinkTarget("{target-overview}")
+	currentChapterTitleBreadcrumb = title
 
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, cross_site_scripting.Category().Id) {
-		skipped = "SKIPPED - "
+	var intro strings.Builder
+	html := pdf.HTMLBasicNew()
+
+	intro.WriteString(uni("<b>Business Criticality</b><br><br>"))
+	intro.WriteString(uni("The overall business criticality of \"" + uni(model.ParsedModelRoot.Title) + "\" was rated as:<br><br>"))
+	html.Write(5, intro.String())
+	criticality := model.ParsedModelRoot.BusinessCriticality
+	intro.Reset()
+	pdfColorGray()
+	intro.WriteString("(  ")
+	if criticality == model.Archive {
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorBlack()
+		intro.WriteString("<b><u>" + strings.ToUpper(model.Archive.String()) + "</u></b>")
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorGray()
 	} else {
-		skipped = ""
+		intro.WriteString(model.Archive.String())
 	}
-	pdf.CellFormat(190, 3, skipped+cross_site_scripting.Category().Title, "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(cross_site_scripting.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_scripting.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(cross_site_scripting.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_scripting.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(cross_site_scripting.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, dos_risky_access_across_trust_boundary.Category().Id) {
-		skipped = "SKIPPED - "
+	intro.WriteString("  |  ")
+	if criticality == model.Operational {
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorBlack()
+		intro.WriteString("<b><u>" + strings.ToUpper(model.Operational.String()) + "</u></b>")
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorGray()
 	} else {
-		skipped = ""
+		intro.WriteString(model.Operational.String())
 	}
-	pdf.CellFormat(190, 3, uni(skipped+dos_risky_access_across_trust_boundary.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(dos_risky_access_across_trust_boundary.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(dos_risky_access_across_trust_boundary.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(dos_risky_access_across_trust_boundary.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(dos_risky_access_across_trust_boundary.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(dos_risky_access_across_trust_boundary.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, incomplete_model.Category().Id) {
-		skipped = "SKIPPED - "
+	intro.WriteString("  |  ")
+	if criticality == model.Important {
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorBlack()
+		intro.WriteString("<b><u>" + strings.ToUpper(model.Important.String()) + "</u></b>")
+		html.Write(5, intro.String())
+		intro.Reset()
+		pdfColorGray()
 	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+incomplete_model.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(incomplete_model.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(incomplete_model.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(incomplete_model.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(incomplete_model.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(incomplete_model.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, ldap_injection.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+ldap_injection.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(ldap_injection.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(ldap_injection.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(ldap_injection.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(ldap_injection.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(ldap_injection.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_authentication.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_authentication.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_authentication.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_authentication.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_authentication_second_factor.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_authentication_second_factor.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_authentication_second_factor.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication_second_factor.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_authentication_second_factor.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication_second_factor.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_authentication_second_factor.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_build_infrastructure.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_build_infrastructure.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_build_infrastructure.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_build_infrastructure.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_build_infrastructure.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_build_infrastructure.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_build_infrastructure.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_cloud_hardening.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_cloud_hardening.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_cloud_hardening.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_cloud_hardening.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_cloud_hardening.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_cloud_hardening.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_cloud_hardening.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_file_validation.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_file_validation.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_file_validation.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_file_validation.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_file_validation.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_file_validation.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_file_validation.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, uni(missing_hardening.Category().Id)) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, skipped+missing_hardening.Category().Title, "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_hardening.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_hardening.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_hardening.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_hardening.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_hardening.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, uni(missing_identity_propagation.Category().Id)) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_identity_propagation.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_identity_propagation.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_propagation.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_identity_propagation.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_propagation.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_propagation.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_identity_provider_isolation.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_identity_provider_isolation.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_identity_provider_isolation.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_provider_isolation.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_identity_provider_isolation.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_provider_isolation.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_provider_isolation.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_identity_store.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_identity_store.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_identity_store.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_store.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_identity_store.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_store.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_identity_store.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_network_segmentation.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_network_segmentation.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_network_segmentation.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_network_segmentation.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_network_segmentation.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_network_segmentation.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_network_segmentation.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_vault.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_vault.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_vault.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_vault.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_vault_isolation.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_vault_isolation.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_vault_isolation.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault_isolation.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_vault_isolation.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault_isolation.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_vault_isolation.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, missing_waf.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+missing_waf.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(missing_waf.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_waf.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(missing_waf.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_waf.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(missing_waf.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, mixed_targets_on_shared_runtime.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+mixed_targets_on_shared_runtime.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(mixed_targets_on_shared_runtime.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(mixed_targets_on_shared_runtime.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(mixed_targets_on_shared_runtime.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(mixed_targets_on_shared_runtime.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(mixed_targets_on_shared_runtime.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, path_traversal.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+path_traversal.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(path_traversal.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(path_traversal.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(path_traversal.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(path_traversal.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(path_traversal.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, push_instead_of_pull_deployment.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+push_instead_of_pull_deployment.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(push_instead_of_pull_deployment.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(push_instead_of_pull_deployment.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(push_instead_of_pull_deployment.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(push_instead_of_pull_deployment.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(push_instead_of_pull_deployment.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, search_query_injection.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+search_query_injection.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(search_query_injection.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(search_query_injection.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(search_query_injection.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(search_query_injection.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(search_query_injection.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, server_side_request_forgery.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+server_side_request_forgery.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(server_side_request_forgery.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(server_side_request_forgery.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(server_side_request_forgery.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(server_side_request_forgery.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(server_side_request_forgery.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, service_registry_poisoning.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+service_registry_poisoning.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(service_registry_poisoning.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(service_registry_poisoning.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(service_registry_poisoning.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(service_registry_poisoning.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(service_registry_poisoning.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, sql_nosql_injection.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+sql_nosql_injection.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(sql_nosql_injection.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(sql_nosql_injection.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(sql_nosql_injection.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(sql_nosql_injection.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(sql_nosql_injection.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unchecked_deployment.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unchecked_deployment.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unchecked_deployment.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unchecked_deployment.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unchecked_deployment.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unchecked_deployment.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unchecked_deployment.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unencrypted_asset.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unencrypted_asset.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unencrypted_asset.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_asset.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unencrypted_asset.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_asset.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_asset.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unencrypted_communication.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unencrypted_communication.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unencrypted_communication.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_communication.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unencrypted_communication.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_communication.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unencrypted_communication.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unguarded_access_from_internet.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unguarded_access_from_internet.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unguarded_access_from_internet.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_access_from_internet.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unguarded_access_from_internet.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_access_from_internet.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_access_from_internet.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unguarded_direct_datastore_access.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unguarded_direct_datastore_access.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unguarded_direct_datastore_access.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_direct_datastore_access.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unguarded_direct_datastore_access.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_direct_datastore_access.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unguarded_direct_datastore_access.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unnecessary_communication_link.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unnecessary_communication_link.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unnecessary_communication_link.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_communication_link.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unnecessary_communication_link.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_communication_link.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_communication_link.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unnecessary_data_asset.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unnecessary_data_asset.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unnecessary_data_asset.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_asset.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unnecessary_data_asset.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_asset.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_asset.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unnecessary_data_transfer.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unnecessary_data_transfer.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unnecessary_data_transfer.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_transfer.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unnecessary_data_transfer.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_transfer.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_data_transfer.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, unnecessary_technical_asset.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+unnecessary_technical_asset.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(unnecessary_technical_asset.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_technical_asset.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(unnecessary_technical_asset.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_technical_asset.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(unnecessary_technical_asset.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, untrusted_deserialization.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+untrusted_deserialization.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(untrusted_deserialization.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(untrusted_deserialization.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(untrusted_deserialization.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(untrusted_deserialization.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(untrusted_deserialization.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, wrong_communication_link_content.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+wrong_communication_link_content.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(wrong_communication_link_content.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_communication_link_content.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(wrong_communication_link_content.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_communication_link_content.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_communication_link_content.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, wrong_trust_boundary_content.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+wrong_trust_boundary_content.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(wrong_trust_boundary_content.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_trust_boundary_content.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(wrong_trust_boundary_content.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_trust_boundary_content.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(wrong_trust_boundary_content.Category().RiskAssessment), "0", "0", false)
-
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "B", fontSizeBody)
-	if model.Contains(skippedRules, xml_external_entity.Category().Id) {
-		skipped = "SKIPPED - "
-	} else {
-		skipped = ""
-	}
-	pdf.CellFormat(190, 3, uni(skipped+xml_external_entity.Category().Title), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeSmall)
-	pdf.CellFormat(190, 6, uni(xml_external_entity.Category().Id), "0", 0, "", false, 0, "")
-	pdf.Ln(-1)
-	pdf.SetFont("Helvetica", "", fontSizeBody)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "STRIDE:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(xml_external_entity.Category().STRIDE.Title()), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Description:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(firstParagraph(xml_external_entity.Category().Description)), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Detection:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(xml_external_entity.Category().DetectionLogic), "0", "0", false)
-	pdfColorGray()
-	pdf.CellFormat(5, 6, "", "0", 0, "", false, 0, "")
-	pdf.CellFormat(25, 6, "Rating:", "0", 0, "", false, 0, "")
-	pdfColorBlack()
-	pdf.MultiCell(160, 6, uni(xml_external_entity.Category().RiskAssessment), "0", "0", false)
-}
-
-func createTargetDescription(baseFolder string) {
-	uni := pdf.UnicodeTranslatorFromDescriptor("")
-	pdf.SetTextColor(0, 0, 0)
-	title := uni("Application Overview")
-	addHeadline(title, false)
-	defineLinkTarget("{target-overview}")
-	currentChapterTitleBreadcrumb = title
-
-	var intro strings.Builder
-	html := pdf.HTMLBasicNew()
-
-	intro.WriteString(uni("<b>Business Criticality</b><br><br>"))
-	intro.WriteString(uni("The overall business criticality of \"" + uni(model.ParsedModelRoot.Title) + "\" was rated as:<br><br>"))
-	html.Write(5, intro.String())
-	criticality := model.ParsedModelRoot.BusinessCriticality
-	intro.Reset()
-	pdfColorGray()
-	intro.WriteString("(  ")
-	if criticality == model.Archive {
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorBlack()
-		intro.WriteString("<b><u>" + strings.ToUpper(model.Archive.String()) + "</u></b>")
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorGray()
-	} else {
-		intro.WriteString(model.Archive.String())
-	}
-	intro.WriteString("  |  ")
-	if criticality == model.Operational {
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorBlack()
-		intro.WriteString("<b><u>" + strings.ToUpper(model.Operational.String()) + "</u></b>")
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorGray()
-	} else {
-		intro.WriteString(model.Operational.String())
-	}
-	intro.WriteString("  |  ")
-	if criticality == model.Important {
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorBlack()
-		intro.WriteString("<b><u>" + strings.ToUpper(model.Important.String()) + "</u></b>")
-		html.Write(5, intro.String())
-		intro.Reset()
-		pdfColorGray()
-	} else {
-		intro.WriteString(model.Important.String())
+		intro.WriteString(model.Important.String())
 	}
 	intro.WriteString("  |  ")
 	if criticality == model.Critical {
@@ -5634,6 +4213,257 @@ func embedDataFlowDiagram(diagramFilenamePNG string) {
 	html := pdf.HTMLBasicNew()
 	html.Write(5, intro.String())
 
+	embedDiagramImage(diagramFilenamePNG)
+
+	// add diagram legend page
+	if embedDiagramLegendPage {
+		pdf.AddPage()
+		gofpdi.UseImportedTemplate(pdf, diagramLegendTemplateId, 0, 0, 0, 300)
+	}
+}
+
+func createRiskDelta() {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Risk Delta since Baseline"
+	addHeadline(chapTitle, false)
+	defineLinkTarget("{risk-delta}")
+	currentChapterTitleBreadcrumb = chapTitle
+
+	deltas := model.RiskDeltas()
+	counts := make(map[model.RiskDeltaStatus]int)
+	for _, delta := range deltas {
+		counts[delta.Status]++
+	}
+	html := pdf.HTMLBasicNew()
+	html.Write(5, uni("Este capítulo compara os riscos identificados com os riscos da linha de base (o resultado de uma execução anterior) "+
+		"por meio de seus IDs sintéticos: "+
+		"<b>"+strconv.Itoa(counts[model.NewRisk])+"</b> riscos são novos, "+
+		"<b>"+strconv.Itoa(counts[model.SeverityChangedRisk])+"</b> tiveram sua severidade alterada, "+
+		"<b>"+strconv.Itoa(counts[model.UnchangedRisk])+"</b> permanecem inalterados e "+
+		"<b>"+strconv.Itoa(counts[model.ResolvedRisk])+"</b> foram resolvidos. "+
+		"Os riscos inalterados não são listados a seguir."))
+
+	var strBuilder strings.Builder
+	for _, status := range []model.RiskDeltaStatus{model.NewRisk, model.SeverityChangedRisk, model.ResolvedRisk} {
+		if counts[status] == 0 {
+			continue
+		}
+		if pdf.GetY() > 240 {
+			pageBreak()
+			pdf.SetY(36)
+		} else {
+			html.Write(5, "<br><br><br>")
+		}
+		pdfColorBlack()
+		html.Write(5, "<b>"+status.Title()+": "+strconv.Itoa(counts[status])+"</b>")
+		for _, delta := range deltas {
+			if delta.Status != status {
+				continue
+			}
+			if pdf.GetY() > 260 {
+				pageBreak()
+				pdf.SetY(36)
+			}
+			severity := delta.Severity
+			switch status {
+			case model.SeverityChangedRisk:
+				severity = delta.BaselineSeverity + " > " + delta.Severity
+			case model.ResolvedRisk:
+				severity = delta.BaselineSeverity
+			}
+			pdfColorBlack()
+			strBuilder.WriteString("<br>")
+			strBuilder.WriteString(uni(delta.Title))
+			strBuilder.WriteString(" (" + severity + ")")
+			html.Write(5, strBuilder.String())
+			strBuilder.Reset()
+			pdfColorGray()
+			html.Write(5, "<br>"+uni(delta.SyntheticId))
+		}
+	}
+	pdfColorBlack()
+}
+
+func createSASTConfirmation() {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Risks Confirmed by SAST Findings"
+	addHeadline(chapTitle, false)
+	defineLinkTarget("{sast-confirmation}")
+	currentChapterTitleBreadcrumb = chapTitle
+
+	confirmedRisks := model.ConfirmedRisks()
+	unconfirmingFindings := model.UnconfirmingSASTFindings()
+	html := pdf.HTMLBasicNew()
+	html.Write(5, uni("Este capítulo correlaciona os achados das ferramentas SAST (lidos de arquivos SARIF) com os riscos identificados "+
+		"por meio de seus CWEs: um risco é confirmado quando o repositório de código-fonte de seu ativo técnico mais relevante "+
+		"(referenciado via <b>sourcecode_repositories</b>) tem achados do CWE de sua categoria de risco. "+
+		"<b>"+strconv.Itoa(len(confirmedRisks))+"</b> riscos são confirmados por achados de código e "+
+		"<b>"+strconv.Itoa(len(unconfirmingFindings))+"</b> de <b>"+strconv.Itoa(len(model.ParsedModelRoot.SASTFindings))+"</b> achados "+
+		"não correspondem a nenhum risco modelado (o que pode indicar lacunas no modelo)."))
+
+	var strBuilder strings.Builder
+	for _, risk := range confirmedRisks {
+		if pdf.GetY() > 250 {
+			pageBreak()
+			pdf.SetY(36)
+		} else {
+			html.Write(5, "<br><br>")
+		}
+		pdfColorBlack()
+		strBuilder.WriteString(uni(risk.Title))
+		strBuilder.WriteString(" (" + risk.Severity.String() + ", " + risk.ExploitationLikelihood.String() + ")")
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		pdfColorGray()
+		html.Write(5, "<br>"+uni(risk.SyntheticId))
+		for _, finding := range risk.SASTFindings {
+			if pdf.GetY() > 265 {
+				pageBreak()
+				pdf.SetY(36)
+			}
+			html.Write(5, "<br>"+uni("    "+finding.Tool+" "+finding.RuleId+" ("+finding.CWETitles()+") at "+finding.Repository+" "+finding.Location))
+		}
+	}
+	pdfColorBlack()
+}
+
+func createComplianceCoverage() {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Compliance Coverage"
+	addHeadline(chapTitle, false)
+	defineLinkTarget("{compliance-coverage}")
+	currentChapterTitleBreadcrumb = chapTitle
+
+	html := pdf.HTMLBasicNew()
+	html.Write(5, uni("Este capítulo lista, por framework de conformidade, os controles mapeados às categorias de risco verificadas. "+
+		"Um controle é marcado em <b>vermelho</b> quando ainda há riscos em aberto em alguma de suas categorias, em <b>verde</b> "+
+		"quando todos os seus riscos foram mitigados e em preto quando nenhum risco foi identificado. "+
+		"Além dos mapeamentos embutidos, o modelo pode mapear categorias de risco a controles via <b>compliance_mappings</b>."))
+	coverages := model.ComplianceCoverage()
+	if len(coverages) == 0 {
+		html.Write(5, "<br><br>"+uni("Nenhuma categoria de risco verificada está mapeada a controles."))
+		return
+	}
+
+	var strBuilder strings.Builder
+	framework := ""
+	for _, coverage := range coverages {
+		if coverage.Framework != framework {
+			framework = coverage.Framework
+			if pdf.GetY() > 240 {
+				pageBreak()
+				pdf.SetY(36)
+			} else {
+				html.Write(5, "<br><br><br>")
+			}
+			pdfColorBlack()
+			html.Write(5, "<b>"+uni(model.ComplianceFrameworkTitle(framework))+"</b>")
+		} else if pdf.GetY() > 265 {
+			pageBreak()
+			pdf.SetY(36)
+		}
+		switch coverage.Status {
+		case model.ComplianceOpenRisks:
+			colors.ColorRiskStatusUnchecked(pdf)
+		case model.ComplianceMitigatedRisks:
+			colors.ColorRiskStatusMitigated(pdf)
+		default:
+			pdfColorBlack()
+		}
+		strBuilder.WriteString("<br><b>")
+		strBuilder.WriteString(uni(coverage.Control))
+		strBuilder.WriteString("</b>: ")
+		strBuilder.WriteString(coverage.Status.Title())
+		strBuilder.WriteString(" (" + strconv.Itoa(coverage.OpenRisks) + " open, " + strconv.Itoa(coverage.MitigatedRisks) + " mitigated)")
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		pdfColorGray()
+		html.Write(5, " "+uni(strings.Join(coverage.RiskCategoryIds, ", ")))
+	}
+	pdfColorBlack()
+}
+
+func createAttackPaths(diagramFilenamePNG string) {
+	uni := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTextColor(0, 0, 0)
+	attackPaths := model.AttackPaths()
+	chapTitle := "Attack Paths: " + strconv.Itoa(len(attackPaths))
+	addHeadline(chapTitle, false)
+	defineLinkTarget("{attack-paths}")
+	currentChapterTitleBreadcrumb = chapTitle
+
+	html := pdf.HTMLBasicNew()
+	html.Write(5, uni("Este capítulo lista os caminhos de ataque mais plausíveis (ao longo dos links de comunicação) de ativos técnicos "+
+		"expostos à internet ou usados como clientes até ativos técnicos que armazenam ativos de dados <b>estritamente confidenciais</b> "+
+		"ou de <b>missão crítica</b>. Cada salto custa um ponto, mais a força da autenticação do link de comunicação "+
+		"(de 0 para nenhuma até 2,5 para dois fatores) e mais um ponto ao cruzar um limite de confiança de rede. "+
+		"Quanto menor a pontuação, mais plausível o caminho. "+
+		"O diagrama a seguir destaca os links de comunicação dos caminhos e os ativos alvo em vermelho, os ativos de entrada em âmbar."))
+	if len(attackPaths) == 0 {
+		html.Write(5, "<br><br>"+uni("Nenhum caminho de ataque foi identificado."))
+		return
+	}
+
+	embedDiagramImage(diagramFilenamePNG)
+	isLandscapePage = false
+	pageBreak()
+	pdf.SetY(36)
+
+	pdf.SetFont("Helvetica", "", fontSizeSmall)
+	pdfColorGray()
+	html.Write(5, uni("Parágrafos de caminhos de ataque são clicáveis e vinculados ao capítulo do ativo técnico alvo correspondente."))
+	pdf.SetFont("Helvetica", "", fontSizeBody)
+
+	var strBuilder strings.Builder
+	for _, attackPath := range attackPaths {
+		if pdf.GetY() > 250 {
+			pageBreak()
+			pdf.SetY(36)
+		} else {
+			strBuilder.WriteString("<br><br>")
+		}
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		posY := pdf.GetY()
+		pdfColorBlack()
+		strBuilder.WriteString("<b>")
+		strBuilder.WriteString(uni(model.ParsedModelRoot.TechnicalAssets[attackPath.EntryTechnicalAssetId].Title))
+		strBuilder.WriteString("</b> to <b>")
+		strBuilder.WriteString(uni(model.ParsedModelRoot.TechnicalAssets[attackPath.TargetTechnicalAssetId].Title))
+		strBuilder.WriteString("</b>: score ")
+		strBuilder.WriteString(fmt.Sprintf("%.1f", attackPath.Score))
+		strBuilder.WriteString("<br>")
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		titles := make([]string, 0, len(attackPath.TechnicalAssetIds))
+		for _, id := range attackPath.TechnicalAssetIds {
+			titles = append(titles, model.ParsedModelRoot.TechnicalAssets[id].Title)
+		}
+		dataAssetTitles := make([]string, 0, len(attackPath.TargetDataAssetIds))
+		for _, id := range attackPath.TargetDataAssetIds {
+			dataAssetTitles = append(dataAssetTitles, model.ParsedModelRoot.DataAssets[id].Title)
+		}
+		strBuilder.WriteString(uni(strings.Join(titles, " > ")))
+		strBuilder.WriteString("<br>")
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		pdfColorGray()
+		strBuilder.WriteString(strconv.Itoa(attackPath.Hops) + " hops, " +
+			strconv.Itoa(attackPath.TrustBoundaryCrossings) + " trust boundary crossings, " +
+			fmt.Sprintf("%.1f", attackPath.AuthenticationStrength) + " authentication strength; data at target: ")
+		strBuilder.WriteString(uni(strings.Join(dataAssetTitles, ", ")))
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		pdf.Link(9, posY, 190, pdf.GetY()-posY+4, tocLinkIdByAssetId[attackPath.TargetTechnicalAssetId])
+	}
+	pdfColorBlack()
+}
+
+// embedDiagramImage embeds the diagram below the current position (or on a fresh landscape page if much wider than high)
+func embedDiagramImage(diagramFilenamePNG string) {
 	// check to rotate the image if it is wider than high
 	/* #nosec diagramFilenamePNG is not tainted */
 	imagePath, _ := os.Open(diagramFilenamePNG)
@@ -5697,12 +4527,6 @@ func embedDataFlowDiagram(diagramFilenamePNG string) {
 	}
 	pdf.ImageOptions(diagramFilenamePNG, 10, pdf.GetY(), embedWidth, embedHeight, true, options, 0, "")
 	isLandscapePage = false
-
-	// add diagram legend page
-	if embedDiagramLegendPage {
-		pdf.AddPage()
-		gofpdi.UseImportedTemplate(pdf, diagramLegendTemplateId, 0, 0, 0, 300)
-	}
 }
 
 func embedDataRiskMapping(diagramFilenamePNG string) {
@@ -0,0 +1,256 @@
+package report
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// ModelElementLocator finds the model file and position (line and column are 0 when unknown) of the model element
+// given by its path (like "technical_assets", "Some Title")
+type ModelElementLocator func(path ...string) (filename string, line int, column int)
+
+const sarifSchema, sarifVersion = "https://json.schemastore.org/sarif-2.1.0.json", "2.1.0"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalUriBaseIds map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	FullDescription      sarifMessage           `json:"fullDescription"`
+	Help                 sarifMessage           `json:"help"`
+	HelpUri              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId              string                 `json:"ruleId"`
+	RuleIndex           int                    `json:"ruleIndex"`
+	Kind                string                 `json:"kind"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations,omitempty"`
+	Fingerprints        map[string]string      `json:"fingerprints"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression     `json:"suppressions,omitempty"`
+	Properties          map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// sarifModelRoot is the base of the model file locations, which are relative to the folder of the model file
+const sarifModelRoot = "MODELROOT"
+
+// WriteRisksSARIF writes all risks as SARIF 2.1.0 log: each risk category becomes a rule and each risk a result located
+// at its most relevant model element, so that code scanning dashboards can show them along with other findings
+func WriteRisksSARIF(filename string, modelFolder string, locate ModelElementLocator) {
+	driver := sarifDriver{
+		Name:           "Threagile",
+		Version:        model.ThreagileVersion,
+		InformationUri: "https://threagile.io",
+		Rules:          make([]sarifRule, 0),
+	}
+	results := make([]sarifResult, 0)
+	for ruleIndex, category := range model.SortedRiskCategories() {
+		risks := model.SortedRisksOfCategory(category)
+		highestSeverity := model.HighestSeverity(risks)
+		tags := []string{"security", category.STRIDE.String()}
+		if category.CWE > 0 {
+			tags = append(tags, "external/cwe/cwe-"+strconv.Itoa(category.CWE))
+		}
+		driver.Rules = append(driver.Rules, sarifRule{
+			Id:                   category.Id,
+			Name:                 removeFormattingTags(category.Title),
+			ShortDescription:     sarifMessage{Text: removeFormattingTags(category.Title)},
+			FullDescription:      sarifMessage{Text: removeFormattingTags(category.Description)},
+			Help:                 sarifMessage{Text: removeFormattingTags(category.Mitigation)},
+			HelpUri:              category.CheatSheet,
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(highestSeverity)},
+			Properties: map[string]interface{}{
+				"tags":              tags,
+				"cwe":               category.CWE,
+				"function":          category.Function.String(),
+				"security-severity": sarifSecuritySeverity(highestSeverity),
+			},
+		})
+		for _, risk := range risks {
+			riskTracking := risk.GetRiskTracking()
+			status := risk.GetRiskTrackingStatusDefaultingUnchecked()
+			result := sarifResult{
+				RuleId:    category.Id,
+				RuleIndex: ruleIndex,
+				Kind:      "fail",
+				Level:     sarifLevel(risk.Severity),
+				Message:   sarifMessage{Text: removeFormattingTags(risk.Title)},
+				Locations: sarifLocations(risk, modelFolder, locate),
+				// the synthetic id is stable regardless of any changes of the model file layout
+				Fingerprints:        map[string]string{"syntheticRiskId/v1": risk.SyntheticId},
+				PartialFingerprints: map[string]string{"syntheticRiskId/v1": risk.SyntheticId},
+				Properties: map[string]interface{}{
+					"severity":                risk.Severity.String(),
+					"exploitation_likelihood": risk.ExploitationLikelihood.String(),
+					"exploitation_impact":     risk.ExploitationImpact.String(),
+					"data_breach_probability": risk.DataBreachProbability.String(),
+					"risk_status":             status.String(),
+				},
+			}
+			switch status {
+			case model.Accepted, model.FalsePositive:
+				result.Suppressions = []sarifSuppression{{Kind: "external", Status: "accepted", Justification: riskTracking.Justification}}
+			case model.Mitigated: // fixed
+				result.Kind, result.Level = "pass", "none"
+			}
+			if len(riskTracking.Ticket) > 0 {
+				result.Properties["ticket"] = riskTracking.Ticket
+			}
+			results = append(results, result)
+		}
+	}
+	jsonBytes, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:               sarifTool{Driver: driver},
+			OriginalUriBaseIds: map[string]sarifArtifactLocation{sarifModelRoot: sarifModelRootLocation(modelFolder)},
+			Results:            results,
+		}},
+	}, "", "  ")
+	checkErr(err)
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	checkErr(err)
+}
+
+// sarifLocations points to the most relevant communication link or else technical asset (or else the other elements)
+func sarifLocations(risk model.Risk, modelFolder string, locate ModelElementLocator) []sarifLocation {
+	var path []string
+	var kind, id string
+	if link, exists := model.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; exists {
+		path, kind, id = []string{"technical_assets", model.ParsedModelRoot.TechnicalAssets[link.SourceId].Title, "communication_links", link.Title}, "communication_links", link.Id
+	} else if technicalAsset, exists := model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; exists {
+		path, kind, id = []string{"technical_assets", technicalAsset.Title}, "technical_assets", technicalAsset.Id
+	} else if dataAsset, exists := model.ParsedModelRoot.DataAssets[risk.MostRelevantDataAssetId]; exists {
+		path, kind, id = []string{"data_assets", dataAsset.Title}, "data_assets", dataAsset.Id
+	} else if trustBoundary, exists := model.ParsedModelRoot.TrustBoundaries[risk.MostRelevantTrustBoundaryId]; exists {
+		path, kind, id = []string{"trust_boundaries", trustBoundary.Title}, "trust_boundaries", trustBoundary.Id
+	} else if sharedRuntime, exists := model.ParsedModelRoot.SharedRuntimes[risk.MostRelevantSharedRuntimeId]; exists {
+		path, kind, id = []string{"shared_runtimes", sharedRuntime.Title}, "shared_runtimes", sharedRuntime.Id
+	} else {
+		return nil
+	}
+	filename, line, column := locate(path...)
+	if len(filename) == 0 {
+		return nil
+	}
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocationOf(filename, modelFolder)},
+		LogicalLocations: []sarifLogicalLocation{{Name: id, FullyQualifiedName: kind + "/" + id}},
+	}
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line, StartColumn: column}
+	}
+	return []sarifLocation{location}
+}
+
+// sarifArtifactLocationOf locates the model file relative to the model folder (see sarifModelRootLocation), so that
+// the locations neither depend on the directory the analysis was started in nor on temporary folders (of the server)
+func sarifArtifactLocationOf(filename string, modelFolder string) sarifArtifactLocation {
+	absoluteFilename, err := filepath.Abs(filename)
+	checkErr(err)
+	absoluteModelFolder, err := filepath.Abs(modelFolder)
+	checkErr(err)
+	if relativeFilename, err := filepath.Rel(absoluteModelFolder, absoluteFilename); err == nil && !strings.HasPrefix(relativeFilename, "..") {
+		return sarifArtifactLocation{Uri: filepath.ToSlash(relativeFilename), UriBaseId: sarifModelRoot}
+	}
+	return sarifArtifactLocation{Uri: "file://" + filepath.ToSlash(absoluteFilename)}
+}
+
+// sarifModelRootLocation resolves the model folder relative to the directory the analysis was started in (usually the
+// repository, known to code scanning dashboards as %SRCROOT%), or else as absolute file URI
+func sarifModelRootLocation(modelFolder string) sarifArtifactLocation {
+	if folder := filepath.ToSlash(filepath.Clean(modelFolder)); !filepath.IsAbs(modelFolder) && !strings.HasPrefix(folder, "..") {
+		return sarifArtifactLocation{Uri: folder + "/", UriBaseId: "%SRCROOT%"}
+	}
+	absoluteModelFolder, err := filepath.Abs(modelFolder)
+	checkErr(err)
+	return sarifArtifactLocation{Uri: "file://" + strings.TrimSuffix(filepath.ToSlash(absoluteModelFolder), "/") + "/"}
+}
+
+func sarifLevel(severity model.RiskSeverity) string {
+	switch severity {
+	case model.CriticalSeverity, model.HighSeverity:
+		return "error"
+	case model.ElevatedSeverity, model.MediumSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifSecuritySeverity maps the severity to the CVSS-like score used by code scanning dashboards for ranking
+func sarifSecuritySeverity(severity model.RiskSeverity) string {
+	return [...]string{"2.0", "4.0", "6.0", "8.0", "9.5"}[severity]
+}
@@ -0,0 +1,356 @@
+package report
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+const threatDragonVersion = "2.2.0"
+
+// layout of the exported diagram: elements (and boxes of nested trust boundaries) are placed in rows
+const tdElementWidth, tdElementHeight, tdGap, tdPadding, tdHeader, tdPerRow = 160.0, 80.0, 60.0, 40.0, 30.0, 4
+
+type tdModel struct {
+	Version string    `json:"version"`
+	Summary tdSummary `json:"summary"`
+	Detail  tdDetail  `json:"detail"`
+}
+
+type tdSummary struct {
+	Title       string `json:"title"`
+	Owner       string `json:"owner"`
+	Description string `json:"description"`
+	Id          int    `json:"id"`
+}
+
+type tdDetail struct {
+	Contributors []tdContributor `json:"contributors"`
+	Diagrams     []tdDiagram     `json:"diagrams"`
+	DiagramTop   int             `json:"diagramTop"`
+	Reviewer     string          `json:"reviewer"`
+	ThreatTop    int             `json:"threatTop"`
+}
+
+type tdContributor struct {
+	Name string `json:"name"`
+}
+
+type tdDiagram struct {
+	Id          int      `json:"id"`
+	Title       string   `json:"title"`
+	DiagramType string   `json:"diagramType"`
+	Placeholder string   `json:"placeholder"`
+	Thumbnail   string   `json:"thumbnail"`
+	Version     string   `json:"version"`
+	Cells       []tdCell `json:"cells"`
+}
+
+type tdCell struct {
+	Id       string                 `json:"id"`
+	Shape    string                 `json:"shape"`
+	ZIndex   int                    `json:"zIndex"`
+	Visible  bool                   `json:"visible"`
+	Position *tdPoint               `json:"position,omitempty"`
+	Size     *tdSize                `json:"size,omitempty"`
+	Source   *tdEndpoint            `json:"source,omitempty"`
+	Target   *tdEndpoint            `json:"target,omitempty"`
+	Labels   []string               `json:"labels,omitempty"`
+	Attrs    map[string]interface{} `json:"attrs"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+type tdPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type tdSize struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type tdEndpoint struct {
+	Cell string `json:"cell"`
+}
+
+type tdThreat struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Severity    string `json:"severity"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Mitigation  string `json:"mitigation"`
+	ModelType   string `json:"modelType"`
+	Number      int    `json:"number"`
+	Score       string `json:"score"`
+}
+
+// WriteThreatDragonJSON writes the model as OWASP Threat Dragon (2.x) model with a single diagram: the technical
+// assets become processes, stores and actors, the communication links become flows, and the trust boundaries become
+// (nested) trust boundary boxes, while the risks become threats on their most relevant element
+func WriteThreatDragonJSON(filename string) {
+	threats := threatsByCellId()
+	cells := make([]tdCell, 0)
+	layoutThreatDragonCells(&cells, threats, "", 0, 0, 0)
+	for _, asset := range model.SortedTechnicalAssetsByTitle() {
+		for _, link := range asset.CommunicationLinks {
+			cells = append(cells, threatDragonFlow(link, threats[link.Id]))
+		}
+	}
+	threatTop := 0
+	for _, cellThreats := range threats {
+		threatTop += len(cellThreats)
+	}
+	contributors := make([]tdContributor, 0)
+	if len(model.ParsedModelRoot.Author.Name) > 0 {
+		contributors = append(contributors, tdContributor{Name: model.ParsedModelRoot.Author.Name})
+	}
+	jsonBytes, err := json.MarshalIndent(tdModel{
+		Version: threatDragonVersion,
+		Summary: tdSummary{
+			Title:       model.ParsedModelRoot.Title,
+			Owner:       model.ParsedModelRoot.Author.Name,
+			Description: removeFormattingTags(model.ParsedModelRoot.BusinessOverview.Description),
+		},
+		Detail: tdDetail{
+			Contributors: contributors,
+			Diagrams: []tdDiagram{{
+				Title:       model.ParsedModelRoot.Title,
+				DiagramType: "STRIDE",
+				Placeholder: "Data-flow diagram exported by Threagile",
+				Thumbnail:   "./public/content/images/thumbnail.stride.jpg",
+				Version:     threatDragonVersion,
+				Cells:       cells,
+			}},
+			DiagramTop: 1,
+			ThreatTop:  threatTop,
+		},
+	}, "", "  ")
+	checkErr(err)
+	err = ioutil.WriteFile(filename, jsonBytes, 0644)
+	checkErr(err)
+}
+
+// layoutThreatDragonCells places the technical assets and nested trust boundaries of the trust boundary given (or of
+// the model itself when empty) in rows starting at the position given, and returns the size they need
+func layoutThreatDragonCells(cells *[]tdCell, threats map[string][]tdThreat, trustBoundaryId string, x, y float64, depth int) (float64, float64) {
+	assetIds, boundaryIds := make([]string, 0), make([]string, 0)
+	if len(trustBoundaryId) == 0 {
+		for _, asset := range model.SortedTechnicalAssetsByTitle() {
+			if len(asset.GetTrustBoundaryId()) == 0 {
+				assetIds = append(assetIds, asset.Id)
+			}
+		}
+		for _, boundary := range model.SortedTrustBoundariesByTitle() {
+			if len(boundary.ParentTrustBoundaryID()) == 0 {
+				boundaryIds = append(boundaryIds, boundary.Id)
+			}
+		}
+	} else {
+		boundary := model.ParsedModelRoot.TrustBoundaries[trustBoundaryId]
+		assetIds = append(assetIds, boundary.TechnicalAssetsInside...)
+		boundaryIds = append(boundaryIds, boundary.TrustBoundariesNested...)
+		sort.Strings(assetIds)
+		sort.Strings(boundaryIds)
+	}
+	width, height := 0.0, 0.0
+	rowX, rowY, rowHeight, inRow := x, y, 0.0, 0
+	place := func(elementWidth, elementHeight float64) (float64, float64) {
+		if inRow == tdPerRow {
+			rowX, rowY, rowHeight, inRow = x, rowY+rowHeight+tdGap, 0, 0
+		}
+		elementX, elementY := rowX, rowY
+		rowX, inRow = rowX+elementWidth+tdGap, inRow+1
+		if elementHeight > rowHeight {
+			rowHeight = elementHeight
+		}
+		if rowX-tdGap-x > width {
+			width = rowX - tdGap - x
+		}
+		if rowY+rowHeight-y > height {
+			height = rowY + rowHeight - y
+		}
+		return elementX, elementY
+	}
+	for _, assetId := range assetIds {
+		elementX, elementY := place(tdElementWidth, tdElementHeight)
+		*cells = append(*cells, threatDragonElement(model.ParsedModelRoot.TechnicalAssets[assetId], elementX, elementY, threats[assetId]))
+	}
+	for _, boundaryId := range boundaryIds {
+		// the box is sized by its content first (laid out into a scratch list) and then placed
+		scratch := make([]tdCell, 0)
+		innerWidth, innerHeight := layoutThreatDragonCells(&scratch, threats, boundaryId, 0, 0, depth+1)
+		boxWidth, boxHeight := innerWidth+2*tdPadding, innerHeight+2*tdPadding+tdHeader
+		boxX, boxY := place(boxWidth, boxHeight)
+		*cells = append(*cells, threatDragonBoundary(model.ParsedModelRoot.TrustBoundaries[boundaryId], boxX, boxY, boxWidth, boxHeight, depth, threats[boundaryId]))
+		layoutThreatDragonCells(cells, threats, boundaryId, boxX+tdPadding, boxY+tdPadding+tdHeader, depth+1)
+	}
+	return width, height
+}
+
+func threatDragonElement(asset model.TechnicalAsset, x, y float64, threats []tdThreat) tdCell {
+	data := threatDragonData(asset.Title, asset.Description, threats)
+	data["outOfScope"], data["reasonOutOfScope"] = asset.OutOfScope, asset.JustificationOutOfScope
+	var shape string
+	switch asset.Type {
+	case model.ExternalEntity:
+		shape = "actor"
+		data["type"], data["providesAuthentication"] = "tm.Actor", asset.Technology == model.IdentityProvider
+	case model.Datastore:
+		shape = "store"
+		data["type"] = "tm.Store"
+		data["isALog"] = asset.Technology == model.Monitoring
+		data["isEncrypted"] = asset.Encryption != model.NoneEncryption
+		data["isSigned"] = false
+		data["storesCredentials"] = storesCredentials(asset)
+		data["storesInventory"] = false
+	default:
+		shape = "process"
+		data["type"] = "tm.Process"
+		data["isWebApplication"] = asset.Technology.IsWebApplication()
+		data["handlesCardPayment"], data["handlesGoodsOrServices"], data["privilegeLevel"] = false, false, ""
+	}
+	return tdCell{
+		Id:       stableUUID("technical-asset", asset.Id),
+		Shape:    shape,
+		ZIndex:   10,
+		Visible:  true,
+		Position: &tdPoint{X: x, Y: y},
+		Size:     &tdSize{Width: tdElementWidth, Height: tdElementHeight},
+		Attrs:    map[string]interface{}{"text": map[string]string{"text": asset.Title}},
+		Data:     data,
+	}
+}
+
+func threatDragonBoundary(boundary model.TrustBoundary, x, y, width, height float64, depth int, threats []tdThreat) tdCell {
+	data := threatDragonData(boundary.Title, boundary.Description, threats)
+	data["type"], data["isTrustBoundary"] = "tm.BoundaryBox", true
+	return tdCell{
+		Id:       stableUUID("trust-boundary", boundary.Id),
+		Shape:    "trust-boundary-box",
+		ZIndex:   depth - 10, // behind the elements, the outer boxes behind the inner ones
+		Visible:  true,
+		Position: &tdPoint{X: x, Y: y},
+		Size:     &tdSize{Width: width, Height: height},
+		Attrs:    map[string]interface{}{"headerText": map[string]string{"text": boundary.Title}},
+		Data:     data,
+	}
+}
+
+func threatDragonFlow(link model.CommunicationLink, threats []tdThreat) tdCell {
+	source, target := model.ParsedModelRoot.TechnicalAssets[link.SourceId], model.ParsedModelRoot.TechnicalAssets[link.TargetId]
+	data := threatDragonData(link.Title, link.Description, threats)
+	data["type"], data["protocol"] = "tm.Flow", link.Protocol.String()
+	data["isEncrypted"], data["isPublicNetwork"] = link.Protocol.IsEncrypted(), source.Internet || target.Internet
+	data["isBidirectional"], data["outOfScope"], data["reasonOutOfScope"] = false, false, ""
+	return tdCell{
+		Id:      stableUUID("communication-link", link.Id),
+		Shape:   "flow",
+		ZIndex:  20,
+		Visible: true,
+		Source:  &tdEndpoint{Cell: stableUUID("technical-asset", link.SourceId)},
+		Target:  &tdEndpoint{Cell: stableUUID("technical-asset", link.TargetId)},
+		Labels:  []string{link.Title},
+		Attrs: map[string]interface{}{"line": map[string]interface{}{
+			"stroke": "#333333", "targetMarker": map[string]string{"name": "block"}, "sourceMarker": map[string]string{"name": ""},
+		}},
+		Data: data,
+	}
+}
+
+func threatDragonData(name, description string, threats []tdThreat) map[string]interface{} {
+	if threats == nil {
+		threats = make([]tdThreat, 0)
+	}
+	hasOpenThreats := false
+	for _, threat := range threats {
+		if threat.Status == "Open" {
+			hasOpenThreats = true
+		}
+	}
+	return map[string]interface{}{
+		"name":           name,
+		"description":    removeFormattingTags(description),
+		"threats":        threats,
+		"hasOpenThreats": hasOpenThreats,
+	}
+}
+
+// threatsByCellId assigns each risk as threat to its most relevant communication link, technical asset or trust
+// boundary (risks of shared runtimes to their first technical asset running, as shared runtimes are no cells)
+func threatsByCellId() map[string][]tdThreat {
+	result := make(map[string][]tdThreat)
+	number := 0
+	for _, category := range model.SortedRiskCategories() {
+		for _, risk := range model.SortedRisksOfCategory(category) {
+			var elementId string
+			if _, exists := model.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; exists {
+				elementId = risk.MostRelevantCommunicationLinkId
+			} else if _, exists := model.ParsedModelRoot.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; exists {
+				elementId = risk.MostRelevantTechnicalAssetId
+			} else if _, exists := model.ParsedModelRoot.TrustBoundaries[risk.MostRelevantTrustBoundaryId]; exists {
+				elementId = risk.MostRelevantTrustBoundaryId
+			} else if sharedRuntime, exists := model.ParsedModelRoot.SharedRuntimes[risk.MostRelevantSharedRuntimeId]; exists && len(sharedRuntime.TechnicalAssetsRunning) > 0 {
+				assetIds := append([]string{}, sharedRuntime.TechnicalAssetsRunning...)
+				sort.Strings(assetIds)
+				elementId = assetIds[0]
+			} else {
+				continue
+			}
+			number++
+			status := "Open"
+			switch risk.GetRiskTrackingStatusDefaultingUnchecked() {
+			case model.Mitigated:
+				status = "Mitigated"
+			case model.Accepted, model.FalsePositive:
+				status = "NotApplicable"
+			}
+			description := removeFormattingTags(category.Description)
+			if justification := risk.GetRiskTracking().Justification; len(justification) > 0 {
+				description += "\n\nRisk tracking: " + justification
+			}
+			result[elementId] = append(result[elementId], tdThreat{
+				Id:          stableUUID("risk", risk.SyntheticId),
+				Title:       removeFormattingTags(risk.Title),
+				Status:      status,
+				Severity:    threatDragonSeverity(risk.Severity),
+				Type:        category.STRIDE.Title(),
+				Description: description,
+				Mitigation:  removeFormattingTags(category.Mitigation),
+				ModelType:   "STRIDE",
+				Number:      number,
+			})
+		}
+	}
+	return result
+}
+
+func threatDragonSeverity(severity model.RiskSeverity) string {
+	switch severity {
+	case model.CriticalSeverity:
+		return "Critical"
+	case model.HighSeverity, model.ElevatedSeverity:
+		return "High"
+	case model.MediumSeverity:
+		return "Medium"
+	}
+	return "Low"
+}
+
+// storesCredentials tells whether one of the data assets stored looks like credentials (by its id or tags)
+func storesCredentials(asset model.TechnicalAsset) bool {
+	for _, dataAssetId := range asset.DataAssetsStored {
+		dataAsset := model.ParsedModelRoot.DataAssets[dataAssetId]
+		for _, hint := range []string{"credential", "password", "secret"} {
+			if strings.Contains(dataAsset.Id, hint) || dataAsset.IsTaggedWithAny(hint, hint+"s") {
+				return true
+			}
+		}
+	}
+	return false
+}
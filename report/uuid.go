@@ -0,0 +1,15 @@
+package report
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+)
+
+// stableUUID derives a UUID (in the format of version 5) from the element kind and id, being stable across runs
+func stableUUID(kind, id string) string {
+	hash := sha1.Sum([]byte(kind + "/" + id))
+	hash[6] = (hash[6] & 0x0f) | 0x50
+	hash[8] = (hash[8] & 0x3f) | 0x80
+	encoded := hex.EncodeToString(hash[:16])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32]
+}
@@ -2,8 +2,13 @@ package accidental_secret_leak
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "accidental-secret-leak",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{"git", "nexus"}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V2.10", "V14.3"},
+		model.NIST80053Framework: {"IA-5(7)", "SC-28"},
+		model.ISO27001Framework:  {"A.5.17", "A.8.4"},
+		model.PCIDSSFramework:    {"8.6.2"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
@@ -0,0 +1,51 @@
+// Package built_in registers all built-in risk rules when imported (each rule package registers itself when initialized
+// along with its supported tags, compliance controls and default enablement). So adding a built-in risk rule means
+// adding its package and its import below, nothing else.
+package built_in
+
+import (
+	_ "github.com/threagile/threagile/risks/built-in/accidental-secret-leak"
+	_ "github.com/threagile/threagile/risks/built-in/code-backdooring"
+	_ "github.com/threagile/threagile/risks/built-in/container-baseimage-backdooring"
+	_ "github.com/threagile/threagile/risks/built-in/container-platform-escape"
+	_ "github.com/threagile/threagile/risks/built-in/cross-site-request-forgery"
+	_ "github.com/threagile/threagile/risks/built-in/cross-site-scripting"
+	_ "github.com/threagile/threagile/risks/built-in/dos-risky-access-across-trust-boundary"
+	_ "github.com/threagile/threagile/risks/built-in/incomplete-model"
+	_ "github.com/threagile/threagile/risks/built-in/known-vulnerable-component"
+	_ "github.com/threagile/threagile/risks/built-in/ldap-injection"
+	_ "github.com/threagile/threagile/risks/built-in/missing-authentication"
+	_ "github.com/threagile/threagile/risks/built-in/missing-authentication-second-factor"
+	_ "github.com/threagile/threagile/risks/built-in/missing-build-infrastructure"
+	_ "github.com/threagile/threagile/risks/built-in/missing-cloud-hardening"
+	_ "github.com/threagile/threagile/risks/built-in/missing-file-validation"
+	_ "github.com/threagile/threagile/risks/built-in/missing-hardening"
+	_ "github.com/threagile/threagile/risks/built-in/missing-identity-propagation"
+	_ "github.com/threagile/threagile/risks/built-in/missing-identity-provider-isolation"
+	_ "github.com/threagile/threagile/risks/built-in/missing-identity-store"
+	_ "github.com/threagile/threagile/risks/built-in/missing-network-segmentation"
+	_ "github.com/threagile/threagile/risks/built-in/missing-vault"
+	_ "github.com/threagile/threagile/risks/built-in/missing-vault-isolation"
+	_ "github.com/threagile/threagile/risks/built-in/missing-waf"
+	_ "github.com/threagile/threagile/risks/built-in/mixed-targets-on-shared-runtime"
+	_ "github.com/threagile/threagile/risks/built-in/path-traversal"
+	_ "github.com/threagile/threagile/risks/built-in/push-instead-of-pull-deployment"
+	_ "github.com/threagile/threagile/risks/built-in/search-query-injection"
+	_ "github.com/threagile/threagile/risks/built-in/server-side-request-forgery"
+	_ "github.com/threagile/threagile/risks/built-in/service-registry-poisoning"
+	_ "github.com/threagile/threagile/risks/built-in/sql-nosql-injection"
+	_ "github.com/threagile/threagile/risks/built-in/unchecked-deployment"
+	_ "github.com/threagile/threagile/risks/built-in/undeclared-communication"
+	_ "github.com/threagile/threagile/risks/built-in/unencrypted-asset"
+	_ "github.com/threagile/threagile/risks/built-in/unencrypted-communication"
+	_ "github.com/threagile/threagile/risks/built-in/unguarded-access-from-internet"
+	_ "github.com/threagile/threagile/risks/built-in/unguarded-direct-datastore-access"
+	_ "github.com/threagile/threagile/risks/built-in/unnecessary-communication-link"
+	_ "github.com/threagile/threagile/risks/built-in/unnecessary-data-asset"
+	_ "github.com/threagile/threagile/risks/built-in/unnecessary-data-transfer"
+	_ "github.com/threagile/threagile/risks/built-in/unnecessary-technical-asset"
+	_ "github.com/threagile/threagile/risks/built-in/untrusted-deserialization"
+	_ "github.com/threagile/threagile/risks/built-in/wrong-communication-link-content"
+	_ "github.com/threagile/threagile/risks/built-in/wrong-trust-boundary-content"
+	_ "github.com/threagile/threagile/risks/built-in/xml-external-entity"
+)
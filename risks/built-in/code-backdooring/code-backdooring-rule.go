@@ -2,8 +2,13 @@ package code_backdooring
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "code-backdooring",
@@ -40,6 +45,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V10.3", "V14.1"},
+		model.NIST80053Framework: {"CM-5", "SA-10", "SI-7"},
+		model.ISO27001Framework:  {"A.8.4", "A.8.25", "A.8.32"},
+		model.PCIDSSFramework:    {"6.2.1", "6.5.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
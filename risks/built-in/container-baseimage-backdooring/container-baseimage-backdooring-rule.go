@@ -2,8 +2,13 @@ package container_baseimage_backdooring
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "container-baseimage-backdooring",
@@ -35,6 +40,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V10.3", "V14.2"},
+		model.NIST80053Framework: {"CM-2", "SI-7", "SR-3"},
+		model.ISO27001Framework:  {"A.5.21", "A.8.19"},
+		model.PCIDSSFramework:    {"6.3.2", "6.3.3"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
@@ -2,8 +2,13 @@ package container_platform_escape
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "container-platform-escape",
@@ -40,6 +45,15 @@ func SupportedTags() []string {
 	return []string{"docker", "kubernetes", "openshift"}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.14", "V14.1"},
+		model.NIST80053Framework: {"AC-6", "CM-7", "SC-39"},
+		model.ISO27001Framework:  {"A.8.9", "A.8.22"},
+		model.PCIDSSFramework:    {"2.2.1", "2.2.3"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
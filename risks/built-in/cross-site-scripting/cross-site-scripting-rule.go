@@ -2,8 +2,13 @@ package cross_site_scripting
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "cross-site-scripting",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V5.3"},
+		model.NIST80053Framework: {"SI-10", "SI-15"},
+		model.ISO27001Framework:  {"A.8.28"},
+		model.PCIDSSFramework:    {"6.2.4"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
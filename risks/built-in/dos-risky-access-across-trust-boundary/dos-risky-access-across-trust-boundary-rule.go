@@ -2,8 +2,13 @@ package dos_risky_access_across_trust_boundary
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "dos-risky-access-across-trust-boundary",
@@ -38,6 +43,14 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V11.1"},
+		model.NIST80053Framework: {"SC-5", "SC-7"},
+		model.ISO27001Framework:  {"A.8.6", "A.8.20"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
@@ -0,0 +1,128 @@
+package known_vulnerable_component
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
+)
+
+const raaLimit = 40
+
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
+func Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:    "known-vulnerable-component",
+		Title: "Known Vulnerable Component",
+		Description: "Ativos técnicos contendo componentes com vulnerabilidades publicamente conhecidas (conforme relatado pelos arquivos de SBOM ou de " +
+			"varredura de vulnerabilidades referenciados via <i>vulnerability_scans</i> do ativo técnico) correm o risco de serem atacados com exploits publicados.",
+		Impact:     "Se esse risco não for mitigado, os invasores podem ser capazes de explorar as vulnerabilidades conhecidas do componente para comprometer o ativo técnico.",
+		ASVS:       "V14 - Configuration Verification Requirements",
+		CheatSheet: "https://cheatsheetseries.owasp.org/cheatsheets/Vulnerable_Dependency_Management_Cheat_Sheet.html",
+		Action:     "Atualização de componentes vulneráveis",
+		Mitigation: "Atualize o componente vulnerável para uma versão que corrija as vulnerabilidades (ou reconstrua a imagem do contêiner sobre uma imagem base atualizada). " +
+			"Quando nenhuma versão corrigida estiver disponível, aplique as soluções alternativas recomendadas pelo fornecedor ou substitua o componente. " +
+			"Faça a varredura dos ativos técnicos regularmente (de preferência no pipeline de build) e mantenha atualizados os arquivos de varredura referenciados.",
+		Check:          "As recomendações do cheat sheet e do ASVS/CSVS referenciado são aplicadas?",
+		Function:       model.Operations,
+		STRIDE:         model.ElevationOfPrivilege,
+		DetectionLogic: "Ativos técnicos no escopo com componentes vulneráveis relatados pelos arquivos JSON de CycloneDX, Trivy ou Grype referenciados via <i>vulnerability_scans</i>.",
+		RiskAssessment: "A classificação de risco depende da maior pontuação CVSS das vulnerabilidades do componente (ou então da severidade relatada pelo scanner): " +
+			"pontuações a partir de 9.0 têm impacto muito alto, a partir de 7.0 impacto alto e a partir de 4.0 impacto médio. " +
+			"A probabilidade é aumentada quando o ativo técnico é diretamente acessível pela internet e mais uma vez quando tem um valor RAA de " +
+			strconv.Itoa(raaLimit) + " % ou mais.",
+		FalsePositives: "Vulnerabilidades em caminhos de código não usados pelo ativo técnico ou não alcançáveis por invasores podem ser consideradas " +
+			"como falsos positivos após revisão individual.",
+		ModelFailurePossibleReason: false,
+		CWE:                        1395,
+	}
+}
+
+func SupportedTags() []string {
+	return []string{}
+}
+
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V14.2"},
+		model.NIST80053Framework: {"RA-5", "SI-2", "SR-3"},
+		model.ISO27001Framework:  {"A.8.8", "A.8.19"},
+		model.PCIDSSFramework:    {"6.3.1", "6.3.3", "11.3.1"},
+	}
+}
+
+func GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if technicalAsset.OutOfScope {
+			continue
+		}
+		for _, vulnerabilities := range technicalAsset.SortedKnownVulnerabilitiesByComponent() {
+			risks = append(risks, createRisk(technicalAsset, vulnerabilities))
+		}
+	}
+	return risks
+}
+
+// the vulnerabilities are all of the same component (by id) and sorted descending by score
+func createRisk(technicalAsset model.TechnicalAsset, vulnerabilities []model.KnownVulnerability) model.Risk {
+	component := vulnerabilities[0].Component
+	versions := make([]string, 0)
+	ids := make([]string, 0, len(vulnerabilities))
+	for i, vulnerability := range vulnerabilities {
+		if len(vulnerability.Version) > 0 && !model.Contains(versions, vulnerability.Version) {
+			versions = append(versions, vulnerability.Version)
+		}
+		if i < 3 {
+			ids = append(ids, vulnerability.Id)
+		} else if i == 3 {
+			ids = append(ids, "e mais "+strconv.Itoa(len(vulnerabilities)-i))
+		}
+	}
+	if len(versions) > 0 {
+		component += " " + strings.Join(versions, ", ")
+	}
+	score := vulnerabilities[0].Score()
+	rating := "severidade desconhecida"
+	if vulnerabilities[0].CVSS > 0 {
+		rating = fmt.Sprintf("maior pontuação CVSS %.1f", vulnerabilities[0].CVSS)
+	} else if len(vulnerabilities[0].ReportedSeverity) > 0 { // the score is only approximated from the severity then
+		rating = "maior severidade " + strings.ToLower(vulnerabilities[0].ReportedSeverity)
+	}
+	title := "<b>Componente vulnerável conhecido</b> em <b>" + technicalAsset.Title + "</b>: <b>" + component + "</b> " +
+		"(" + strings.Join(ids, ", ") + " com " + rating + ")"
+	impact := model.LowImpact
+	if score >= 9.0 {
+		impact = model.VeryHighImpact
+	} else if score >= 7.0 {
+		impact = model.HighImpact
+	} else if score >= 4.0 {
+		impact = model.MediumImpact
+	}
+	likelihood := model.Unlikely
+	if technicalAsset.Internet {
+		likelihood++
+	}
+	if technicalAsset.RAA >= raaLimit {
+		likelihood++
+	}
+	risk := model.Risk{
+		Category:                     Category(),
+		Severity:                     model.CalculateSeverity(likelihood, impact),
+		ExploitationLikelihood:       likelihood,
+		ExploitationImpact:           impact,
+		Title:                        title,
+		MostRelevantTechnicalAssetId: technicalAsset.Id,
+		DataBreachProbability:        model.Probable,
+		DataBreachTechnicalAssetIDs:  []string{technicalAsset.Id},
+	}
+	// the component version is not part of the id, so that the risk tracking survives updates of still vulnerable components
+	risk.SyntheticId = risk.Category.Id + "@" + technicalAsset.Id + "@" + vulnerabilities[0].ComponentId()
+	return risk
+}
@@ -2,9 +2,14 @@ package missing_authentication_second_factor
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 	missing_authentication "github.com/threagile/threagile/risks/built-in/missing-authentication"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-authentication-second-factor",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V2.8"},
+		model.NIST80053Framework: {"IA-2(1)", "IA-2(2)"},
+		model.ISO27001Framework:  {"A.8.5"},
+		model.PCIDSSFramework:    {"8.4.2", "8.4.3"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
@@ -2,8 +2,13 @@ package missing_authentication
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:          "missing-authentication",
@@ -33,6 +38,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.2", "V2.10"},
+		model.NIST80053Framework: {"AC-3", "IA-2", "IA-9"},
+		model.ISO27001Framework:  {"A.5.15", "A.8.5"},
+		model.PCIDSSFramework:    {"8.3.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
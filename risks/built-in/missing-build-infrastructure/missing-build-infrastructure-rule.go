@@ -2,8 +2,13 @@ package missing_build_infrastructure
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-build-infrastructure",
@@ -35,6 +40,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V14.1"},
+		model.NIST80053Framework: {"CM-2", "CM-3", "SA-10"},
+		model.ISO27001Framework:  {"A.8.25", "A.8.32"},
+		model.PCIDSSFramework:    {"6.2.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	hasCustomDevelopedParts, hasBuildPipeline, hasSourcecodeRepo, hasDevOpsClient := false, false, false, false
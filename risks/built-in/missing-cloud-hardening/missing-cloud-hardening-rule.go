@@ -4,8 +4,13 @@ import (
 	"sort"
 
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-cloud-hardening",
@@ -50,6 +55,15 @@ func SupportedTags() []string {
 	return res
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V14.1"},
+		model.NIST80053Framework: {"CM-6", "CM-7"},
+		model.ISO27001Framework:  {"A.5.23", "A.8.9"},
+		model.PCIDSSFramework:    {"2.2.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 
@@ -2,8 +2,13 @@ package missing_file_validation
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:          "missing-file-validation",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V12.1", "V12.2"},
+		model.NIST80053Framework: {"SI-3", "SI-10"},
+		model.ISO27001Framework:  {"A.8.7", "A.8.28"},
+		model.PCIDSSFramework:    {"6.2.4"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
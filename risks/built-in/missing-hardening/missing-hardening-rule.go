@@ -4,8 +4,13 @@ import (
 	"strconv"
 
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 const raaLimit = 55
 const raaLimitReduced = 40
 
@@ -37,6 +42,15 @@ func SupportedTags() []string {
 	return []string{"tomcat"}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V14.1"},
+		model.NIST80053Framework: {"CM-6", "CM-7"},
+		model.ISO27001Framework:  {"A.8.9"},
+		model.PCIDSSFramework:    {"2.2.1", "2.2.4"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
@@ -2,8 +2,13 @@ package missing_identity_propagation
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-identity-propagation",
@@ -39,6 +44,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.4", "V4.1"},
+		model.NIST80053Framework: {"AC-3", "AC-6", "IA-9"},
+		model.ISO27001Framework:  {"A.5.15", "A.5.18"},
+		model.PCIDSSFramework:    {"7.2.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, id := range model.SortedTechnicalAssetIDs() {
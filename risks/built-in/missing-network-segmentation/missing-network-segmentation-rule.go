@@ -4,8 +4,13 @@ import (
 	"sort"
 
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 const raaLimit = 50
 
 func Category() model.RiskCategory {
@@ -42,6 +47,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.14"},
+		model.NIST80053Framework: {"AC-4", "SC-7"},
+		model.ISO27001Framework:  {"A.8.22"},
+		model.PCIDSSFramework:    {"1.3.1", "1.4.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	// first create them in memory (see the link replacement below for nested trust boundaries) - otherwise in Go ranging over map is random order
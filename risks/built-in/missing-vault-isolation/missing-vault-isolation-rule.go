@@ -2,8 +2,13 @@ package missing_vault_isolation
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-vault-isolation",
@@ -35,6 +40,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.14", "V6.4"},
+		model.NIST80053Framework: {"SC-3", "SC-7", "SC-12"},
+		model.ISO27001Framework:  {"A.8.22", "A.8.24"},
+		model.PCIDSSFramework:    {"3.6.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	for _, technicalAsset := range model.ParsedModelRoot.TechnicalAssets {
@@ -2,8 +2,13 @@ package missing_vault
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "missing-vault",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V6.4"},
+		model.NIST80053Framework: {"IA-5", "SC-12", "SC-28"},
+		model.ISO27001Framework:  {"A.5.17", "A.8.24"},
+		model.PCIDSSFramework:    {"3.6.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	hasVault := false
@@ -4,8 +4,13 @@ import (
 	"sort"
 
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "mixed-targets-on-shared-runtime",
@@ -38,6 +43,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.14"},
+		model.NIST80053Framework: {"CM-7", "SC-39"},
+		model.ISO27001Framework:  {"A.8.22"},
+		model.PCIDSSFramework:    {"2.2.3"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	// as in Go ranging over map is random order, range over them in sorted (hence reproducible) way:
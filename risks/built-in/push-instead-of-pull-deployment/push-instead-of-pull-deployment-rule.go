@@ -2,8 +2,13 @@ package push_instead_of_pull_deployment
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "push-instead-of-pull-deployment",
@@ -35,6 +40,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V14.1"},
+		model.NIST80053Framework: {"AC-6", "CM-5"},
+		model.ISO27001Framework:  {"A.8.2", "A.8.32"},
+		model.PCIDSSFramework:    {"6.5.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	impact := model.LowImpact
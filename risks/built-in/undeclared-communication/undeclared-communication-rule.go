@@ -0,0 +1,111 @@
+package undeclared_communication
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
+)
+
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
+func Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:    "undeclared-communication",
+		Title: "Undeclared Communication",
+		Description: "Quando o flow log fornecido via <i>-verify-flows</i> mostra comunicação entre ativos técnicos (mapeados via <i>hosts</i>) " +
+			"sem link de comunicação correspondente no modelo, isso é um indicador para um caminho de rede não intencional (ou para um modelo incompleto).",
+		Impact: "Se esse risco não for mitigado, os invasores podem ser capazes de usar caminhos de rede não previstos pelo modelo " +
+			"(e, portanto, não cobertos pela análise de risco) para alcançar o ativo técnico de destino.",
+		ASVS:       "V1 - Architecture, Design and Threat Modeling Requirements",
+		CheatSheet: "https://cheatsheetseries.owasp.org/cheatsheets/Attack_Surface_Analysis_Cheat_Sheet.html",
+		Action:     "Segmentação de rede",
+		Mitigation: "Restrinja a comunicação de rede (por exemplo, via security groups ou network policies) aos links de comunicação " +
+			"declarados ou, quando a comunicação for intencional, modele o link de comunicação correspondente.",
+		Check:          "As recomendações do cheat sheet e do ASVS/CSVS referenciado são aplicadas?",
+		Function:       model.Operations,
+		STRIDE:         model.ElevationOfPrivilege,
+		DetectionLogic: "Fluxos observados entre ativos técnicos no escopo sem link de comunicação declarado entre eles (em qualquer direção, já que a distinção entre requisições e respostas pelas portas do flow log é apenas heurística).",
+		RiskAssessment: "O impacto é médio, ou alto quando o ativo técnico de destino processa ou armazena dados confidenciais ou críticos para a integridade. " +
+			"A probabilidade é aumentada quando a comunicação atravessa um limite de confiança.",
+		FalsePositives: "Comunicação de infraestrutura (como DNS, NTP ou monitoramento) não modelada intencionalmente pode ser considerada " +
+			"como falso positivo após revisão individual.",
+		ModelFailurePossibleReason: true,
+		CWE:                        923,
+	}
+}
+
+func SupportedTags() []string {
+	return []string{}
+}
+
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.1", "V14.1"},
+		model.NIST80053Framework: {"AC-4", "CA-9", "SC-7"},
+		model.ISO27001Framework:  {"A.8.20", "A.8.22"},
+		model.PCIDSSFramework:    {"1.2.6", "1.3.1"},
+	}
+}
+
+func GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	if !model.HasObservedFlows() {
+		return risks
+	}
+	portsByAssetPair := make(map[[2]string][]string)
+	for _, flow := range model.VerifyFlows().UndeclaredFlows {
+		pair := [2]string{flow.SourceTechnicalAssetId, flow.TargetTechnicalAssetId}
+		if port := flow.PortTitle(); !model.Contains(portsByAssetPair[pair], port) {
+			portsByAssetPair[pair] = append(portsByAssetPair[pair], port)
+		}
+	}
+	pairs := make([][2]string, 0, len(portsByAssetPair))
+	for pair := range portsByAssetPair {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0] || (pairs[i][0] == pairs[j][0] && pairs[i][1] < pairs[j][1])
+	})
+	for _, pair := range pairs {
+		sourceAsset := model.ParsedModelRoot.TechnicalAssets[pair[0]]
+		targetAsset := model.ParsedModelRoot.TechnicalAssets[pair[1]]
+		if !sourceAsset.OutOfScope || !targetAsset.OutOfScope {
+			risks = append(risks, createRisk(sourceAsset, targetAsset, portsByAssetPair[pair]))
+		}
+	}
+	return risks
+}
+
+func createRisk(sourceAsset, targetAsset model.TechnicalAsset, ports []string) model.Risk {
+	portLabel := "porta"
+	if len(ports) > 1 {
+		portLabel = "portas"
+	}
+	title := "<b>Comunicação não declarada</b> observada de <b>" + sourceAsset.Title + "</b> para <b>" + targetAsset.Title +
+		"</b> (" + portLabel + " " + strings.Join(ports, ", ") + ")"
+	impact := model.MediumImpact
+	if targetAsset.HighestConfidentiality() >= model.Confidential || targetAsset.HighestIntegrity() >= model.Critical {
+		impact = model.HighImpact
+	}
+	likelihood := model.Likely
+	if sourceAsset.GetTrustBoundaryId() != targetAsset.GetTrustBoundaryId() {
+		likelihood = model.VeryLikely
+	}
+	risk := model.Risk{
+		Category:                     Category(),
+		Severity:                     model.CalculateSeverity(likelihood, impact),
+		ExploitationLikelihood:       likelihood,
+		ExploitationImpact:           impact,
+		Title:                        title,
+		MostRelevantTechnicalAssetId: targetAsset.Id,
+		DataBreachProbability:        model.Possible,
+		DataBreachTechnicalAssetIDs:  []string{targetAsset.Id},
+	}
+	// the ports are not part of the id, so that the risk tracking survives changes of the observed ports
+	risk.SyntheticId = risk.Category.Id + "@" + sourceAsset.Id + "@" + targetAsset.Id
+	return risk
+}
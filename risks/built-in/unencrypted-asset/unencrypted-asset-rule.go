@@ -2,8 +2,13 @@ package unencrypted_asset
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "unencrypted-asset",
@@ -35,6 +40,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V6.1"},
+		model.NIST80053Framework: {"SC-28"},
+		model.ISO27001Framework:  {"A.8.24"},
+		model.PCIDSSFramework:    {"3.5.1"},
+	}
+}
+
 // check for technical assets that should be encrypted due to their confidentiality
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
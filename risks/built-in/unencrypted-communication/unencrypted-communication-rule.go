@@ -2,8 +2,13 @@ package unencrypted_communication
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "unencrypted-communication",
@@ -32,6 +37,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V9.1", "V9.2"},
+		model.NIST80053Framework: {"SC-8"},
+		model.ISO27001Framework:  {"A.5.14", "A.8.24"},
+		model.PCIDSSFramework:    {"4.2.1"},
+	}
+}
+
 // check for communication links that should be encrypted due to their confidentiality and/or integrity
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
@@ -2,8 +2,13 @@ package unguarded_direct_datastore_access
 
 import (
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:          "unguarded-direct-datastore-access",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.14"},
+		model.NIST80053Framework: {"AC-3", "SC-7"},
+		model.ISO27001Framework:  {"A.8.3", "A.8.22"},
+		model.PCIDSSFramework:    {"1.4.4"},
+	}
+}
+
 // check for datastores that should not be accessed directly across trust boundaries
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
@@ -4,8 +4,13 @@ import (
 	"sort"
 
 	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks"
 )
 
+func init() {
+	risks.RegisterBuiltInRiskRule(Category, SupportedTags, ComplianceControls, GenerateRisks, risks.EnabledByDefault)
+}
+
 func Category() model.RiskCategory {
 	return model.RiskCategory{
 		Id:    "unnecessary-data-asset",
@@ -34,6 +39,15 @@ func SupportedTags() []string {
 	return []string{}
 }
 
+func ComplianceControls() map[string][]string {
+	return map[string][]string{
+		model.ASVSFramework:      {"V1.1"},
+		model.NIST80053Framework: {"SI-12"},
+		model.ISO27001Framework:  {"A.8.10"},
+		model.PCIDSSFramework:    {"3.2.1"},
+	}
+}
+
 func GenerateRisks() []model.Risk {
 	risks := make([]model.Risk, 0)
 	// first create them in memory - otherwise in Go ranging over map is random order
@@ -0,0 +1,12 @@
+package risks
+
+import "github.com/threagile/threagile/model"
+
+// ComplianceControls returns the controls (by framework) the risk category of the rule relates to, which built-in risk
+// rules provide when registering and custom risk rules by implementing model.ComplianceMappedRiskRule
+func (registeredRule RegisteredRiskRule) ComplianceControls() map[string][]string {
+	if complianceMappedRule, ok := registeredRule.Rule.(model.ComplianceMappedRiskRule); ok {
+		return model.MergeComplianceControls(complianceMappedRule.ComplianceControls())
+	}
+	return make(map[string][]string)
+}
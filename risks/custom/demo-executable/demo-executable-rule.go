@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/threagile/threagile/model"
+	"github.com/threagile/threagile/risks/executable-rules"
+)
+
+// the same rule as the plugin demo, but built as executable (go build) and loaded via -custom-risk-rules-executables
+type customRiskRule string
+
+func main() {
+	executable_rules.Serve(customRiskRule("executable-demo"))
+}
+
+func (r customRiskRule) Category() model.RiskCategory {
+	return model.RiskCategory{
+		Id:                         string(r),
+		Title:                      "Just an Executable Demo",
+		Description:                "Demo Description",
+		Impact:                     "Demo Impact",
+		ASVS:                       "Demo ASVS",
+		CheatSheet:                 "https://example.com",
+		Action:                     "Demo Action",
+		Mitigation:                 "Demo Mitigation",
+		Check:                      "Demo Check",
+		Function:                   model.Development,
+		STRIDE:                     model.Tampering,
+		DetectionLogic:             "Demo Detection",
+		RiskAssessment:             "Demo Risk Assessment",
+		FalsePositives:             "Demo False Positive.",
+		ModelFailurePossibleReason: false,
+		CWE:                        0,
+	}
+}
+
+func (r customRiskRule) SupportedTags() []string {
+	return []string{"demo tag"}
+}
+
+func (r customRiskRule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		techAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if techAsset.IsTaggedWithAny("demo tag") || len(model.IncomingTechnicalCommunicationLinksMappedByTargetId[id]) == 0 {
+			risks = append(risks, r.createRisk(techAsset))
+		}
+	}
+	return risks
+}
+
+func (r customRiskRule) createRisk(technicalAsset model.TechnicalAsset) model.Risk {
+	risk := model.Risk{
+		Category:                     r.Category(),
+		Severity:                     model.CalculateSeverity(model.VeryLikely, model.MediumImpact),
+		ExploitationLikelihood:       model.VeryLikely,
+		ExploitationImpact:           model.MediumImpact,
+		Title:                        "<b>Executable Demo</b> risk at <b>" + technicalAsset.Title + "</b>",
+		MostRelevantTechnicalAssetId: technicalAsset.Id,
+		DataBreachProbability:        model.Possible,
+		DataBreachTechnicalAssetIDs:  []string{technicalAsset.Id},
+	}
+	risk.SyntheticId = risk.Category.Id + "@" + technicalAsset.Id
+	return risk
+}
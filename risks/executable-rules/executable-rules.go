@@ -0,0 +1,183 @@
+// Package executable_rules provides custom risk rules implemented as executables (written in any language), which are
+// run as separate processes instead of being loaded as Go plugins: Each call writes a PluginRequest as JSON to the
+// standard input of the executable and reads a PluginResponse as JSON from its standard output. A non-zero exit code
+// marks a failure (with the standard error output as reason). Rules implementing model.CustomRiskRule can be turned
+// into such executables by calling Serve from their main function.
+package executable_rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/threagile/threagile/model"
+)
+
+type riskRule struct {
+	executable         string
+	timeout            time.Duration
+	category           model.RiskCategory
+	supportedTags      []string
+	complianceControls map[string][]string
+}
+
+// LoadRiskRules asks each executable for the category and supported tags of its risk rule (keyed by the category id).
+// Each call to an executable (also later when generating risks) is aborted when not finished within the timeout.
+func LoadRiskRules(executables []string, timeout time.Duration, verbose bool) (riskRules map[string]model.CustomRiskRule, err error) {
+	defer recoverError(&err)
+	riskRules = make(map[string]model.CustomRiskRule)
+	for _, executable := range executables {
+		if len(executable) == 0 {
+			continue
+		}
+		if verbose {
+			fmt.Println("Loading custom risk rule executable:", executable)
+		}
+		response := callExecutable(executable, timeout, PluginRequest{Protocol_version: ProtocolVersion, Command: DescribeCommand})
+		rule := riskRule{
+			executable:         executable,
+			timeout:            timeout,
+			category:           riskCategoryOf(response.Category),
+			supportedTags:      response.Supported_tags,
+			complianceControls: model.MergeComplianceControls(response.Category.Compliance),
+		}
+		if rule.supportedTags == nil {
+			rule.supportedTags = make([]string, 0)
+		}
+		if _, exists := riskRules[rule.category.Id]; exists {
+			panic(errors.New("duplicate custom risk rule id: " + rule.category.Id))
+		}
+		riskRules[rule.category.Id] = rule
+		if verbose {
+			fmt.Println("Custom risk rule loaded:", rule.category.Id)
+		}
+	}
+	return riskRules, nil
+}
+
+func (rule riskRule) Category() model.RiskCategory {
+	return rule.category
+}
+
+func (rule riskRule) SupportedTags() []string {
+	return rule.supportedTags
+}
+
+func (rule riskRule) ComplianceControls() map[string][]string {
+	return rule.complianceControls
+}
+
+func (rule riskRule) GenerateRisks() []model.Risk {
+	response := callExecutable(rule.executable, rule.timeout, PluginRequest{
+		Protocol_version: ProtocolVersion,
+		Command:          GenerateRisksCommand,
+		Model:            pluginModelOf(model.ParsedModelRoot),
+	})
+	if response.Category.ID != rule.category.Id {
+		panic(errors.New("custom risk rule executable " + rule.executable + " responded with category '" + response.Category.ID +
+			"' instead of '" + rule.category.Id + "'"))
+	}
+	risks := make([]model.Risk, 0)
+	for _, risk := range response.Risks {
+		risks = append(risks, riskOf(risk, rule.category))
+	}
+	return risks
+}
+
+func callExecutable(executable string, timeout time.Duration, request PluginRequest) PluginResponse {
+	requestBytes, err := json.Marshal(request)
+	checkErr(err)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, executable)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(requestBytes)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		panic(errors.New("custom risk rule executable " + executable + " did not finish '" + request.Command + "' within " + timeout.String()))
+	}
+	if err != nil {
+		message := "custom risk rule executable " + executable + " failed on '" + request.Command + "': " + err.Error()
+		if reason := strings.TrimSpace(stderr.String()); len(reason) > 0 {
+			message += ": " + reason
+		}
+		panic(errors.New(message))
+	}
+	var response PluginResponse
+	err = json.Unmarshal(stdout.Bytes(), &response)
+	if err != nil {
+		panic(errors.New("invalid response of custom risk rule executable " + executable + " on '" + request.Command + "': " + err.Error()))
+	}
+	return response
+}
+
+// Serve answers the request read from the standard input on behalf of the rule and exits on failure. The model
+// received is made available via the package-level model variables (like model.ParsedModelRoot), so rules written
+// against model.CustomRiskRule for Go plugins work unchanged.
+func Serve(rule model.CustomRiskRule) {
+	err := serve(rule, os.Stdin, os.Stdout)
+	if err != nil {
+		os.Stderr.WriteString(err.Error() + "\n")
+		os.Exit(1)
+	}
+}
+
+func serve(rule model.CustomRiskRule, in io.Reader, out io.Writer) (err error) {
+	defer recoverError(&err)
+	var request PluginRequest
+	err = json.NewDecoder(in).Decode(&request)
+	if err != nil {
+		return errors.New("invalid request: " + err.Error())
+	}
+	if request.Protocol_version != ProtocolVersion {
+		return errors.New("unsupported protocol version: " + strconv.Itoa(request.Protocol_version))
+	}
+	response := PluginResponse{
+		Category:       pluginRiskCategoryOf(rule.Category()),
+		Supported_tags: rule.SupportedTags(),
+		Risks:          make([]PluginRisk, 0),
+	}
+	if complianceMappedRule, ok := rule.(model.ComplianceMappedRiskRule); ok {
+		response.Category.Compliance = complianceMappedRule.ComplianceControls()
+	}
+	switch request.Command {
+	case DescribeCommand:
+	case GenerateRisksCommand:
+		if request.Model == nil {
+			return errors.New("missing model to generate risks for")
+		}
+		activateModel(*request.Model)
+		for _, risk := range rule.GenerateRisks() {
+			response.Risks = append(response.Risks, pluginRiskOf(risk))
+		}
+	default:
+		return errors.New("unknown command: " + request.Command)
+	}
+	return json.NewEncoder(out).Encode(response)
+}
+
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		if recoveredError, ok := r.(error); ok {
+			*err = recoveredError
+		} else {
+			*err = errors.New(fmt.Sprintf("%v", r))
+		}
+	}
+}
+
+func checkErr(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
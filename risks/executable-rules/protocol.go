@@ -0,0 +1,509 @@
+package executable_rules
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// ProtocolVersion is sent with each request, so that plugins can reject requests they do not understand
+const ProtocolVersion = 1
+
+// the commands sent to plugins
+const (
+	DescribeCommand      = "describe"       // respond with the category and the supported tags only
+	GenerateRisksCommand = "generate-risks" // respond with the category, the supported tags and the risks found in the model
+)
+
+// PluginRequest is written as JSON to the standard input of the plugin
+type PluginRequest struct {
+	Protocol_version int          `json:"protocol_version"`
+	Command          string       `json:"command"`
+	Model            *PluginModel `json:"model,omitempty"` // only for command generate-risks
+}
+
+// PluginResponse is expected as JSON on the standard output of the plugin
+type PluginResponse struct {
+	Category       PluginRiskCategory `json:"category"`
+	Supported_tags []string           `json:"supported_tags"`
+	Risks          []PluginRisk       `json:"risks"`
+}
+
+// PluginModel is the parsed model sent to plugins, all enum values are given by their names (as in the model YAML)
+type PluginModel struct {
+	Title                string                          `json:"title"`
+	Business_criticality string                          `json:"business_criticality"`
+	Tags_available       []string                        `json:"tags_available"`
+	Data_assets          map[string]PluginDataAsset      `json:"data_assets"`
+	Technical_assets     map[string]PluginTechnicalAsset `json:"technical_assets"`
+	Trust_boundaries     map[string]PluginTrustBoundary  `json:"trust_boundaries"`
+	Shared_runtimes      map[string]PluginSharedRuntime  `json:"shared_runtimes"`
+}
+
+type PluginDataAsset struct {
+	ID                       string   `json:"id"`
+	Title                    string   `json:"title"`
+	Description              string   `json:"description"`
+	Usage                    string   `json:"usage"`
+	Tags                     []string `json:"tags"`
+	Origin                   string   `json:"origin"`
+	Owner                    string   `json:"owner"`
+	Quantity                 string   `json:"quantity"`
+	Confidentiality          string   `json:"confidentiality"`
+	Integrity                string   `json:"integrity"`
+	Availability             string   `json:"availability"`
+	Justification_cia_rating string   `json:"justification_cia_rating"`
+}
+
+type PluginTechnicalAsset struct {
+	ID                         string                    `json:"id"`
+	Title                      string                    `json:"title"`
+	Description                string                    `json:"description"`
+	Usage                      string                    `json:"usage"`
+	Type                       string                    `json:"type"`
+	Size                       string                    `json:"size"`
+	Technology                 string                    `json:"technology"`
+	Machine                    string                    `json:"machine"`
+	Internet                   bool                      `json:"internet"`
+	Multi_tenant               bool                      `json:"multi_tenant"`
+	Redundant                  bool                      `json:"redundant"`
+	Custom_developed_parts     bool                      `json:"custom_developed_parts"`
+	Out_of_scope               bool                      `json:"out_of_scope"`
+	Used_as_client_by_human    bool                      `json:"used_as_client_by_human"`
+	Encryption                 string                    `json:"encryption"`
+	Justification_out_of_scope string                    `json:"justification_out_of_scope"`
+	Owner                      string                    `json:"owner"`
+	Confidentiality            string                    `json:"confidentiality"`
+	Integrity                  string                    `json:"integrity"`
+	Availability               string                    `json:"availability"`
+	Justification_cia_rating   string                    `json:"justification_cia_rating"`
+	Tags                       []string                  `json:"tags"`
+	Data_assets_processed      []string                  `json:"data_assets_processed"`
+	Data_assets_stored         []string                  `json:"data_assets_stored"`
+	Data_formats_accepted      []string                  `json:"data_formats_accepted"`
+	Communication_links        []PluginCommunicationLink `json:"communication_links"`
+	RAA                        float64                   `json:"raa"`
+}
+
+type PluginCommunicationLink struct {
+	ID                   string   `json:"id"`
+	Source_id            string   `json:"source_id"`
+	Target_id            string   `json:"target_id"`
+	Title                string   `json:"title"`
+	Description          string   `json:"description"`
+	Protocol             string   `json:"protocol"`
+	Tags                 []string `json:"tags"`
+	VPN                  bool     `json:"vpn"`
+	IP_filtered          bool     `json:"ip_filtered"`
+	Readonly             bool     `json:"readonly"`
+	Authentication       string   `json:"authentication"`
+	Authorization        string   `json:"authorization"`
+	Usage                string   `json:"usage"`
+	Data_assets_sent     []string `json:"data_assets_sent"`
+	Data_assets_received []string `json:"data_assets_received"`
+}
+
+type PluginTrustBoundary struct {
+	ID                      string   `json:"id"`
+	Title                   string   `json:"title"`
+	Description             string   `json:"description"`
+	Type                    string   `json:"type"`
+	Tags                    []string `json:"tags"`
+	Technical_assets_inside []string `json:"technical_assets_inside"`
+	Trust_boundaries_nested []string `json:"trust_boundaries_nested"`
+}
+
+type PluginSharedRuntime struct {
+	ID                       string   `json:"id"`
+	Title                    string   `json:"title"`
+	Description              string   `json:"description"`
+	Tags                     []string `json:"tags"`
+	Technical_assets_running []string `json:"technical_assets_running"`
+}
+
+// PluginRiskCategory uses the same fields as the individual risk categories of the model YAML
+type PluginRiskCategory struct {
+	ID                            string `json:"id"`
+	Title                         string `json:"title"`
+	Description                   string `json:"description"`
+	Impact                        string `json:"impact"`
+	ASVS                          string `json:"asvs"`
+	Cheat_sheet                   string `json:"cheat_sheet"`
+	Action                        string `json:"action"`
+	Mitigation                    string `json:"mitigation"`
+	Check                         string `json:"check"`
+	Function                      string `json:"function"`
+	STRIDE                        string `json:"stride"`
+	Detection_logic               string `json:"detection_logic"`
+	Risk_assessment               string `json:"risk_assessment"`
+	False_positives               string `json:"false_positives"`
+	Model_failure_possible_reason bool   `json:"model_failure_possible_reason"`
+	CWE                           int    `json:"cwe"`
+
+	Compliance map[string][]string `json:"compliance,omitempty"` // controls by framework (like asvs, nist-800-53, iso-27001, pci-dss)
+}
+
+// PluginRisk uses the same fields as the risks JSON output
+type PluginRisk struct {
+	Severity                         string   `json:"severity"` // calculated from likelihood and impact when not given
+	Exploitation_likelihood          string   `json:"exploitation_likelihood"`
+	Exploitation_impact              string   `json:"exploitation_impact"`
+	Title                            string   `json:"title"`
+	Synthetic_id                     string   `json:"synthetic_id"`
+	Most_relevant_data_asset         string   `json:"most_relevant_data_asset"`
+	Most_relevant_technical_asset    string   `json:"most_relevant_technical_asset"`
+	Most_relevant_trust_boundary     string   `json:"most_relevant_trust_boundary"`
+	Most_relevant_shared_runtime     string   `json:"most_relevant_shared_runtime"`
+	Most_relevant_communication_link string   `json:"most_relevant_communication_link"`
+	Data_breach_probability          string   `json:"data_breach_probability"`
+	Data_breach_technical_assets     []string `json:"data_breach_technical_assets"`
+}
+
+// === Conversion of the model to be sent to plugins ========================================
+
+func pluginModelOf(parsedModel model.ParsedModel) *PluginModel {
+	result := &PluginModel{
+		Title:                parsedModel.Title,
+		Business_criticality: parsedModel.BusinessCriticality.String(),
+		Tags_available:       parsedModel.TagsAvailable,
+		Data_assets:          make(map[string]PluginDataAsset),
+		Technical_assets:     make(map[string]PluginTechnicalAsset),
+		Trust_boundaries:     make(map[string]PluginTrustBoundary),
+		Shared_runtimes:      make(map[string]PluginSharedRuntime),
+	}
+	for id, dataAsset := range parsedModel.DataAssets {
+		result.Data_assets[id] = PluginDataAsset{
+			ID:                       dataAsset.Id,
+			Title:                    dataAsset.Title,
+			Description:              dataAsset.Description,
+			Usage:                    dataAsset.Usage.String(),
+			Tags:                     dataAsset.Tags,
+			Origin:                   dataAsset.Origin,
+			Owner:                    dataAsset.Owner,
+			Quantity:                 dataAsset.Quantity.String(),
+			Confidentiality:          dataAsset.Confidentiality.String(),
+			Integrity:                dataAsset.Integrity.String(),
+			Availability:             dataAsset.Availability.String(),
+			Justification_cia_rating: dataAsset.JustificationCiaRating,
+		}
+	}
+	for id, technicalAsset := range parsedModel.TechnicalAssets {
+		dataFormatsAccepted := make([]string, 0)
+		for _, dataFormat := range technicalAsset.DataFormatsAccepted {
+			dataFormatsAccepted = append(dataFormatsAccepted, dataFormat.String())
+		}
+		communicationLinks := make([]PluginCommunicationLink, 0)
+		for _, commLink := range technicalAsset.CommunicationLinks {
+			communicationLinks = append(communicationLinks, PluginCommunicationLink{
+				ID:                   commLink.Id,
+				Source_id:            commLink.SourceId,
+				Target_id:            commLink.TargetId,
+				Title:                commLink.Title,
+				Description:          commLink.Description,
+				Protocol:             commLink.Protocol.String(),
+				Tags:                 commLink.Tags,
+				VPN:                  commLink.VPN,
+				IP_filtered:          commLink.IpFiltered,
+				Readonly:             commLink.Readonly,
+				Authentication:       commLink.Authentication.String(),
+				Authorization:        commLink.Authorization.String(),
+				Usage:                commLink.Usage.String(),
+				Data_assets_sent:     commLink.DataAssetsSent,
+				Data_assets_received: commLink.DataAssetsReceived,
+			})
+		}
+		result.Technical_assets[id] = PluginTechnicalAsset{
+			ID:                         technicalAsset.Id,
+			Title:                      technicalAsset.Title,
+			Description:                technicalAsset.Description,
+			Usage:                      technicalAsset.Usage.String(),
+			Type:                       technicalAsset.Type.String(),
+			Size:                       technicalAsset.Size.String(),
+			Technology:                 technicalAsset.Technology.String(),
+			Machine:                    technicalAsset.Machine.String(),
+			Internet:                   technicalAsset.Internet,
+			Multi_tenant:               technicalAsset.MultiTenant,
+			Redundant:                  technicalAsset.Redundant,
+			Custom_developed_parts:     technicalAsset.CustomDevelopedParts,
+			Out_of_scope:               technicalAsset.OutOfScope,
+			Used_as_client_by_human:    technicalAsset.UsedAsClientByHuman,
+			Encryption:                 technicalAsset.Encryption.String(),
+			Justification_out_of_scope: technicalAsset.JustificationOutOfScope,
+			Owner:                      technicalAsset.Owner,
+			Confidentiality:            technicalAsset.Confidentiality.String(),
+			Integrity:                  technicalAsset.Integrity.String(),
+			Availability:               technicalAsset.Availability.String(),
+			Justification_cia_rating:   technicalAsset.JustificationCiaRating,
+			Tags:                       technicalAsset.Tags,
+			Data_assets_processed:      technicalAsset.DataAssetsProcessed,
+			Data_assets_stored:         technicalAsset.DataAssetsStored,
+			Data_formats_accepted:      dataFormatsAccepted,
+			Communication_links:        communicationLinks,
+			RAA:                        technicalAsset.RAA,
+		}
+	}
+	for id, trustBoundary := range parsedModel.TrustBoundaries {
+		result.Trust_boundaries[id] = PluginTrustBoundary{
+			ID:                      trustBoundary.Id,
+			Title:                   trustBoundary.Title,
+			Description:             trustBoundary.Description,
+			Type:                    trustBoundary.Type.String(),
+			Tags:                    trustBoundary.Tags,
+			Technical_assets_inside: trustBoundary.TechnicalAssetsInside,
+			Trust_boundaries_nested: trustBoundary.TrustBoundariesNested,
+		}
+	}
+	for id, sharedRuntime := range parsedModel.SharedRuntimes {
+		result.Shared_runtimes[id] = PluginSharedRuntime{
+			ID:                       sharedRuntime.Id,
+			Title:                    sharedRuntime.Title,
+			Description:              sharedRuntime.Description,
+			Tags:                     sharedRuntime.Tags,
+			Technical_assets_running: sharedRuntime.TechnicalAssetsRunning,
+		}
+	}
+	return result
+}
+
+func pluginRiskCategoryOf(category model.RiskCategory) PluginRiskCategory {
+	return PluginRiskCategory{
+		ID:                            category.Id,
+		Title:                         category.Title,
+		Description:                   category.Description,
+		Impact:                        category.Impact,
+		ASVS:                          category.ASVS,
+		Cheat_sheet:                   category.CheatSheet,
+		Action:                        category.Action,
+		Mitigation:                    category.Mitigation,
+		Check:                         category.Check,
+		Function:                      category.Function.String(),
+		STRIDE:                        category.STRIDE.String(),
+		Detection_logic:               category.DetectionLogic,
+		Risk_assessment:               category.RiskAssessment,
+		False_positives:               category.FalsePositives,
+		Model_failure_possible_reason: category.ModelFailurePossibleReason,
+		CWE:                           category.CWE,
+	}
+}
+
+func pluginRiskOf(risk model.Risk) PluginRisk {
+	return PluginRisk{
+		Severity:                         risk.Severity.String(),
+		Exploitation_likelihood:          risk.ExploitationLikelihood.String(),
+		Exploitation_impact:              risk.ExploitationImpact.String(),
+		Title:                            risk.Title,
+		Synthetic_id:                     risk.SyntheticId,
+		Most_relevant_data_asset:         risk.MostRelevantDataAssetId,
+		Most_relevant_technical_asset:    risk.MostRelevantTechnicalAssetId,
+		Most_relevant_trust_boundary:     risk.MostRelevantTrustBoundaryId,
+		Most_relevant_shared_runtime:     risk.MostRelevantSharedRuntimeId,
+		Most_relevant_communication_link: risk.MostRelevantCommunicationLinkId,
+		Data_breach_probability:          risk.DataBreachProbability.String(),
+		Data_breach_technical_assets:     risk.DataBreachTechnicalAssetIDs,
+	}
+}
+
+// === Conversion of what is received from plugins (or by plugins) ========================================
+
+func riskCategoryOf(input PluginRiskCategory) model.RiskCategory {
+	where := "risk category '" + input.ID + "'"
+	if len(strings.TrimSpace(input.ID)) == 0 {
+		panic(errors.New("missing id of risk category"))
+	}
+	return model.RiskCategory{
+		Id:                         input.ID,
+		Title:                      withDefault(input.Title, input.ID),
+		Description:                input.Description,
+		Impact:                     input.Impact,
+		ASVS:                       input.ASVS,
+		CheatSheet:                 input.Cheat_sheet,
+		Action:                     input.Action,
+		Mitigation:                 input.Mitigation,
+		Check:                      input.Check,
+		Function:                   parseEnum(input.Function, "", model.RiskFunctionValues(), "function", where).(model.RiskFunction),
+		STRIDE:                     parseEnum(input.STRIDE, "", model.STRIDEValues(), "stride", where).(model.STRIDE),
+		DetectionLogic:             input.Detection_logic,
+		RiskAssessment:             input.Risk_assessment,
+		FalsePositives:             input.False_positives,
+		ModelFailurePossibleReason: input.Model_failure_possible_reason,
+		CWE:                        input.CWE,
+	}
+}
+
+func riskOf(input PluginRisk, category model.RiskCategory) model.Risk {
+	where := "risk '" + input.Synthetic_id + "'"
+	if len(strings.TrimSpace(input.Synthetic_id)) == 0 {
+		panic(errors.New("missing synthetic id of risk of category " + category.Id))
+	}
+	risk := model.Risk{
+		Category:                        category,
+		ExploitationLikelihood:          parseEnum(input.Exploitation_likelihood, model.Likely.String(), model.RiskExploitationLikelihoodValues(), "exploitation_likelihood", where).(model.RiskExploitationLikelihood),
+		ExploitationImpact:              parseEnum(input.Exploitation_impact, model.MediumImpact.String(), model.RiskExploitationImpactValues(), "exploitation_impact", where).(model.RiskExploitationImpact),
+		Title:                           withDefault(input.Title, input.Synthetic_id),
+		SyntheticId:                     input.Synthetic_id,
+		MostRelevantDataAssetId:         input.Most_relevant_data_asset,
+		MostRelevantTechnicalAssetId:    input.Most_relevant_technical_asset,
+		MostRelevantTrustBoundaryId:     input.Most_relevant_trust_boundary,
+		MostRelevantSharedRuntimeId:     input.Most_relevant_shared_runtime,
+		MostRelevantCommunicationLinkId: input.Most_relevant_communication_link,
+		DataBreachProbability:           parseEnum(input.Data_breach_probability, model.Possible.String(), model.DataBreachProbabilityValues(), "data_breach_probability", where).(model.DataBreachProbability),
+		DataBreachTechnicalAssetIDs:     input.Data_breach_technical_assets,
+	}
+	if risk.DataBreachTechnicalAssetIDs == nil {
+		risk.DataBreachTechnicalAssetIDs = make([]string, 0)
+	}
+	risk.Severity = model.CalculateSeverity(risk.ExploitationLikelihood, risk.ExploitationImpact)
+	if len(input.Severity) > 0 {
+		risk.Severity = parseEnum(input.Severity, "", model.RiskSeverityValues(), "severity", where).(model.RiskSeverity)
+	}
+	return risk
+}
+
+// activateModel points the package-level model variables to the model received (the way the model gets parsed within
+// Threagile), so that rules implementing model.CustomRiskRule work within plugins unchanged
+func activateModel(input PluginModel) {
+	model.Init()
+	parsedModel := model.ParsedModel{
+		Title:               input.Title,
+		BusinessCriticality: parseEnum(input.Business_criticality, model.Important.String(), model.CriticalityValues(), "business_criticality", "model").(model.Criticality),
+		TagsAvailable:       input.Tags_available,
+		DataAssets:          make(map[string]model.DataAsset),
+		TechnicalAssets:     make(map[string]model.TechnicalAsset),
+		TrustBoundaries:     make(map[string]model.TrustBoundary),
+		SharedRuntimes:      make(map[string]model.SharedRuntime),
+	}
+	for id, dataAsset := range input.Data_assets {
+		where := "data asset '" + id + "'"
+		parsedModel.DataAssets[id] = model.DataAsset{
+			Id:                     dataAsset.ID,
+			Title:                  dataAsset.Title,
+			Description:            dataAsset.Description,
+			Usage:                  parseEnum(dataAsset.Usage, "", model.UsageValues(), "usage", where).(model.Usage),
+			Tags:                   dataAsset.Tags,
+			Origin:                 dataAsset.Origin,
+			Owner:                  dataAsset.Owner,
+			Quantity:               parseEnum(dataAsset.Quantity, "", model.QuantityValues(), "quantity", where).(model.Quantity),
+			Confidentiality:        parseEnum(dataAsset.Confidentiality, "", model.ConfidentialityValues(), "confidentiality", where).(model.Confidentiality),
+			Integrity:              parseEnum(dataAsset.Integrity, "", model.CriticalityValues(), "integrity", where).(model.Criticality),
+			Availability:           parseEnum(dataAsset.Availability, "", model.CriticalityValues(), "availability", where).(model.Criticality),
+			JustificationCiaRating: dataAsset.Justification_cia_rating,
+		}
+	}
+	for _, id := range sortedKeysOfTechnicalAssets(input.Technical_assets) {
+		technicalAsset := input.Technical_assets[id]
+		where := "technical asset '" + id + "'"
+		dataFormatsAccepted := make([]model.DataFormat, 0)
+		for _, dataFormat := range technicalAsset.Data_formats_accepted {
+			dataFormatsAccepted = append(dataFormatsAccepted, parseEnum(dataFormat, "", model.DataFormatValues(), "data_formats_accepted", where).(model.DataFormat))
+		}
+		communicationLinks := make([]model.CommunicationLink, 0)
+		for _, commLink := range technicalAsset.Communication_links {
+			where := "communication link '" + commLink.ID + "'"
+			communicationLink := model.CommunicationLink{
+				Id:                 commLink.ID,
+				SourceId:           commLink.Source_id,
+				TargetId:           commLink.Target_id,
+				Title:              commLink.Title,
+				Description:        commLink.Description,
+				Protocol:           parseEnum(commLink.Protocol, "", model.ProtocolValues(), "protocol", where).(model.Protocol),
+				Tags:               commLink.Tags,
+				VPN:                commLink.VPN,
+				IpFiltered:         commLink.IP_filtered,
+				Readonly:           commLink.Readonly,
+				Authentication:     parseEnum(commLink.Authentication, "", model.AuthenticationValues(), "authentication", where).(model.Authentication),
+				Authorization:      parseEnum(commLink.Authorization, "", model.AuthorizationValues(), "authorization", where).(model.Authorization),
+				Usage:              parseEnum(commLink.Usage, "", model.UsageValues(), "usage", where).(model.Usage),
+				DataAssetsSent:     commLink.Data_assets_sent,
+				DataAssetsReceived: commLink.Data_assets_received,
+			}
+			communicationLinks = append(communicationLinks, communicationLink)
+			model.CommunicationLinks[communicationLink.Id] = communicationLink
+			model.IncomingTechnicalCommunicationLinksMappedByTargetId[communicationLink.TargetId] = append(
+				model.IncomingTechnicalCommunicationLinksMappedByTargetId[communicationLink.TargetId], communicationLink)
+		}
+		parsedModel.TechnicalAssets[id] = model.TechnicalAsset{
+			Id:                      technicalAsset.ID,
+			Title:                   technicalAsset.Title,
+			Description:             technicalAsset.Description,
+			Usage:                   parseEnum(technicalAsset.Usage, "", model.UsageValues(), "usage", where).(model.Usage),
+			Type:                    parseEnum(technicalAsset.Type, "", model.TechnicalAssetTypeValues(), "type", where).(model.TechnicalAssetType),
+			Size:                    parseEnum(technicalAsset.Size, "", model.TechnicalAssetSizeValues(), "size", where).(model.TechnicalAssetSize),
+			Technology:              parseEnum(technicalAsset.Technology, "", model.TechnicalAssetTechnologyValues(), "technology", where).(model.TechnicalAssetTechnology),
+			Machine:                 parseEnum(technicalAsset.Machine, "", model.TechnicalAssetMachineValues(), "machine", where).(model.TechnicalAssetMachine),
+			Internet:                technicalAsset.Internet,
+			MultiTenant:             technicalAsset.Multi_tenant,
+			Redundant:               technicalAsset.Redundant,
+			CustomDevelopedParts:    technicalAsset.Custom_developed_parts,
+			OutOfScope:              technicalAsset.Out_of_scope,
+			UsedAsClientByHuman:     technicalAsset.Used_as_client_by_human,
+			Encryption:              parseEnum(technicalAsset.Encryption, "", model.EncryptionStyleValues(), "encryption", where).(model.EncryptionStyle),
+			JustificationOutOfScope: technicalAsset.Justification_out_of_scope,
+			Owner:                   technicalAsset.Owner,
+			Confidentiality:         parseEnum(technicalAsset.Confidentiality, "", model.ConfidentialityValues(), "confidentiality", where).(model.Confidentiality),
+			Integrity:               parseEnum(technicalAsset.Integrity, "", model.CriticalityValues(), "integrity", where).(model.Criticality),
+			Availability:            parseEnum(technicalAsset.Availability, "", model.CriticalityValues(), "availability", where).(model.Criticality),
+			JustificationCiaRating:  technicalAsset.Justification_cia_rating,
+			Tags:                    technicalAsset.Tags,
+			DataAssetsProcessed:     technicalAsset.Data_assets_processed,
+			DataAssetsStored:        technicalAsset.Data_assets_stored,
+			DataFormatsAccepted:     dataFormatsAccepted,
+			CommunicationLinks:      communicationLinks,
+			RAA:                     technicalAsset.RAA,
+		}
+	}
+	for id, trustBoundary := range input.Trust_boundaries {
+		parsedModel.TrustBoundaries[id] = model.TrustBoundary{
+			Id:                    trustBoundary.ID,
+			Title:                 trustBoundary.Title,
+			Description:           trustBoundary.Description,
+			Type:                  parseEnum(trustBoundary.Type, "", model.TrustBoundaryTypeValues(), "type", "trust boundary '"+id+"'").(model.TrustBoundaryType),
+			Tags:                  trustBoundary.Tags,
+			TechnicalAssetsInside: trustBoundary.Technical_assets_inside,
+			TrustBoundariesNested: trustBoundary.Trust_boundaries_nested,
+		}
+		for _, technicalAssetId := range trustBoundary.Technical_assets_inside {
+			model.DirectContainingTrustBoundaryMappedByTechnicalAssetId[technicalAssetId] = parsedModel.TrustBoundaries[id]
+		}
+	}
+	for id, sharedRuntime := range input.Shared_runtimes {
+		parsedModel.SharedRuntimes[id] = model.SharedRuntime{
+			Id:                     sharedRuntime.ID,
+			Title:                  sharedRuntime.Title,
+			Description:            sharedRuntime.Description,
+			Tags:                   sharedRuntime.Tags,
+			TechnicalAssetsRunning: sharedRuntime.Technical_assets_running,
+		}
+		for _, technicalAssetId := range sharedRuntime.Technical_assets_running {
+			model.DirectContainingSharedRuntimeMappedByTechnicalAssetId[technicalAssetId] = parsedModel.SharedRuntimes[id]
+		}
+	}
+	model.ParsedModelRoot = parsedModel
+}
+
+func parseEnum(value string, defaultWhenEmpty string, values []model.TypeEnum, field string, where string) model.TypeEnum {
+	value = withDefault(strings.TrimSpace(value), defaultWhenEmpty)
+	for _, candidate := range values {
+		if candidate.String() == value {
+			return candidate
+		}
+	}
+	panic(errors.New("unknown '" + field + "' value of " + where + ": " + value))
+}
+
+func withDefault(value string, defaultWhenEmpty string) string {
+	if len(strings.TrimSpace(value)) == 0 {
+		return defaultWhenEmpty
+	}
+	return value
+}
+
+func sortedKeysOfTechnicalAssets(technicalAssets map[string]PluginTechnicalAsset) []string {
+	keys := make([]string, 0, len(technicalAssets))
+	for key := range technicalAssets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,120 @@
+package risks
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/model"
+)
+
+// RegisteredRiskRule is a risk rule known to the registry along with its metadata
+type RegisteredRiskRule struct {
+	ID               string // the risk category id
+	Rule             model.CustomRiskRule
+	BuiltIn          bool
+	EnabledByDefault bool // rules not enabled by default are only executed (and listed as checked in the report) when enabled explicitly
+}
+
+// IsEnabled tells whether the rule is enabled by default or explicitly via the comma-separated list of risk rules (by
+// their ID) to enable
+func (registeredRule RegisteredRiskRule) IsEnabled(enableRiskRules string) bool {
+	return registeredRule.EnabledByDefault || model.Contains(strings.Split(enableRiskRules, ","), registeredRule.ID)
+}
+
+func (registeredRule RegisteredRiskRule) Category() model.RiskCategory {
+	return registeredRule.Rule.Category()
+}
+
+func (registeredRule RegisteredRiskRule) SupportedTags() []string {
+	return registeredRule.Rule.SupportedTags()
+}
+
+func (registeredRule RegisteredRiskRule) GenerateRisks() []model.Risk {
+	return registeredRule.Rule.GenerateRisks()
+}
+
+// builtInRiskRule bundles the package-level functions of a built-in risk rule package as model.CustomRiskRule (and
+// model.ComplianceMappedRiskRule)
+type builtInRiskRule struct {
+	category           func() model.RiskCategory
+	supportedTags      func() []string
+	complianceControls func() map[string][]string
+	generateRisks      func() []model.Risk
+}
+
+func (rule builtInRiskRule) Category() model.RiskCategory {
+	return rule.category()
+}
+
+func (rule builtInRiskRule) SupportedTags() []string {
+	return rule.supportedTags()
+}
+
+func (rule builtInRiskRule) ComplianceControls() map[string][]string {
+	return rule.complianceControls()
+}
+
+func (rule builtInRiskRule) GenerateRisks() []model.Risk {
+	return rule.generateRisks()
+}
+
+// the default enablement of built-in risk rules (see RegisterBuiltInRiskRule): rules disabled by default are executed
+// only when enabled explicitly via -enable-risk-rules
+const EnabledByDefault, DisabledByDefault = true, false
+
+var builtInRiskRules = make(map[string]RegisteredRiskRule)
+
+// RegisterBuiltInRiskRule makes a built-in risk rule known to the registry along with the controls (by framework: ASVS
+// sections, NIST SP 800-53 controls, ISO/IEC 27001 Annex A controls and PCI DSS requirements) its risk category relates
+// to. Each built-in risk rule package calls it from its init function, so importing the package (via package built_in
+// importing all of them) is enough.
+func RegisterBuiltInRiskRule(category func() model.RiskCategory, supportedTags func() []string, complianceControls func() map[string][]string,
+	generateRisks func() []model.Risk, enabledByDefault bool) {
+	id := category().Id
+	if _, exists := builtInRiskRules[id]; exists {
+		panic(errors.New("risk rule registered twice: " + id))
+	}
+	builtInRiskRules[id] = RegisteredRiskRule{
+		ID: id,
+		Rule: builtInRiskRule{category: category, supportedTags: supportedTags, complianceControls: complianceControls,
+			generateRisks: generateRisks},
+		BuiltIn:          true,
+		EnabledByDefault: enabledByDefault,
+	}
+}
+
+// BuiltInRiskRules returns all registered built-in risk rules sorted by their id
+func BuiltInRiskRules() []RegisteredRiskRule {
+	result := make([]RegisteredRiskRule, 0, len(builtInRiskRules))
+	for _, registeredRule := range builtInRiskRules {
+		result = append(result, registeredRule)
+	}
+	sortByID(result)
+	return result
+}
+
+// CustomRiskRules returns the given custom risk rules (keyed by their id) in the registry's shape sorted by their id
+func CustomRiskRules(customRiskRules map[string]model.CustomRiskRule) []RegisteredRiskRule {
+	result := make([]RegisteredRiskRule, 0, len(customRiskRules))
+	for id, customRule := range customRiskRules {
+		result = append(result, RegisteredRiskRule{
+			ID:               id,
+			Rule:             customRule,
+			EnabledByDefault: true, // as custom risk rules are only loaded when given explicitly
+		})
+	}
+	sortByID(result)
+	return result
+}
+
+// AllRiskRules returns all built-in risk rules followed by the given custom risk rules (in the order of their execution)
+func AllRiskRules(customRiskRules map[string]model.CustomRiskRule) []RegisteredRiskRule {
+	return append(BuiltInRiskRules(), CustomRiskRules(customRiskRules)...)
+}
+
+func sortByID(rules []RegisteredRiskRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+}
@@ -0,0 +1,580 @@
+// Package yaml_rules provides custom risk rules declared in YAML files (as an alternative to compiling Go plugins):
+// Each rule consists of the risk category description, the conditions selecting the model elements at risk (technical
+// assets, communication links, data assets, or trust boundaries) and the rating of the resulting risks.
+package yaml_rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/threagile/threagile/model"
+	"gopkg.in/yaml.v3"
+)
+
+type InputRiskRules struct {
+	Risk_rules map[string]InputRiskRule `json:"risk_rules"`
+}
+
+type InputRiskRule struct {
+	ID                            string              `json:"id"`
+	Description                   string              `json:"description"`
+	Impact                        string              `json:"impact"`
+	ASVS                          string              `json:"asvs"`
+	Cheat_sheet                   string              `json:"cheat_sheet"`
+	Action                        string              `json:"action"`
+	Mitigation                    string              `json:"mitigation"`
+	Check                         string              `json:"check"`
+	Function                      string              `json:"function"`
+	STRIDE                        string              `json:"stride"`
+	Detection_logic               string              `json:"detection_logic"`
+	Risk_assessment               string              `json:"risk_assessment"`
+	False_positives               string              `json:"false_positives"`
+	Model_failure_possible_reason bool                `json:"model_failure_possible_reason"`
+	CWE                           int                 `json:"cwe"`
+	Compliance                    map[string][]string `json:"compliance"` // controls by framework (like asvs, nist-800-53, iso-27001, pci-dss)
+	Supported_tags                []string            `json:"supported_tags"`
+	Match                         InputMatch          `json:"match"`
+	Risk                          InputRisk           `json:"risk"`
+}
+
+// InputMatch selects the kind of model elements to check (exactly one of them must be given) along with the conditions
+// all of which must be fulfilled by an element for a risk to be generated
+type InputMatch struct {
+	Technical_assets    *InputTechnicalAssetConditions    `json:"technical_assets"`
+	Communication_links *InputCommunicationLinkConditions `json:"communication_links"`
+	Data_assets         *InputDataAssetConditions         `json:"data_assets"`
+	Trust_boundaries    *InputTrustBoundaryConditions     `json:"trust_boundaries"`
+}
+
+type InputTechnicalAssetConditions struct {
+	Technologies            []string `json:"technologies"`
+	Types                   []string `json:"types"`
+	Sizes                   []string `json:"sizes"`
+	Machines                []string `json:"machines"`
+	Encryption              []string `json:"encryption"`
+	Usage                   []string `json:"usage"`
+	Tags                    []string `json:"tags"` // any of them (also inherited from trust boundaries and shared runtimes)
+	Internet                *bool    `json:"internet"`
+	Multi_tenant            *bool    `json:"multi_tenant"`
+	Redundant               *bool    `json:"redundant"`
+	Custom_developed_parts  *bool    `json:"custom_developed_parts"`
+	Used_as_client_by_human *bool    `json:"used_as_client_by_human"`
+	Out_of_scope            *bool    `json:"out_of_scope"` // when not given only assets within scope match
+	Min_confidentiality     string   `json:"min_confidentiality"`
+	Min_integrity           string   `json:"min_integrity"`
+	Min_availability        string   `json:"min_availability"`
+}
+
+type InputCommunicationLinkConditions struct {
+	Protocols                          []string                       `json:"protocols"`
+	Authentication                     []string                       `json:"authentication"`
+	Authorization                      []string                       `json:"authorization"`
+	Usage                              []string                       `json:"usage"`
+	Tags                               []string                       `json:"tags"` // any of them
+	Across_trust_boundary              *bool                          `json:"across_trust_boundary"`
+	Across_trust_boundary_network_only *bool                          `json:"across_trust_boundary_network_only"`
+	VPN                                *bool                          `json:"vpn"`
+	IP_filtered                        *bool                          `json:"ip_filtered"`
+	Readonly                           *bool                          `json:"readonly"`
+	Min_confidentiality                string                         `json:"min_confidentiality"`
+	Min_integrity                      string                         `json:"min_integrity"`
+	Min_availability                   string                         `json:"min_availability"`
+	Source                             *InputTechnicalAssetConditions `json:"source"`
+	Target                             *InputTechnicalAssetConditions `json:"target"`
+}
+
+type InputDataAssetConditions struct {
+	Usage               []string `json:"usage"`
+	Quantity            []string `json:"quantity"`
+	Tags                []string `json:"tags"` // any of them
+	Min_confidentiality string   `json:"min_confidentiality"`
+	Min_integrity       string   `json:"min_integrity"`
+	Min_availability    string   `json:"min_availability"`
+}
+
+type InputTrustBoundaryConditions struct {
+	Types               []string `json:"types"`
+	Tags                []string `json:"tags"` // any of them
+	Min_confidentiality string   `json:"min_confidentiality"`
+	Min_integrity       string   `json:"min_integrity"`
+	Min_availability    string   `json:"min_availability"`
+}
+
+// InputRisk rates the generated risks, title and synthetic id are Go templates (see riskTemplateData for their fields)
+type InputRisk struct {
+	Severity                string `json:"severity"` // calculated from likelihood and impact when not given
+	Exploitation_likelihood string `json:"exploitation_likelihood"`
+	Exploitation_impact     string `json:"exploitation_impact"`
+	Data_breach_probability string `json:"data_breach_probability"`
+	Title                   string `json:"title"`
+	Synthetic_id            string `json:"synthetic_id"`
+}
+
+const defaultTitleTemplate, defaultSyntheticIdTemplate = "<b>{{.Category.Title}}</b> risk at <b>{{.Title}}</b>", "{{.Category.Id}}@{{.Id}}"
+
+// riskTemplateData is available within the title and synthetic id templates, Id and Title are those of the matched
+// element, the other fields are set as far as they apply to the kind of element matched
+type riskTemplateData struct {
+	Category          model.RiskCategory
+	Id, Title         string
+	TechnicalAsset    model.TechnicalAsset
+	CommunicationLink model.CommunicationLink
+	Source, Target    model.TechnicalAsset
+	DataAsset         model.DataAsset
+	TrustBoundary     model.TrustBoundary
+}
+
+type riskRule struct {
+	category              model.RiskCategory
+	supportedTags         []string
+	complianceControls    map[string][]string
+	technicalAssets       *technicalAssetConditions
+	communicationLinks    *communicationLinkConditions
+	dataAssets            *dataAssetConditions
+	trustBoundaries       *trustBoundaryConditions
+	severity              model.RiskSeverity
+	likelihood            model.RiskExploitationLikelihood
+	impact                model.RiskExploitationImpact
+	dataBreachProbability model.DataBreachProbability
+	title, syntheticId    *template.Template
+}
+
+type ratingConditions struct {
+	minConfidentiality            model.Confidentiality
+	minIntegrity, minAvailability model.Criticality
+}
+
+type technicalAssetConditions struct {
+	technologies, types, sizes, machines, encryption, usage                     map[string]bool
+	tags                                                                        []string
+	internet, multiTenant, redundant, customDevelopedParts, usedAsClientByHuman *bool
+	outOfScope                                                                  bool
+	rating                                                                      ratingConditions
+}
+
+type communicationLinkConditions struct {
+	protocols, authentication, authorization, usage                                map[string]bool
+	tags                                                                           []string
+	acrossTrustBoundary, acrossTrustBoundaryNetworkOnly, vpn, ipFiltered, readonly *bool
+	rating                                                                         ratingConditions
+	source, target                                                                 *technicalAssetConditions
+}
+
+type dataAssetConditions struct {
+	usage, quantity map[string]bool
+	tags            []string
+	rating          ratingConditions
+}
+
+type trustBoundaryConditions struct {
+	types  map[string]bool
+	tags   []string
+	rating ratingConditions
+}
+
+// LoadRiskRules reads the risk rules declared in the given YAML files keyed by their id
+func LoadRiskRules(filenames []string, verbose bool) (riskRules map[string]model.CustomRiskRule, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if recoveredError, ok := r.(error); ok {
+				err = recoveredError
+			} else {
+				err = errors.New(fmt.Sprintf("%v", r))
+			}
+		}
+	}()
+	riskRules = make(map[string]model.CustomRiskRule)
+	for _, filename := range filenames {
+		if verbose {
+			fmt.Println("Loading custom risk rules from YAML file:", filename)
+		}
+		data, err := ioutil.ReadFile(filename)
+		checkErr(err)
+		var input InputRiskRules
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true) // typos within conditions must not silently widen the match
+		err = decoder.Decode(&input)
+		if err != nil {
+			panic(errors.New("unable to parse custom risk rules YAML file " + filename + ": " + err.Error()))
+		}
+		for _, title := range sortedKeys(input.Risk_rules) {
+			rule := parseRiskRule(title, input.Risk_rules[title], filename)
+			if _, exists := riskRules[rule.category.Id]; exists {
+				panic(errors.New("duplicate custom risk rule id: " + rule.category.Id))
+			}
+			riskRules[rule.category.Id] = rule
+			if verbose {
+				fmt.Println("Custom risk rule loaded:", rule.category.Id)
+			}
+		}
+	}
+	return riskRules, nil
+}
+
+func parseRiskRule(title string, input InputRiskRule, filename string) riskRule {
+	where := "custom risk rule '" + title + "' in " + filename
+	if len(strings.TrimSpace(input.ID)) == 0 {
+		panic(errors.New("missing id of " + where))
+	}
+	rule := riskRule{
+		category: model.RiskCategory{
+			Id:                         strings.TrimSpace(input.ID),
+			Title:                      title,
+			Description:                withDefault(input.Description, title),
+			Impact:                     input.Impact,
+			ASVS:                       input.ASVS,
+			CheatSheet:                 input.Cheat_sheet,
+			Action:                     input.Action,
+			Mitigation:                 input.Mitigation,
+			Check:                      input.Check,
+			DetectionLogic:             input.Detection_logic,
+			RiskAssessment:             input.Risk_assessment,
+			FalsePositives:             input.False_positives,
+			Function:                   parseEnum(input.Function, "", model.RiskFunctionValues(), "function", where).(model.RiskFunction),
+			STRIDE:                     parseEnum(input.STRIDE, "", model.STRIDEValues(), "stride", where).(model.STRIDE),
+			ModelFailurePossibleReason: input.Model_failure_possible_reason,
+			CWE:                        input.CWE,
+		},
+		complianceControls:    model.MergeComplianceControls(input.Compliance),
+		likelihood:            parseEnum(input.Risk.Exploitation_likelihood, model.Likely.String(), model.RiskExploitationLikelihoodValues(), "exploitation_likelihood", where).(model.RiskExploitationLikelihood),
+		impact:                parseEnum(input.Risk.Exploitation_impact, model.MediumImpact.String(), model.RiskExploitationImpactValues(), "exploitation_impact", where).(model.RiskExploitationImpact),
+		dataBreachProbability: parseEnum(input.Risk.Data_breach_probability, model.Possible.String(), model.DataBreachProbabilityValues(), "data_breach_probability", where).(model.DataBreachProbability),
+		title:                 parseTemplate(withDefault(input.Risk.Title, defaultTitleTemplate), "title", where),
+		syntheticId:           parseTemplate(withDefault(input.Risk.Synthetic_id, defaultSyntheticIdTemplate), "synthetic_id", where),
+	}
+	rule.severity = model.CalculateSeverity(rule.likelihood, rule.impact)
+	if len(input.Risk.Severity) > 0 {
+		rule.severity = parseEnum(input.Risk.Severity, "", model.RiskSeverityValues(), "severity", where).(model.RiskSeverity)
+	}
+
+	matches := 0
+	tags := append(make([]string, 0), input.Supported_tags...)
+	if input.Match.Technical_assets != nil {
+		matches++
+		rule.technicalAssets = parseTechnicalAssetConditions(*input.Match.Technical_assets, where)
+		tags = append(tags, rule.technicalAssets.tags...)
+	}
+	if input.Match.Communication_links != nil {
+		matches++
+		rule.communicationLinks = parseCommunicationLinkConditions(*input.Match.Communication_links, where)
+		tags = append(tags, rule.communicationLinks.tags...)
+		tags = append(tags, rule.communicationLinks.source.tags...)
+		tags = append(tags, rule.communicationLinks.target.tags...)
+	}
+	if input.Match.Data_assets != nil {
+		matches++
+		rule.dataAssets = parseDataAssetConditions(*input.Match.Data_assets, where)
+		tags = append(tags, rule.dataAssets.tags...)
+	}
+	if input.Match.Trust_boundaries != nil {
+		matches++
+		rule.trustBoundaries = parseTrustBoundaryConditions(*input.Match.Trust_boundaries, where)
+		tags = append(tags, rule.trustBoundaries.tags...)
+	}
+	if matches != 1 {
+		panic(errors.New("exactly one of 'technical_assets', 'communication_links', 'data_assets', or 'trust_boundaries' must be given as 'match' of " + where))
+	}
+	rule.supportedTags = distinctLowerCase(tags)
+	return rule
+}
+
+func parseTechnicalAssetConditions(input InputTechnicalAssetConditions, where string) *technicalAssetConditions {
+	conditions := &technicalAssetConditions{
+		technologies:         parseEnumSet(input.Technologies, model.TechnicalAssetTechnologyValues(), "technologies", where),
+		types:                parseEnumSet(input.Types, model.TechnicalAssetTypeValues(), "types", where),
+		sizes:                parseEnumSet(input.Sizes, model.TechnicalAssetSizeValues(), "sizes", where),
+		machines:             parseEnumSet(input.Machines, model.TechnicalAssetMachineValues(), "machines", where),
+		encryption:           parseEnumSet(input.Encryption, model.EncryptionStyleValues(), "encryption", where),
+		usage:                parseEnumSet(input.Usage, model.UsageValues(), "usage", where),
+		tags:                 input.Tags,
+		internet:             input.Internet,
+		multiTenant:          input.Multi_tenant,
+		redundant:            input.Redundant,
+		customDevelopedParts: input.Custom_developed_parts,
+		usedAsClientByHuman:  input.Used_as_client_by_human,
+		rating:               parseRatingConditions(input.Min_confidentiality, input.Min_integrity, input.Min_availability, where),
+	}
+	if input.Out_of_scope != nil {
+		conditions.outOfScope = *input.Out_of_scope
+	}
+	return conditions
+}
+
+func parseCommunicationLinkConditions(input InputCommunicationLinkConditions, where string) *communicationLinkConditions {
+	conditions := &communicationLinkConditions{
+		protocols:                      parseEnumSet(input.Protocols, model.ProtocolValues(), "protocols", where),
+		authentication:                 parseEnumSet(input.Authentication, model.AuthenticationValues(), "authentication", where),
+		authorization:                  parseEnumSet(input.Authorization, model.AuthorizationValues(), "authorization", where),
+		usage:                          parseEnumSet(input.Usage, model.UsageValues(), "usage", where),
+		tags:                           input.Tags,
+		acrossTrustBoundary:            input.Across_trust_boundary,
+		acrossTrustBoundaryNetworkOnly: input.Across_trust_boundary_network_only,
+		vpn:                            input.VPN,
+		ipFiltered:                     input.IP_filtered,
+		readonly:                       input.Readonly,
+		rating:                         parseRatingConditions(input.Min_confidentiality, input.Min_integrity, input.Min_availability, where),
+	}
+	// both ends need to be within scope by default
+	source, target := InputTechnicalAssetConditions{}, InputTechnicalAssetConditions{}
+	if input.Source != nil {
+		source = *input.Source
+	}
+	if input.Target != nil {
+		target = *input.Target
+	}
+	conditions.source = parseTechnicalAssetConditions(source, where+" (source)")
+	conditions.target = parseTechnicalAssetConditions(target, where+" (target)")
+	return conditions
+}
+
+func parseDataAssetConditions(input InputDataAssetConditions, where string) *dataAssetConditions {
+	return &dataAssetConditions{
+		usage:    parseEnumSet(input.Usage, model.UsageValues(), "usage", where),
+		quantity: parseEnumSet(input.Quantity, model.QuantityValues(), "quantity", where),
+		tags:     input.Tags,
+		rating:   parseRatingConditions(input.Min_confidentiality, input.Min_integrity, input.Min_availability, where),
+	}
+}
+
+func parseTrustBoundaryConditions(input InputTrustBoundaryConditions, where string) *trustBoundaryConditions {
+	return &trustBoundaryConditions{
+		types:  parseEnumSet(input.Types, model.TrustBoundaryTypeValues(), "types", where),
+		tags:   input.Tags,
+		rating: parseRatingConditions(input.Min_confidentiality, input.Min_integrity, input.Min_availability, where),
+	}
+}
+
+func parseRatingConditions(minConfidentiality, minIntegrity, minAvailability string, where string) ratingConditions {
+	return ratingConditions{
+		minConfidentiality: parseEnum(minConfidentiality, model.Public.String(), model.ConfidentialityValues(), "min_confidentiality", where).(model.Confidentiality),
+		minIntegrity:       parseEnum(minIntegrity, model.Archive.String(), model.CriticalityValues(), "min_integrity", where).(model.Criticality),
+		minAvailability:    parseEnum(minAvailability, model.Archive.String(), model.CriticalityValues(), "min_availability", where).(model.Criticality),
+	}
+}
+
+func parseEnum(value string, defaultWhenEmpty string, values []model.TypeEnum, field string, where string) model.TypeEnum {
+	value = withDefault(strings.TrimSpace(value), defaultWhenEmpty)
+	for _, candidate := range values {
+		if candidate.String() == value {
+			return candidate
+		}
+	}
+	panic(errors.New("unknown '" + field + "' value of " + where + ": " + value))
+}
+
+func parseEnumSet(values []string, candidates []model.TypeEnum, field string, where string) map[string]bool {
+	result := make(map[string]bool)
+	for _, value := range values {
+		result[parseEnum(value, "", candidates, field, where).String()] = true
+	}
+	return result
+}
+
+func parseTemplate(text string, field string, where string) *template.Template {
+	parsedTemplate, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		panic(errors.New("invalid '" + field + "' template of " + where + ": " + err.Error()))
+	}
+	return parsedTemplate
+}
+
+func (rule riskRule) Category() model.RiskCategory {
+	return rule.category
+}
+
+func (rule riskRule) SupportedTags() []string {
+	return rule.supportedTags
+}
+
+func (rule riskRule) ComplianceControls() map[string][]string {
+	return rule.complianceControls
+}
+
+func (rule riskRule) GenerateRisks() []model.Risk {
+	risks := make([]model.Risk, 0)
+	for _, id := range model.SortedTechnicalAssetIDs() {
+		technicalAsset := model.ParsedModelRoot.TechnicalAssets[id]
+		if rule.technicalAssets != nil && rule.technicalAssets.matches(technicalAsset) {
+			risks = append(risks, rule.createRisk(riskTemplateData{
+				Id:             technicalAsset.Id,
+				Title:          technicalAsset.Title,
+				TechnicalAsset: technicalAsset,
+			}, technicalAsset.Id, []string{technicalAsset.Id}, func(risk *model.Risk) {
+				risk.MostRelevantTechnicalAssetId = technicalAsset.Id
+			}))
+		}
+		if rule.communicationLinks != nil {
+			for _, communicationLink := range technicalAsset.CommunicationLinksSorted() {
+				target := model.ParsedModelRoot.TechnicalAssets[communicationLink.TargetId]
+				if rule.communicationLinks.matches(communicationLink, technicalAsset, target) {
+					risks = append(risks, rule.createRisk(riskTemplateData{
+						Id:                communicationLink.Id,
+						Title:             communicationLink.Title,
+						CommunicationLink: communicationLink,
+						Source:            technicalAsset,
+						Target:            target,
+					}, communicationLink.Id, []string{target.Id}, func(risk *model.Risk) {
+						risk.MostRelevantCommunicationLinkId = communicationLink.Id
+						risk.MostRelevantTechnicalAssetId = target.Id
+					}))
+				}
+			}
+		}
+	}
+	if rule.dataAssets != nil {
+		for _, id := range model.SortedKeysOfDataAssets() {
+			dataAsset := model.ParsedModelRoot.DataAssets[id]
+			if rule.dataAssets.matches(dataAsset) {
+				dataBreachTechnicalAssetIDs := make([]string, 0)
+				for _, technicalAsset := range dataAsset.ProcessedByTechnicalAssetsSorted() {
+					dataBreachTechnicalAssetIDs = append(dataBreachTechnicalAssetIDs, technicalAsset.Id)
+				}
+				risks = append(risks, rule.createRisk(riskTemplateData{
+					Id:        dataAsset.Id,
+					Title:     dataAsset.Title,
+					DataAsset: dataAsset,
+				}, dataAsset.Id, dataBreachTechnicalAssetIDs, func(risk *model.Risk) {
+					risk.MostRelevantDataAssetId = dataAsset.Id
+				}))
+			}
+		}
+	}
+	if rule.trustBoundaries != nil {
+		for _, id := range model.SortedKeysOfTrustBoundaries() {
+			trustBoundary := model.ParsedModelRoot.TrustBoundaries[id]
+			if rule.trustBoundaries.matches(trustBoundary) {
+				risks = append(risks, rule.createRisk(riskTemplateData{
+					Id:            trustBoundary.Id,
+					Title:         trustBoundary.Title,
+					TrustBoundary: trustBoundary,
+				}, trustBoundary.Id, trustBoundary.RecursivelyAllTechnicalAssetIDsInside(), func(risk *model.Risk) {
+					risk.MostRelevantTrustBoundaryId = trustBoundary.Id
+				}))
+			}
+		}
+	}
+	return risks
+}
+
+func (rule riskRule) createRisk(data riskTemplateData, elementId string, dataBreachTechnicalAssetIDs []string, setMostRelevant func(risk *model.Risk)) model.Risk {
+	data.Category = rule.category
+	risk := model.Risk{
+		Category:                    rule.category,
+		Severity:                    rule.severity,
+		ExploitationLikelihood:      rule.likelihood,
+		ExploitationImpact:          rule.impact,
+		Title:                       executeTemplate(rule.title, data, rule.category.Id, elementId),
+		DataBreachProbability:       rule.dataBreachProbability,
+		DataBreachTechnicalAssetIDs: dataBreachTechnicalAssetIDs,
+	}
+	setMostRelevant(&risk)
+	risk.SyntheticId = executeTemplate(rule.syntheticId, data, rule.category.Id, elementId)
+	return risk
+}
+
+func executeTemplate(parsedTemplate *template.Template, data riskTemplateData, ruleId, elementId string) string {
+	var result strings.Builder
+	err := parsedTemplate.Execute(&result, data)
+	if err != nil {
+		panic(errors.New("unable to render '" + parsedTemplate.Name() + "' of custom risk rule " + ruleId + " for " + elementId + ": " + err.Error()))
+	}
+	return result.String()
+}
+
+func (conditions *technicalAssetConditions) matches(technicalAsset model.TechnicalAsset) bool {
+	return technicalAsset.OutOfScope == conditions.outOfScope &&
+		matchesEnum(conditions.technologies, technicalAsset.Technology) &&
+		matchesEnum(conditions.types, technicalAsset.Type) &&
+		matchesEnum(conditions.sizes, technicalAsset.Size) &&
+		matchesEnum(conditions.machines, technicalAsset.Machine) &&
+		matchesEnum(conditions.encryption, technicalAsset.Encryption) &&
+		matchesEnum(conditions.usage, technicalAsset.Usage) &&
+		(len(conditions.tags) == 0 || technicalAsset.IsTaggedWithAnyTraversingUp(conditions.tags...)) &&
+		matchesFlag(conditions.internet, technicalAsset.Internet) &&
+		matchesFlag(conditions.multiTenant, technicalAsset.MultiTenant) &&
+		matchesFlag(conditions.redundant, technicalAsset.Redundant) &&
+		matchesFlag(conditions.customDevelopedParts, technicalAsset.CustomDevelopedParts) &&
+		matchesFlag(conditions.usedAsClientByHuman, technicalAsset.UsedAsClientByHuman) &&
+		conditions.rating.matches(technicalAsset.HighestConfidentiality(), technicalAsset.HighestIntegrity(), technicalAsset.HighestAvailability())
+}
+
+func (conditions *communicationLinkConditions) matches(communicationLink model.CommunicationLink, source, target model.TechnicalAsset) bool {
+	return matchesEnum(conditions.protocols, communicationLink.Protocol) &&
+		matchesEnum(conditions.authentication, communicationLink.Authentication) &&
+		matchesEnum(conditions.authorization, communicationLink.Authorization) &&
+		matchesEnum(conditions.usage, communicationLink.Usage) &&
+		(len(conditions.tags) == 0 || communicationLink.IsTaggedWithAny(conditions.tags...)) &&
+		matchesFlag(conditions.acrossTrustBoundary, communicationLink.IsAcrossTrustBoundary()) &&
+		matchesFlag(conditions.acrossTrustBoundaryNetworkOnly, communicationLink.IsAcrossTrustBoundaryNetworkOnly()) &&
+		matchesFlag(conditions.vpn, communicationLink.VPN) &&
+		matchesFlag(conditions.ipFiltered, communicationLink.IpFiltered) &&
+		matchesFlag(conditions.readonly, communicationLink.Readonly) &&
+		conditions.rating.matches(communicationLink.HighestConfidentiality(), communicationLink.HighestIntegrity(), communicationLink.HighestAvailability()) &&
+		conditions.source.matches(source) &&
+		conditions.target.matches(target)
+}
+
+func (conditions *dataAssetConditions) matches(dataAsset model.DataAsset) bool {
+	return matchesEnum(conditions.usage, dataAsset.Usage) &&
+		matchesEnum(conditions.quantity, dataAsset.Quantity) &&
+		(len(conditions.tags) == 0 || dataAsset.IsTaggedWithAny(conditions.tags...)) &&
+		conditions.rating.matches(dataAsset.Confidentiality, dataAsset.Integrity, dataAsset.Availability)
+}
+
+func (conditions *trustBoundaryConditions) matches(trustBoundary model.TrustBoundary) bool {
+	return matchesEnum(conditions.types, trustBoundary.Type) &&
+		(len(conditions.tags) == 0 || trustBoundary.IsTaggedWithAnyTraversingUp(conditions.tags...)) &&
+		conditions.rating.matches(trustBoundary.HighestConfidentiality(), trustBoundary.HighestIntegrity(), trustBoundary.HighestAvailability())
+}
+
+func (conditions ratingConditions) matches(confidentiality model.Confidentiality, integrity, availability model.Criticality) bool {
+	return confidentiality >= conditions.minConfidentiality && integrity >= conditions.minIntegrity && availability >= conditions.minAvailability
+}
+
+func matchesEnum(values map[string]bool, value model.TypeEnum) bool {
+	return len(values) == 0 || values[value.String()]
+}
+
+func matchesFlag(expected *bool, value bool) bool {
+	return expected == nil || *expected == value
+}
+
+func withDefault(value string, defaultWhenEmpty string) string {
+	if len(strings.TrimSpace(value)) == 0 {
+		return defaultWhenEmpty
+	}
+	return value
+}
+
+func distinctLowerCase(values []string) []string {
+	distinct := make(map[string]bool)
+	for _, value := range values {
+		distinct[strings.ToLower(strings.TrimSpace(value))] = true
+	}
+	result := make([]string, 0, len(distinct))
+	for value := range distinct {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedKeys(riskRules map[string]InputRiskRule) []string {
+	keys := make([]string, 0, len(riskRules))
+	for key := range riskRules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func checkErr(err error) {
+	if err != nil {
+		panic(err)
+	}
+}